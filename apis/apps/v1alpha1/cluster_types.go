@@ -19,9 +19,12 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -168,6 +171,23 @@ type ClusterSpec struct {
 	// +optional
 	Backup *ClusterBackup `json:"backup,omitempty"`
 
+	// Specifies the recurring maintenance window of the Cluster.
+	//
+	// Disruptive OpsRequests, such as Restart, VerticalScaling, Upgrade and Reconfiguring that requires a restart,
+	// are kept in the `Scheduled` phase until the window opens.
+	// An OpsRequest can override this window with its own `spec.maintenanceWindow`.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies the NetworkPolicies generated for the Components of the Cluster.
+	//
+	// When enabled, the pods of each Component only accept the traffic from the pods of the same Cluster,
+	// the KubeBlocks, lorry and dataprotection pods, and the clients listed in `allowedClients`.
+	//
+	// +optional
+	NetworkPolicy *ClusterNetworkPolicy `json:"networkPolicy,omitempty"`
+
 	// !!!!! The following fields may be deprecated in subsequent versions, please DO NOT rely on them for new requirements.
 
 	// Describes how pods are distributed across node.
@@ -294,6 +314,48 @@ type ClusterBackup struct {
 	PITREnabled *bool `json:"pitrEnabled,omitempty"`
 }
 
+// MaintenanceWindow defines a recurring time window in which disruptive operations are allowed to start.
+type MaintenanceWindow struct {
+	// Specifies when each window opens, using a standard five-field cron expression.
+	// For example, `0 2 * * 6` opens the window at 02:00 every Saturday.
+	//
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// Specifies how long each window stays open, such as `2h` or `90m`.
+	//
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// Specifies the IANA time zone used to interpret the schedule, such as `Asia/Shanghai`.
+	// If not set, the schedule is interpreted in UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ClusterNetworkPolicy defines the NetworkPolicies generated for the Components of the Cluster.
+type ClusterNetworkPolicy struct {
+	// Specifies whether to generate the NetworkPolicies.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Specifies the clients allowed to access the service ports of the Components,
+	// selected by namespaces, pods or IP blocks.
+	//
+	// The following traffic is always allowed:
+	//
+	// - from the pods of the same Component to the container ports and the lorry port, for the replication.
+	// - from the pods of the same Cluster to the service ports.
+	// - from the KubeBlocks and dataprotection pods to the service ports and the lorry port.
+	// - from anywhere to the metrics ports of the exporters defined by the ComponentDefinition, for the scraping.
+	//
+	// +optional
+	AllowedClients []networkingv1.NetworkPolicyPeer `json:"allowedClients,omitempty"`
+}
+
 // ClusterResources is deprecated since v0.9.
 type ClusterResources struct {
 	// Specifies the amount of processing power the cluster needs.
@@ -840,6 +902,26 @@ type ClusterComponentSpec struct {
 	//
 	// +optional
 	MonitorEnabled *bool `json:"monitorEnabled,omitempty"`
+
+	// Specifies the policy to automatically rebuild the instances which are unrecoverable.
+	// If not set, the instances have to be rebuilt manually by a RebuildInstance OpsRequest.
+	//
+	// +optional
+	AutoHeal *AutoHealPolicy `json:"autoHeal,omitempty"`
+
+	// Specifies how the instances are spread across the zones, and whether they are rebalanced
+	// when they are concentrated in some of the zones, such as after the node failures.
+	//
+	// +optional
+	InstancePlacement *InstancePlacementPolicy `json:"instancePlacement,omitempty"`
+
+	// Overrides the settings of the system accounts defined in the ComponentDefinition,
+	// such as the policy to rotate the passwords.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SystemAccounts []ComponentSystemAccount `json:"systemAccounts,omitempty"`
 }
 
 type ComponentMessageMap map[string]string
@@ -1100,17 +1182,113 @@ type TLSConfig struct {
 	Issuer *Issuer `json:"issuer,omitempty"`
 }
 
+// AutoHealPolicy defines how the unrecoverable instances of a component are rebuilt automatically.
+//
+// An instance is considered unrecoverable if:
+//
+// - it stays failed, such as crash-looping or unschedulable, for longer than the `unhealthyThreshold`, or
+// - one of its PVCs is bound to a node that no longer exists.
+//
+// The instance is rebuilt by a RebuildInstance OpsRequest created by the controller.
+// The current leader (the instance with a writable role) is never rebuilt automatically.
+type AutoHealPolicy struct {
+	// Specifies how long an instance stays failed before it is rebuilt, such as `10m` or `1h`.
+	//
+	// +kubebuilder:default="10m"
+	// +optional
+	UnhealthyThreshold metav1.Duration `json:"unhealthyThreshold,omitempty"`
+
+	// Specifies the minimum interval between two automatic rebuilds of the component, such as `30m`.
+	//
+	// +kubebuilder:default="30m"
+	// +optional
+	MinInterval metav1.Duration `json:"minInterval,omitempty"`
+
+	// Specifies the maximum number of instances rebuilt by a single automatic RebuildInstance OpsRequest.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxInstances int32 `json:"maxInstances,omitempty"`
+
+	// Indicates whether to rebuild the instances by streaming the data from a healthy peer.
+	// If false, the instances are rebuilt with empty volumes and are expected to catch up by replication.
+	//
+	// +optional
+	FromPeer bool `json:"fromPeer,omitempty"`
+}
+
+// InstancePlacementPolicy defines how the instances of a component are spread across the topology domains,
+// such as the zones, and how they are rebalanced.
+//
+// The domain of each instance is tracked by the InstanceSet of the component. If the skew exceeds the `maxSkew`
+// and the rebalance is enabled, a follower in the most populated domain is rebuilt in the least populated domain
+// by a RebuildInstance OpsRequest created by the controller. The leader (the instance with a writable role)
+// is never rebuilt, and only one instance is rebuilt at a time while the component is Running.
+type InstancePlacementPolicy struct {
+	workloads.InstancePlacementPolicy `json:",inline"`
+
+	// Indicates whether to rebuild the instances in the under-represented domains when the skew exceeds the `maxSkew`.
+	// The instances are rebuilt with empty volumes and are expected to catch up by replication.
+	//
+	// +optional
+	Rebalance bool `json:"rebalance,omitempty"`
+
+	// Specifies the minimum interval between two rebuilds for the rebalance, such as `30m`.
+	//
+	// +kubebuilder:default="30m"
+	// +optional
+	MinInterval metav1.Duration `json:"minInterval,omitempty"`
+}
+
+// ComponentSystemAccount overrides the settings of a system account defined in the ComponentDefinition.
+type ComponentSystemAccount struct {
+	// The name of the system account defined in the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the policy to rotate the password of the account periodically.
+	// If not set, the password is generated once and never changed.
+	//
+	// +optional
+	PasswordRotation *PasswordRotationPolicy `json:"passwordRotation,omitempty"`
+}
+
+// PasswordRotationPolicy defines how the password of a system account is rotated.
+//
+// The new password is generated by the PasswordGenerationPolicy of the account, and applied to the database
+// through the AccountProvision lifecycle action. Then the account secret is updated, and the instances are
+// restarted to pick up the new password.
+type PasswordRotationPolicy struct {
+	// Specifies how often the password is rotated, such as `2160h` for 90 days.
+	//
+	// +kubebuilder:validation:Required
+	Period metav1.Duration `json:"period"`
+
+	// Specifies how long the old password keeps working after the new one is applied, such as `30m`,
+	// it should be long enough to restart all the instances.
+	// It only takes effect for the engines which support multiple passwords for an account,
+	// such as MySQL 8.0.14+ and Redis, the old password is replaced immediately for the others.
+	//
+	// +optional
+	OverlapPeriod *metav1.Duration `json:"overlapPeriod,omitempty"`
+}
+
 // Issuer defines the TLS certificates issuer for the cluster.
 type Issuer struct {
 	// The issuer for TLS certificates.
-	// It only allows two enum values: `KubeBlocks` and `UserProvided`.
+	// It only allows three enum values: `KubeBlocks`, `UserProvided` and `CertManager`.
 	//
 	// - `KubeBlocks` indicates that the self-signed TLS certificates generated by the KubeBlocks Operator will be used.
+	//   The certificates are rotated automatically before they expire.
 	// - `UserProvided` means that the user is responsible for providing their own CA, Cert, and Key.
 	//   In this case, the user-provided CA certificate, server certificate, and private key will be used
 	//   for TLS communication.
+	// - `CertManager` indicates that the certificates are issued and renewed by cert-manager,
+	//   a Certificate resource is created for the component.
 	//
-	// +kubebuilder:validation:Enum={KubeBlocks, UserProvided}
+	// +kubebuilder:validation:Enum={KubeBlocks, UserProvided, CertManager}
 	// +kubebuilder:default=KubeBlocks
 	// +kubebuilder:validation:Required
 	Name IssuerName `json:"name"`
@@ -1120,6 +1298,44 @@ type Issuer struct {
 	//
 	// +optional
 	SecretRef *TLSSecretRef `json:"secretRef,omitempty"`
+
+	// CertManager references the cert-manager Issuer or ClusterIssuer which signs the certificates.
+	// It is required when the issuer is set to `CertManager`.
+	//
+	// +optional
+	CertManager *CertManagerIssuerRef `json:"certManager,omitempty"`
+
+	// Specifies the validity duration of the certificates issued by `KubeBlocks` or `CertManager`.
+	// Defaults to 3650 days for `KubeBlocks`, and to the default duration of cert-manager for `CertManager`.
+	//
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Specifies how long before the expiry the certificates issued by `KubeBlocks` or `CertManager` are renewed.
+	// Defaults to one third of the validity duration.
+	//
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CertManagerIssuerRef references a cert-manager Issuer or ClusterIssuer.
+type CertManagerIssuerRef struct {
+	// Name of the Issuer or ClusterIssuer.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Kind of the issuer, either `Issuer` in the namespace of the cluster or `ClusterIssuer`.
+	//
+	// +kubebuilder:validation:Enum={Issuer,ClusterIssuer}
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, defaults to `cert-manager.io`. It is required for the external issuers of cert-manager.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // TLSSecretRef defines Secret contains Tls certs
@@ -1457,6 +1673,20 @@ func (r ClusterSpec) GetDefNameMappingComponents() map[string][]ClusterComponent
 	return m
 }
 
+// Validate checks whether the schedule, duration and time zone of the maintenance window are valid.
+func (r MaintenanceWindow) Validate() error {
+	if _, err := cron.ParseStandard(r.Schedule); err != nil {
+		return fmt.Errorf(`invalid maintenance window schedule "%s": %s`, r.Schedule, err.Error())
+	}
+	if r.Duration.Duration <= 0 {
+		return fmt.Errorf(`the duration of maintenance window must be greater than 0, current is "%s"`, r.Duration.Duration)
+	}
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return fmt.Errorf(`invalid maintenance window time zone "%s": %s`, r.TimeZone, err.Error())
+	}
+	return nil
+}
+
 // GetMessage gets message map deep copy object.
 func (r ClusterComponentStatus) GetMessage() ComponentMessageMap {
 	messageMap := map[string]string{}
@@ -216,5 +216,8 @@ func (r *Cluster) validateComponentTLSSettings(allErrs *field.ErrorList) {
 		if component.Issuer.Name == IssuerUserProvided && component.Issuer.SecretRef == nil {
 			*allErrs = append(*allErrs, field.Required(field.NewPath(fmt.Sprintf("spec.components[%d].issuer.secretRef", index)), "Secret must provide when issuer name is UserProvided"))
 		}
+		if component.Issuer.Name == IssuerCertManager && component.Issuer.CertManager == nil {
+			*allErrs = append(*allErrs, field.Required(field.NewPath(fmt.Sprintf("spec.components[%d].issuer.certManager", index)), "CertManager must provide when issuer name is CertManager"))
+		}
 	}
 }
@@ -179,6 +179,10 @@ var _ = Describe("cluster webhook", func() {
 			cluster.Spec.ComponentSpecs[0].Issuer = &Issuer{Name: IssuerUserProvided}
 			Expect(testCtx.CreateObj(ctx, cluster)).ShouldNot(Succeed())
 
+			By("creating cluster with nil cert-manager issuer ref")
+			cluster.Spec.ComponentSpecs[0].Issuer = &Issuer{Name: IssuerCertManager}
+			Expect(testCtx.CreateObj(ctx, cluster)).ShouldNot(Succeed())
+
 			By("creating cluster with KubeBlocks issuer")
 			cluster.Spec.ComponentSpecs[0].Issuer = &Issuer{Name: IssuerKubeBlocks}
 			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
@@ -233,6 +233,28 @@ type ComponentSpec struct {
 	//
 	// +optional
 	MonitorEnabled *bool `json:"monitorEnabled,omitempty"`
+
+	// Specifies the policy to automatically rebuild the instances which are unrecoverable.
+	//
+	// +optional
+	AutoHeal *AutoHealPolicy `json:"autoHeal,omitempty"`
+
+	// Specifies how the instances are spread across the zones, and whether they are rebalanced.
+	//
+	// +optional
+	InstancePlacement *InstancePlacementPolicy `json:"instancePlacement,omitempty"`
+
+	// Overrides the settings of the system accounts defined in the ComponentDefinition.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SystemAccounts []ComponentSystemAccount `json:"systemAccounts,omitempty"`
+
+	// Specifies the NetworkPolicy generated for the Component.
+	//
+	// +optional
+	NetworkPolicy *ClusterNetworkPolicy `json:"networkPolicy,omitempty"`
 }
 
 // ComponentStatus represents the observed state of a Component within the cluster.
@@ -265,6 +287,45 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message ComponentMessageMap `json:"message,omitempty"`
+
+	// Records the TLS certificate used by the component when TLS is enabled.
+	//
+	// +optional
+	TLSCertificate *TLSCertificateStatus `json:"tlsCertificate,omitempty"`
+}
+
+// TLSCertificateStatus records the TLS certificate used by a component.
+type TLSCertificateStatus struct {
+	// The name of the Secret which contains the certificate.
+	//
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// The serial number of the certificate in hex, it changes when the certificate is rotated.
+	//
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// The serial number of the certificate which has been reloaded by all the instances.
+	// It is only set if the ComponentDefinition reloads the certificates dynamically.
+	//
+	// +optional
+	ReloadedSerialNumber string `json:"reloadedSerialNumber,omitempty"`
+
+	// The time when the certificate becomes valid.
+	//
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// The time when the certificate expires.
+	//
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// The time when the certificate is going to be renewed, it is empty for the user-provided certificates.
+	//
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 }
 
 // +genclient
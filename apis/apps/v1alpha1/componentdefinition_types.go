@@ -435,6 +435,10 @@ type ComponentDefinitionSpec struct {
 	//   For example, consider a component with 5 replicas. To maintain the component's availability and quorum,
 	//   the operator may allow a maximum of 2 replicas to be simultaneously updated. This ensures that at least
 	//   3 replicas (a quorum) remain available and functional during the update process.
+	// - `BlueGreen`: A parallel set of replicas is brought up on the new revision, and the Component is switched
+	//   to them after they are ready. The replicas of the new set are named after the workload with the `-green` suffix,
+	//   or without the suffix if the replicas of the current set have it. The old replicas are kept for an hour
+	//   to roll back the update, and the leader role is taken over by the new replicas after the old ones are torn down.
 	//
 	// This field is immutable.
 	//
@@ -523,6 +527,29 @@ type ComponentDefinitionSpec struct {
 	// +kubebuilder:default=0
 	// +optional
 	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// Specifies whether the leader is protected from the voluntary disruptions, such as the evictions triggered by
+	// node drains.
+	//
+	// If it is true, the leader can be evicted only after a switchover has moved the leadership to another replica,
+	// so the node drains are blocked until then. It should be enabled only if the switchover is performed before
+	// draining the nodes, otherwise the node drains will not complete.
+	// The quorum of the voting replicas is protected regardless of it.
+	//
+	// +optional
+	ProtectLeaderFromEviction bool `json:"protectLeaderFromEviction,omitempty"`
+
+	// Specifies how the engine picks up the TLS certificates after they are rotated.
+	//
+	// - `Restart`: The replicas are restarted in a rolling manner, following the `updateStrategy`.
+	// - `Reload`: The engine reloads the certificates from the mounted files dynamically,
+	//   the files are refreshed in place by the kubelet, so the replicas are not restarted.
+	//   The `reconfigure` lifecycle action is required, it is invoked on each replica to reload the certificates
+	//   after the mounted files are refreshed.
+	//
+	// +kubebuilder:default=Restart
+	// +optional
+	TLSCertReloadPolicy TLSCertReloadPolicy `json:"tlsCertReloadPolicy,omitempty"`
 }
 
 // ComponentDefinitionStatus defines the observed state of ComponentDefinition.
@@ -1350,6 +1377,49 @@ type ComponentLifecycleActions struct {
 	//
 	// +optional
 	AccountProvision *LifecycleActionHandler `json:"accountProvision,omitempty"`
+
+	// Defines the procedure to add a new shard into the sharding cluster, e.g. to join the shard into the cluster
+	// topology, or to assign slots or chunks to it.
+	//
+	// This action is executed by the ShardScaling OpsRequest once for each new shard, after the shard is ready.
+	// Only `customHandler.exec` is supported currently.
+	//
+	// In addition to the environment variables of the PostProvision Action,
+	// the container executing this action has access to following environment variables:
+	//
+	// - KB_SHARDING_NAME: The name of the sharding.
+	// - KB_SHARDING_COMPONENT_LIST: Comma-separated list of the shards after the scaling (e.g., "shard-abc,shard-def").
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ShardAdd *LifecycleActionHandler `json:"shardAdd,omitempty"`
+
+	// Defines the procedure to drain a shard before it is removed from the sharding cluster,
+	// e.g. to migrate the slots or chunks of the shard to the remaining shards listed in KB_SHARDING_COMPONENT_LIST.
+	//
+	// This action is executed by the ShardScaling OpsRequest once for each shard to be removed.
+	// The shard will not be deleted until the action has completed successfully.
+	// Only `customHandler.exec` is supported currently.
+	//
+	// The container executing this action has access to the same environment variables as the ShardAdd Action.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ShardRemove *LifecycleActionHandler `json:"shardRemove,omitempty"`
+
+	// Defines the procedure to rebalance the data among all the shards of the sharding cluster.
+	//
+	// This action is executed by the ShardScaling OpsRequest once, after all the shards have been added or removed.
+	// Only `customHandler.exec` is supported currently.
+	//
+	// The container executing this action has access to the same environment variables as the ShardAdd Action.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	Rebalance *LifecycleActionHandler `json:"rebalance,omitempty"`
 }
 
 type ComponentSwitchover struct {
@@ -0,0 +1,165 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsFleetSpec defines the desired state of OpsFleet
+type OpsFleetSpec struct {
+	// Specifies the label selector of the clusters to operate on, the clusters are selected in the namespace of the OpsFleet.
+	// The clusters are selected once when the OpsFleet starts, the clusters created later are not included.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterSelector"
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// Specifies the maximum number of the OpsRequests which run at the same time.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxConcurrency int32 `json:"maxConcurrency,omitempty"`
+
+	// Specifies the number of the OpsRequests which are allowed to fail or be cancelled.
+	// Once the number of the failed OpsRequests exceeds the budget, no more OpsRequests will be created
+	// and the OpsFleet fails after the running OpsRequests complete.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	FailureBudget int32 `json:"failureBudget,omitempty"`
+
+	// Specifies the OpsRequest to create for each selected cluster.
+	// The `clusterRef` of the template can be omitted, it is replaced with the name of the selected cluster.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.template"
+	Template OpsRequestSpec `json:"template"`
+}
+
+// OpsFleetStatus defines the observed state of OpsFleet
+type OpsFleetStatus struct {
+	// Specifies the phase of the OpsFleet. Possible values are `Pending`, `Running`, `Succeed` and `Failed`.
+	// +optional
+	Phase OpsPhase `json:"phase,omitempty"`
+
+	// Represents the progress of the OpsFleet, in the format of `<completed clusters>/<total clusters>`.
+	// +kubebuilder:validation:Pattern:=`^(\d+|\-)/(\d+|\-)$`
+	// +kubebuilder:default=-/-
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Specifies the number of the OpsRequests which have failed or been cancelled.
+	// +optional
+	FailedCount int32 `json:"failedCount,omitempty"`
+
+	// Records the status of the OpsRequest of each selected cluster.
+	// +optional
+	// +patchMergeKey=clusterName
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=clusterName
+	Clusters []OpsFleetClusterStatus `json:"clusters,omitempty" patchStrategy:"merge" patchMergeKey:"clusterName"`
+
+	// Indicates the time when the OpsFleet started processing.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Specifies the time when the OpsFleet was completed.
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Describes the detailed status of the OpsFleet.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// OpsFleetClusterStatus represents the status of the OpsRequest created for a selected cluster.
+type OpsFleetClusterStatus struct {
+	// Specifies the name of the cluster.
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the OpsRequest created for the cluster.
+	// +optional
+	OpsRequestName string `json:"opsRequestName,omitempty"`
+
+	// Specifies the phase of the OpsRequest created for the cluster, it is empty if the OpsRequest is not created yet.
+	// +optional
+	Phase OpsPhase `json:"phase,omitempty"`
+
+	// Represents the progress of the OpsRequest created for the cluster.
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Indicates the time when the OpsRequest was created.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Specifies the time when the OpsRequest was completed.
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Provides additional information about the OpsRequest.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks,all},shortName=opf
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.template.type",description="Operation request type."
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="Fleet status phase."
+// +kubebuilder:printcolumn:name="PROGRESS",type="string",JSONPath=".status.progress",description="Fleet processing progress."
+// +kubebuilder:printcolumn:name="FAILED",type="integer",JSONPath=".status.failedCount",description="Number of the failed OpsRequests."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsFleet is the Schema for the opsfleets API.
+// It runs the same OpsRequest on the clusters selected by labels.
+type OpsFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsFleetSpec   `json:"spec,omitempty"`
+	Status OpsFleetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsFleetList contains a list of OpsFleet
+type OpsFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsFleet{}, &OpsFleetList{})
+}
+
+// IsComplete checks if the OpsFleet has been completed.
+func (r *OpsFleet) IsComplete() bool {
+	return r.Status.Phase == OpsSucceedPhase || r.Status.Phase == OpsFailedPhase
+}
+
+// GetClusterOpsRequestName gets the name of the OpsRequest created for the cluster.
+func (r *OpsFleet) GetClusterOpsRequestName(clusterName string) string {
+	return r.Name + "-" + clusterName
+}
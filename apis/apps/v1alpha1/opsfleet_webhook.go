@@ -0,0 +1,36 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func (r *OpsFleet) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(&opsRequesterAdmission{}).
+		WithValidator(&opsRequesterAdmission{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-apps-kubeblocks-io-v1alpha1-opsfleet,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsfleets,verbs=create,versions=v1alpha1,name=mopsfleet.kb.io,admissionReviewVersions=v1
+
+// +kubebuilder:webhook:path=/validate-apps-kubeblocks-io-v1alpha1-opsfleet,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsfleets,verbs=update,versions=v1alpha1,name=vopsfleet.kb.io,admissionReviewVersions=v1
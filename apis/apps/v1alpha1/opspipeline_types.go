@@ -0,0 +1,174 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsPipelineSpec defines the desired state of OpsPipeline
+type OpsPipelineSpec struct {
+	// Specifies the ordered list of steps to run.
+	// Each step creates an OpsRequest, which is created only after the OpsRequest of the previous step completes.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.steps"
+	Steps []OpsPipelineStep `json:"steps" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// OpsPipelineStep defines a step of the OpsPipeline.
+// +kubebuilder:validation:XValidation:rule="has(self.spec.clusterRef) && size(self.spec.clusterRef) > 0",message="spec.clusterRef of the step is required"
+type OpsPipelineStep struct {
+	// Specifies the name of the step, which must be unique in the OpsPipeline.
+	// The OpsRequest of the step is named as `<pipeline name>-<step name>`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies what to do when the OpsRequest of the step fails or is cancelled.
+	//
+	// - `Abort`: fails the OpsPipeline and the rest steps will not run.
+	// - `Continue`: records the failure of the step and runs the next step.
+	//
+	// +kubebuilder:default=Abort
+	// +optional
+	FailurePolicy OpsPipelineFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Specifies the OpsRequest to create for the step.
+	// +kubebuilder:validation:Required
+	Spec OpsRequestSpec `json:"spec"`
+}
+
+// OpsPipelineStatus defines the observed state of OpsPipeline
+type OpsPipelineStatus struct {
+	// Specifies the phase of the OpsPipeline. Possible values are `Pending`, `Running`, `Succeed` and `Failed`.
+	// +optional
+	Phase OpsPhase `json:"phase,omitempty"`
+
+	// Represents the progress of the OpsPipeline, in the format of `<completed steps>/<total steps>`.
+	// +kubebuilder:validation:Pattern:=`^(\d+|\-)/(\d+|\-)$`
+	// +kubebuilder:default=-/-
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Records the status of the steps which have been started.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=name
+	Steps []OpsPipelineStepStatus `json:"steps,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Indicates the time when the OpsPipeline started processing.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Specifies the time when the OpsPipeline was completed.
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Describes the detailed status of the OpsPipeline.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// OpsPipelineStepStatus represents the status of a step of the OpsPipeline.
+type OpsPipelineStepStatus struct {
+	// Specifies the name of the step.
+	Name string `json:"name"`
+
+	// Specifies the name of the OpsRequest created for the step.
+	// +optional
+	OpsRequestName string `json:"opsRequestName,omitempty"`
+
+	// Specifies the phase of the OpsRequest created for the step.
+	// +optional
+	Phase OpsPhase `json:"phase,omitempty"`
+
+	// Indicates the time when the step started.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Specifies the time when the step was completed.
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Provides additional information about the step.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks,all},shortName=opp
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="Pipeline status phase."
+// +kubebuilder:printcolumn:name="PROGRESS",type="string",JSONPath=".status.progress",description="Pipeline processing progress."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsPipeline is the Schema for the opspipelines API
+type OpsPipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsPipelineSpec   `json:"spec,omitempty"`
+	Status OpsPipelineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsPipelineList contains a list of OpsPipeline
+type OpsPipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsPipeline `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsPipeline{}, &OpsPipelineList{})
+}
+
+// IsComplete checks if the OpsPipeline has been completed.
+func (r *OpsPipeline) IsComplete() bool {
+	return r.Status.Phase == OpsSucceedPhase || r.Status.Phase == OpsFailedPhase
+}
+
+// GetStepStatus gets the status of the step by name.
+func (r *OpsPipeline) GetStepStatus(stepName string) *OpsPipelineStepStatus {
+	for i := range r.Status.Steps {
+		if r.Status.Steps[i].Name == stepName {
+			return &r.Status.Steps[i]
+		}
+	}
+	return nil
+}
+
+// GetStepOpsRequestName gets the name of the OpsRequest created for the step.
+func (r *OpsPipeline) GetStepOpsRequestName(stepName string) string {
+	return r.Name + "-" + stepName
+}
@@ -18,9 +18,12 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 const (
@@ -43,7 +46,16 @@ const (
 	ConditionTypeDataScript         = "ExecuteDataScript"
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
+	ConditionTypeVolumeMigrating    = "VolumeMigrating"
+	ConditionTypeFailover           = "Failover"
+	ConditionTypeShardScaling       = "ShardScaling"
+	ConditionTypeClone              = "Clone"
+	ConditionTypeAdopt              = "Adopt"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypeScheduled          = "Scheduled"
+	ConditionTypeDependencies       = "Dependencies"
+	ConditionTypeDryRun             = "DryRun"
+	ConditionTypeApproval           = "Approval"
 
 	// condition and event reasons
 
@@ -64,6 +76,14 @@ const (
 	ReasonOpsCancelFailed          = "CancelFailed"
 	ReasonOpsCancelSucceed         = "CancelSucceed"
 	ReasonOpsCancelByController    = "CancelByController"
+	ReasonWaitForMaintenanceWindow = "WaitForMaintenanceWindow"
+	ReasonWaitForDependencies      = "WaitForDependencies"
+	ReasonDependencyNotSucceed     = "DependencyNotSucceed"
+	ReasonDryRunCompleted          = "DryRunCompleted"
+	ReasonDryRunPreCheckFailed     = "DryRunPreCheckFailed"
+	ReasonWaitForApproval          = "WaitForApproval"
+	ReasonApproved                 = "Approved"
+	ReasonInvalidApproval          = "InvalidApproval"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -82,6 +102,94 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewScheduledCondition creates a condition that the OpsRequest waits for the next maintenance window.
+func NewScheduledCondition(ops *OpsRequest, nextWindowStart time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeScheduled,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonWaitForMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("OpsRequest: %s in Cluster: %s is scheduled to start in the maintenance window at %s",
+			ops.Name, ops.Spec.ClusterRef, nextWindowStart.Format(time.RFC3339)),
+	}
+}
+
+// NewWaitForDependenciesCondition creates a condition that the OpsRequest waits for the OpsRequests it depends on.
+func NewWaitForDependenciesCondition(ops *OpsRequest, pendingDependencies []string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeDependencies,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonWaitForDependencies,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("OpsRequest: %s is waiting for the OpsRequests %v to succeed",
+			ops.Name, pendingDependencies),
+	}
+}
+
+// NewDependencyNotSucceedCondition creates a condition that the OpsRequest is cancelled because a dependency does not succeed.
+func NewDependencyNotSucceedCondition(ops *OpsRequest, dependency string, phase OpsPhase) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeDependencies,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonDependencyNotSucceed,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`OpsRequest: %s is cancelled because the dependent OpsRequest "%s" is %s`,
+			ops.Name, dependency, phase),
+	}
+}
+
+// NewWaitForApprovalCondition creates a condition that the OpsRequest waits for the approval.
+func NewWaitForApprovalCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproval,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonWaitForApproval,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`OpsRequest: %s of type %s is waiting for the approval, approve it by setting the annotation "%s"`,
+			ops.Name, ops.Spec.Type, constant.OpsRequestApprovedByAnnotationKey),
+	}
+}
+
+// NewApprovedCondition creates a condition that the OpsRequest has been approved.
+func NewApprovedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproval,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonApproved,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`OpsRequest: %s is approved by "%s"`, ops.Name, ops.Status.Approval.ApprovedBy),
+	}
+}
+
+// NewInvalidApprovalCondition creates a condition that the approval of the OpsRequest is not accepted.
+func NewInvalidApprovalCondition(ops *OpsRequest, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproval,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonInvalidApproval,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`the approval of OpsRequest: %s is not accepted, %s`, ops.Name, message),
+	}
+}
+
+// NewDryRunCompletedCondition creates a condition that the OpsRequest has rendered its impact in dry-run mode.
+func NewDryRunCompletedCondition(ops *OpsRequest) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               ConditionTypeDryRun,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonDryRunCompleted,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`OpsRequest: %s is completed in dry-run mode, no change is applied to Cluster: "%s"`, ops.Name, ops.Spec.ClusterRef),
+	}
+	if ops.Status.DryRunResult != nil && len(ops.Status.DryRunResult.PreCheckFailures) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonDryRunPreCheckFailed
+		condition.Message = fmt.Sprintf(`OpsRequest: %s is completed in dry-run mode with %d pre-check failures`,
+			ops.Name, len(ops.Status.DryRunResult.PreCheckFailures))
+	}
+	return condition
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -191,6 +299,39 @@ func NewInstancesRebuildingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewVolumeMigratingCondition creates a condition that the operation starts to migrate the volumes.
+func NewVolumeMigratingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeVolumeMigrating,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToMigrateVolumes",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to migrate the volumes in Cluster: %s", ops.Spec.ClusterRef),
+	}
+}
+
+// NewFailoverCondition creates a condition that the operation starts to fail over components.
+func NewFailoverCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeFailover,
+		Status:             metav1.ConditionTrue,
+		Reason:             "FailoverStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to fail over the components in Cluster: %s", ops.Spec.ClusterRef),
+	}
+}
+
+// NewShardScalingCondition creates a condition that the operation starts to scale the shards.
+func NewShardScalingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeShardScaling,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ShardScalingStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to scale the shards in Cluster: %s", ops.Spec.ClusterRef),
+	}
+}
+
 // NewSwitchoveringCondition creates a condition that the operation starts to switchover components
 func NewSwitchoveringCondition(generation int64, message string) *metav1.Condition {
 	return &metav1.Condition{
@@ -373,3 +514,27 @@ func NewRestoreCondition(ops *OpsRequest) *metav1.Condition {
 		Message:            fmt.Sprintf("Start to restore the Cluster: %s", ops.Spec.ClusterRef),
 	}
 }
+
+// NewCloneCondition creates a condition that the OpsRequest clones the cluster.
+func NewCloneCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeClone,
+		Status:             metav1.ConditionTrue,
+		Reason:             "CloneStarted",
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Start to clone the Cluster: %s from the Cluster: %s",
+			ops.Spec.ClusterRef, ops.Spec.CloneSpec.SourceClusterName),
+	}
+}
+
+// NewAdoptCondition creates a condition that the OpsRequest adopts the StatefulSet.
+func NewAdoptCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeAdopt,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AdoptStarted",
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Start to adopt the StatefulSet: %s into the Cluster: %s",
+			ops.Spec.AdoptSpec.StatefulSetName, ops.Spec.ClusterRef),
+	}
+}
@@ -27,9 +27,13 @@ import (
 
 // OpsRequestSpec defines the desired state of OpsRequest
 // +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.dryRun) && self.dryRun ? (self.type in ['HorizontalScaling', 'VolumeExpansion', 'Reconfiguring']) : true",message="forbidden to dry run the opsRequest which type not in ['HorizontalScaling','VolumeExpansion','Reconfiguring']"
+// +kubebuilder:validation:XValidation:rule="has(self.rollbackOnFailure) && self.rollbackOnFailure ? (self.type in ['VerticalScaling', 'Upgrade', 'Reconfiguring']) : true",message="forbidden to roll back the opsRequest which type not in ['VerticalScaling','Upgrade','Reconfiguring']"
+// +kubebuilder:validation:XValidation:rule="has(self.rollout) ? (self.type in ['VerticalScaling', 'Upgrade']) : true",message="forbidden to roll out the opsRequest in steps which type not in ['VerticalScaling','Upgrade']"
 type OpsRequestSpec struct {
 	// References the cluster object.
-	// +kubebuilder:validation:Required
+	// It is required by the OpsRequest, and can be omitted in the template of an OpsFleet.
+	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterRef"
 	ClusterRef string `json:"clusterRef"`
 
@@ -45,11 +49,41 @@ type OpsRequestSpec struct {
 	// +optional
 	Force bool `json:"force,omitempty"`
 
+	// Indicates whether to run the OpsRequest in dry-run mode, supported types: `HorizontalScaling/VolumeExpansion/Reconfiguring`.
+	// In dry-run mode, the pre-checks are performed and the changes are rendered without being applied to the cluster.
+	// The objects that would be affected are reported in `status.dryRunResult`,
+	// and the OpsRequest ends in the `DryRunCompleted` phase.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dryRun"
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Indicates whether to roll back the changes automatically if the OpsRequest fails, supported types: `VerticalScaling/Upgrade/Reconfiguring`.
+	// If set to true, a compensating OpsRequest is created with the configuration recorded in `status.lastConfiguration`
+	// once this OpsRequest transitions to the `Failed` phase. The compensating OpsRequest runs with `force` enabled.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rollbackOnFailure"
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+
+	// Specifies the canary rollout of the OpsRequest, supported types: `VerticalScaling/Upgrade`.
+	// If specified, the replicas of the affected components are updated step by step instead of all at once,
+	// and the OpsRequest fails if the updated replicas turn unhealthy or a step does not finish in time.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rollout"
+	// +optional
+	Rollout *OpsRollout `json:"rollout,omitempty"`
+
 	// Defines the operation type.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.type"
 	Type OpsType `json:"type"`
 
+	// Specifies the names of the OpsRequests in the same namespace that must succeed before this OpsRequest starts.
+	// The OpsRequest stays in the `Pending` phase until all of them succeed,
+	// and it is cancelled if any of them fails or is cancelled.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dependsOn"
+	// +listType=set
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
 	// OpsRequest will be deleted after TTLSecondsAfterSucceed second when OpsRequest.status.phase is Succeed.
 	// +optional
 	TTLSecondsAfterSucceed int32 `json:"ttlSecondsAfterSucceed,omitempty"`
@@ -95,6 +129,16 @@ type OpsRequestSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.switchover"
 	SwitchoverList []Switchover `json:"switchover,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Fails over the specified components whose primary or leader is unhealthy.
+	// Unlike switchover, the old primary is fenced without its cooperation, and the healthiest candidate is promoted forcibly.
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.failover"
+	FailoverList []Failover `json:"failover,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Note: Quantity struct can not do immutable check by CEL.
 	// Defines what component need to vertical scale the specified compute resources.
 	// +optional
@@ -138,6 +182,20 @@ type OpsRequestSpec struct {
 	// +optional
 	TTLSecondsBeforeAbort *int32 `json:"ttlSecondsBeforeAbort,omitempty"`
 
+	// Specifies the maintenance window in which this OpsRequest is allowed to start, overriding `cluster.spec.maintenanceWindow`.
+	// It only takes effect on disruptive operations: Restart, VerticalScaling, Upgrade and Reconfiguring that requires a restart.
+	// The OpsRequest stays in the `Scheduled` phase until the window opens. It is ignored if `spec.force` is true.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.maintenanceWindow"
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies the expected time in seconds for the operation to complete.
+	// When a maintenance window applies, the OpsRequest starts only if the rest of the current window can cover this duration,
+	// otherwise it is held back until the next window. It must not exceed the duration of the maintenance window.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ExpectedDurationSeconds *int32 `json:"expectedDurationSeconds,omitempty"`
+
 	// Defines the script to be executed.
 	// +optional
 	ScriptSpec *ScriptSpec `json:"scriptSpec,omitempty"`
@@ -151,6 +209,16 @@ type OpsRequestSpec struct {
 	// +optional
 	RestoreSpec *RestoreSpec `json:"restoreSpec,omitempty"`
 
+	// Defines how to clone the cluster from an existing cluster, optionally as of a point in time.
+	// The cluster referenced by `clusterRef` is created by this operation.
+	// +optional
+	CloneSpec *CloneSpec `json:"clone,omitempty"`
+
+	// Defines how to adopt an existing StatefulSet into the cluster without copying the data.
+	// The cluster referenced by `clusterRef` is created by this operation.
+	// +optional
+	AdoptSpec *AdoptSpec `json:"adopt,omitempty"`
+
 	// Specifies the instances that require re-creation.
 	// +patchMergeKey=componentName
 	// +patchStrategy=merge,retainKeys
@@ -160,6 +228,28 @@ type OpsRequestSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebuildFrom"
 	RebuildFrom []RebuildInstance `json:"rebuildFrom,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Note: Quantity struct can not do immutable check by CEL.
+	// Specifies the components whose volumes need to be migrated to a new storage class or size.
+	// The instances are rebuilt one at a time onto the new volumes, and the leader is switched over and migrated last.
+	// It can also be used to shrink the volumes, which is not supported by VolumeExpansion.
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +optional
+	VolumeMigrationList []VolumeMigration `json:"volumeMigration,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies the shardings whose number of shards needs to be changed.
+	// The shardAdd, shardRemove and rebalance actions defined in the ComponentDefinition are called to migrate the data,
+	// and the shards to be removed are drained before they are deleted.
+	// +optional
+	// +patchMergeKey=shardingName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=shardingName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.shardScaling"
+	ShardScalingList []ShardScaling `json:"shardScaling,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"shardingName"`
+
 	// Specifies a custom operation as defined by OpsDefinition.
 	// +optional
 	CustomSpec *CustomOpsSpec `json:"customSpec,omitempty"`
@@ -172,6 +262,8 @@ type ComponentOps struct {
 	ComponentName string `json:"componentName"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!(has(self.backupName) && has(self.fromPeer) && self.fromPeer)",message="backupName and fromPeer are mutually exclusive"
+
 type RebuildInstance struct {
 	ComponentOps `json:",inline"`
 
@@ -193,6 +285,73 @@ type RebuildInstance struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
 	EnvForRestore []corev1.EnvVar `json:"envForRestore,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Indicates the instances are rebuilt by streaming the data from a healthy peer instance, instead of from a backup.
+	// The data is exported by the DataDump action and piped into the DataLoad action defined in the ComponentDefinition,
+	// both of them are executed in a temporary pod which mounts the new volumes of the rebuilding instance.
+	// The actions can access the peer by the environment variables `KB_DATA_SOURCE_POD_NAME`, `KB_DATA_SOURCE_POD_IP`
+	// and `KB_DATA_SOURCE_POD_FQDN`, e.g. to run pg_basebackup, MySQL CLONE or xtrabackup stream against it.
+	//
+	// It can not be used together with `backupName`.
+	// +optional
+	FromPeer bool `json:"fromPeer,omitempty"`
+
+	// Specifies the name of the peer instance to stream the data from when `fromPeer` is true.
+	// If not specified, a healthy secondary with the least replication lag is picked, and the primary is picked
+	// only if no secondary is available.
+	// +optional
+	PeerInstanceName string `json:"peerInstanceName,omitempty"`
+}
+
+// VolumeMigration defines the parameters required for a volume migration operation.
+// +kubebuilder:validation:XValidation:rule="has(self.backupName) != (has(self.fromPeer) && self.fromPeer)",message="exactly one of backupName and fromPeer must be specified"
+type VolumeMigration struct {
+	ComponentOps `json:",inline"`
+
+	// Specifies the volumeClaimTemplates to migrate, with the target storage class and/or size.
+	// +kubebuilder:validation:Required
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	VolumeClaimTemplates []VolumeMigrationTemplate `json:"volumeClaimTemplates" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Indicates the name of the backup used to seed the data of the new volumes. Currently, only a full physical backup is supported.
+	//
+	// It can not be used together with `fromPeer`.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// Indicates the data of the new volumes is streamed from a healthy peer instance, instead of from a backup.
+	// It requires the DataDump and DataLoad actions defined in the ComponentDefinition and more than one replica,
+	// see `fromPeer` of RebuildInstance for details.
+	//
+	// It can not be used together with `backupName`.
+	// +optional
+	FromPeer bool `json:"fromPeer,omitempty"`
+
+	// List of environment variables to set in the container for restore. These will be
+	// merged with the env of Backup and ActionSet.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	EnvForRestore []corev1.EnvVar `json:"envForRestore,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+}
+
+type VolumeMigrationTemplate struct {
+	// A reference to the volumeClaimTemplate name from the cluster components.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the storage class of the new volumes.
+	// If not specified, the storage class of the volumeClaimTemplate is retained.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Specifies the storage size of the new volumes, which can be smaller than the current size.
+	// If not specified, the storage size of the volumeClaimTemplate is retained.
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
 }
 
 type Instance struct {
@@ -225,6 +384,39 @@ type Switchover struct {
 	InstanceName string `json:"instanceName"`
 }
 
+// Failover defines the parameters required for a forced failover operation.
+type Failover struct {
+	ComponentOps `json:",inline"`
+
+	// Specifies the instance to be promoted as the new primary or leader.
+	// If not specified, the healthy instance with the least replication lag will be promoted.
+	// +optional
+	InstanceName string `json:"instanceName,omitempty"`
+
+	// Specifies how to fence the old primary or leader.
+	//
+	// - `Offline`: takes the old primary offline through the offlineInstances of the component,
+	//   a new instance will be created to replace it.
+	// - `Cordon`: cordons the old primary by deleting its pod, it is recreated with the same name and joins as a secondary.
+	//
+	// The old primary is deleted forcibly if its node is not ready, and the candidate is promoted only after
+	// the old primary is gone.
+	//
+	// +kubebuilder:default=Offline
+	// +optional
+	FencingPolicy FailoverFencingPolicy `json:"fencingPolicy,omitempty"`
+}
+
+// FailoverFencingPolicy defines how to fence the old primary during the failover.
+// +enum
+// +kubebuilder:validation:Enum={Offline,Cordon}
+type FailoverFencingPolicy string
+
+const (
+	FailoverFencingOffline FailoverFencingPolicy = "Offline"
+	FailoverFencingCordon  FailoverFencingPolicy = "Cordon"
+)
+
 // Upgrade represents the parameters required for an upgrade operation.
 type Upgrade struct {
 	// A reference to the name of the ClusterVersion.
@@ -288,6 +480,19 @@ type HorizontalScaling struct {
 	OfflineInstances []string `json:"offlineInstances,omitempty"`
 }
 
+// ShardScaling defines the variables of shard scaling operation.
+type ShardScaling struct {
+	// Specifies the name of the sharding defined in `cluster.spec.shardingSpecs`.
+	// +kubebuilder:validation:Required
+	ShardingName string `json:"shardingName"`
+
+	// Specifies the desired number of shards.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=2048
+	Shards int32 `json:"shards"`
+}
+
 // Reconfigure represents the variables required for updating a configuration.
 type Reconfigure struct {
 	ComponentOps `json:",inline"`
@@ -648,6 +853,153 @@ type RestoreSpec struct {
 	VolumeRestorePolicy string `json:"volumeRestorePolicy,omitempty"`
 }
 
+// CloneSpec defines the source and the overrides of a cluster clone.
+type CloneSpec struct {
+	// Specifies the name of the source cluster.
+	//
+	// +kubebuilder:validation:Required
+	SourceClusterName string `json:"sourceClusterName"`
+
+	// Specifies the namespace of the source cluster.
+	// If not set, the namespace of the OpsRequest is used.
+	//
+	// Cloning from another namespace requires the requester to be allowed to get the source cluster
+	// and list the backups in that namespace.
+	//
+	// +optional
+	SourceClusterNamespace string `json:"sourceClusterNamespace,omitempty"`
+
+	// Specifies the point in time to clone the source cluster as of, in RFC3339 format, such as `2024-01-02T10:00:00Z`.
+	//
+	// If set, a continuous backup covering the time and a completed full backup taken before it are required
+	// for each backup policy of the source cluster.
+	// If not set, the latest completed full backups of the source cluster are used.
+	//
+	// +optional
+	RestoreTime string `json:"restoreTime,omitempty"`
+
+	// Specifies the volume claim restore policy, support values: [Serial, Parallel]
+	//
+	// +kubebuilder:validation:Enum=Serial;Parallel
+	// +kubebuilder:default=Parallel
+	// +optional
+	VolumeRestorePolicy string `json:"volumeRestorePolicy,omitempty"`
+
+	// Specifies the overrides of the components of the new cluster.
+	//
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +optional
+	ComponentOverrides []CloneComponentOverride `json:"componentOverrides,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+}
+
+// CloneComponentOverride defines the overrides of a component, or a sharding, of the new cluster.
+type CloneComponentOverride struct {
+	// Specifies the name of the component or the sharding.
+	ComponentOps `json:",inline"`
+
+	// Specifies the replicas of the component.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Specifies the resources of the component.
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Specifies the StorageClass of all volume claim templates of the component.
+	//
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// AdoptSpec defines the StatefulSet to be adopted and the component which takes it over.
+type AdoptSpec struct {
+	// Specifies the name of the StatefulSet to be adopted, it should be in the namespace of the OpsRequest.
+	//
+	// +kubebuilder:validation:Required
+	StatefulSetName string `json:"statefulSetName"`
+
+	// Specifies the name of the component which takes over the StatefulSet.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=22
+	// +kubebuilder:validation:Pattern:=`^[a-z]([a-z0-9\-]*[a-z0-9])?$`
+	ComponentName string `json:"componentName"`
+
+	// Specifies the name of the ComponentDefinition of the component.
+	//
+	// The volumes of the ComponentDefinition should match the volume claim templates of the StatefulSet,
+	// and they should be mounted at the same paths as the StatefulSet does.
+	//
+	// +kubebuilder:validation:Required
+	ComponentDef string `json:"componentDef"`
+
+	// Specifies the ServiceVersion of the component.
+	//
+	// +optional
+	ServiceVersion string `json:"serviceVersion,omitempty"`
+
+	// Specifies how the volume claim templates of the StatefulSet map to the volumes of the ComponentDefinition.
+	// A volume claim template maps to the volume with the same name if it is not specified here.
+	//
+	// +patchMergeKey=volumeClaimTemplate
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=volumeClaimTemplate
+	// +optional
+	VolumeMappings []AdoptVolumeMapping `json:"volumeMappings,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"volumeClaimTemplate"`
+
+	// Specifies the secrets holding the existing passwords of the system accounts of the adopted database.
+	//
+	// The passwords are imported as the account secrets of the component, so the adopted database keeps working
+	// with the credentials it was initialized with. It is required for every system account defined by the
+	// ComponentDefinition, except for those whose password is referenced from a secret by the ComponentDefinition.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SystemAccounts []AdoptSystemAccount `json:"systemAccounts,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// AdoptSystemAccount refers to the secret holding the existing password of a system account.
+type AdoptSystemAccount struct {
+	// Specifies the name of the system account defined in the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the name of the secret holding the password, it should be in the namespace of the OpsRequest.
+	//
+	// +kubebuilder:validation:Required
+	SecretName string `json:"secretName"`
+
+	// Specifies the key of the password in the secret.
+	//
+	// +kubebuilder:default:="password"
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// AdoptVolumeMapping maps a volume claim template of the StatefulSet to a volume of the ComponentDefinition.
+type AdoptVolumeMapping struct {
+	// Specifies the name of the volume claim template of the StatefulSet.
+	//
+	// +kubebuilder:validation:Required
+	VolumeClaimTemplate string `json:"volumeClaimTemplate"`
+
+	// Specifies the name of the volume of the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Required
+	VolumeName string `json:"volumeName"`
+}
+
 // ScriptSecret represents the secret that is used to execute the script.
 type ScriptSecret struct {
 	// Specifies the name of the secret.
@@ -724,6 +1076,26 @@ type OpsRequestStatus struct {
 	// +optional
 	ReconfiguringStatusAsComponent map[string]*ReconfiguringStatus `json:"reconfiguringStatusAsComponent,omitempty"`
 
+	// Records the impact of the OpsRequest rendered in dry-run mode.
+	// +optional
+	DryRunResult *DryRunResult `json:"dryRunResult,omitempty"`
+
+	// Specifies the name of the OpsRequest created to roll back this OpsRequest after it failed.
+	// +optional
+	RollbackOpsRequest string `json:"rollbackOpsRequest,omitempty"`
+
+	// Specifies the name of the failed OpsRequest which is rolled back by this OpsRequest.
+	// +optional
+	RollbackOf string `json:"rollbackOf,omitempty"`
+
+	// Records the approval of the OpsRequest, only applicable for the OpsRequest types which need approval.
+	// +optional
+	Approval *OpsApproval `json:"approval,omitempty"`
+
+	// Records the status of the canary rollout, only applicable if `spec.rollout` is specified.
+	// +optional
+	Rollout *OpsRolloutStatus `json:"rollout,omitempty"`
+
 	// Describes the detailed status of the OpsRequest.
 	// +optional
 	// +patchMergeKey=type
@@ -733,6 +1105,132 @@ type OpsRequestStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// OpsRollout defines the steps to roll out the changes of the OpsRequest.
+type OpsRollout struct {
+	// Defines the steps of the rollout. The replicas selected by a step are updated,
+	// and the rollout moves on to the next step after they stay healthy for the pause duration.
+	// The remaining replicas are updated after the last step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Steps []OpsRolloutStep `json:"steps"`
+
+	// Specifies the maximum time in seconds for the replicas of a step to be updated and become healthy.
+	// The OpsRequest fails if the deadline is exceeded. A value of 0 means no deadline.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ProgressDeadlineSeconds int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// OpsRolloutStep defines the replicas to update in a rollout step.
+type OpsRolloutStep struct {
+	// Specifies the number or the percentage of the replicas of each component that are updated by the end of this step,
+	// counted in the update order of the InstanceSet. All the replicas are selected if not specified.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	Replicas *intstr.IntOrString `json:"replicas,omitempty"`
+
+	// Specifies the roles of the replicas that are updated in this step, e.g. `follower`.
+	// Replicas of all roles are selected if not specified.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// Specifies the time in seconds to observe the updated replicas before moving on to the next step.
+	// The rollout is aborted if any updated replica turns unhealthy during the pause.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PauseSeconds int32 `json:"pauseSeconds,omitempty"`
+}
+
+// OpsRolloutStatus records the status of the canary rollout.
+type OpsRolloutStatus struct {
+	// Specifies the index of the current step in `spec.rollout.steps`.
+	// It equals to the number of steps once all the steps are completed.
+	CurrentStep int32 `json:"currentStep"`
+
+	// Records the time when the current step started.
+	// +optional
+	StepStartTimestamp metav1.Time `json:"stepStartTimestamp,omitempty"`
+
+	// Records the time when all the replicas of the current step became healthy, the pause starts from this time.
+	// +optional
+	PauseStartTimestamp *metav1.Time `json:"pauseStartTimestamp,omitempty"`
+}
+
+// OpsApproval records who approved the OpsRequest and when.
+type OpsApproval struct {
+	// Specifies the name of the user who approved the OpsRequest.
+	// +kubebuilder:validation:Required
+	ApprovedBy string `json:"approvedBy"`
+
+	// Specifies the name of the user who requested the OpsRequest.
+	//
+	// The OpsRequests created by an OpsPipeline or an OpsFleet are requested by the user who created it,
+	// and the rollback of an OpsRequest is requested by the requester of the rolled back OpsRequest.
+	// The OpsRequests originated by KubeBlocks itself, e.g. auto-heal and rebalance, are requested by "system:kubeblocks",
+	// they can be approved by any user except the KubeBlocks controller.
+	//
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+
+	// Records the time when the approval is observed.
+	// +optional
+	ApprovalTime metav1.Time `json:"approvalTime,omitempty"`
+}
+
+// DryRunResult describes the impact of the OpsRequest rendered in dry-run mode.
+type DryRunResult struct {
+	// Lists the messages of the pre-checks that failed.
+	// The OpsRequest would be rejected if it runs with the same spec.
+	// +optional
+	PreCheckFailures []string `json:"preCheckFailures,omitempty"`
+
+	// Indicates whether any pod would be restarted.
+	// +optional
+	PodsRestart bool `json:"podsRestart,omitempty"`
+
+	// Lists the objects that would be created, updated or deleted.
+	// +optional
+	AffectedObjects []DryRunAffectedObject `json:"affectedObjects,omitempty"`
+}
+
+// DryRunAffectedObject describes an object that would be changed by the OpsRequest.
+type DryRunAffectedObject struct {
+	// Specifies the kind of the object.
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Specifies the name of the object.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the action that would be performed on the object.
+	// +kubebuilder:validation:Required
+	Action DryRunActionType `json:"action"`
+
+	// Indicates whether the pod would be restarted, only applicable for pods.
+	// +optional
+	Restart bool `json:"restart,omitempty"`
+
+	// Lists the changed fields of the object.
+	// +optional
+	Changes []DryRunFieldChange `json:"changes,omitempty"`
+}
+
+// DryRunFieldChange describes a changed field of the object.
+type DryRunFieldChange struct {
+	// Specifies the path of the field, such as `spec.replicas`.
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Represents the current value of the field in JSON.
+	// +optional
+	Old string `json:"old,omitempty"`
+
+	// Represents the expected value of the field in JSON.
+	// +optional
+	New string `json:"new,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.objectKey) || has(self.actionName)", message="either objectKey and actionName."
 
 type ProgressStatusDetail struct {
@@ -824,6 +1322,11 @@ type LastComponentConfiguration struct {
 	// Records the last offline instances of the component.
 	// +optional
 	OfflineInstances *[]string `json:"offlineInstances,omitempty"`
+
+	// Records the last values of the configurations updated by the Reconfiguring OpsRequest.
+	// The value of a parameter is unset if the parameter does not exist before the reconfiguring.
+	// +optional
+	Configurations []ConfigurationItem `json:"configurations,omitempty"`
 }
 
 type LastConfiguration struct {
@@ -849,6 +1352,10 @@ type OpsRequestComponentStatus struct {
 	// +optional
 	PreCheckResult *PreCheckResult `json:"preCheck,omitempty"`
 
+	// Records the result of the failover, including the potential data loss.
+	// +optional
+	Failover *FailoverStatus `json:"failover,omitempty"`
+
 	// Describes the progress details of the component for this operation.
 	// +optional
 	ProgressDetails []ProgressStatusDetail `json:"progressDetails,omitempty"`
@@ -881,6 +1388,26 @@ type OverrideBy struct {
 	LastComponentConfiguration `json:",inline"`
 }
 
+type FailoverStatus struct {
+	// Specifies the name of the old primary or leader which is fenced.
+	// +optional
+	OldPrimary string `json:"oldPrimary,omitempty"`
+
+	// Specifies the name of the instance which is promoted.
+	// +optional
+	NewPrimary string `json:"newPrimary,omitempty"`
+
+	// Specifies the replication lag of the promoted instance reported by the engine, the unit depends on the engine.
+	// It is not set if the lag is unknown.
+	// +optional
+	ReplicationLag *int64 `json:"replicationLag,omitempty"`
+
+	// Indicates whether some data may be lost, which is true if the replication lag of the promoted instance
+	// is greater than zero or unknown.
+	// +optional
+	PotentialDataLoss bool `json:"potentialDataLoss,omitempty"`
+}
+
 type PreCheckResult struct {
 	// Indicates whether the preCheck operation was successful or not.
 	// +kubebuilder:validation:Required
@@ -975,6 +1502,7 @@ type UpdatedParameters struct {
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="Operation status phase."
 // +kubebuilder:printcolumn:name="PROGRESS",type="string",JSONPath=".status.progress",description="Operation processing progress."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:validation:XValidation:rule="has(self.spec) && has(self.spec.clusterRef) && self.spec.clusterRef != ''",message="spec.clusterRef is required"
 
 // OpsRequest is the Schema for the opsrequests API
 type OpsRequest struct {
@@ -1016,6 +1544,15 @@ func (r OpsRequestSpec) GetSwitchoverComponentNameSet() ComponentNameSet {
 	return set
 }
 
+// GetFailoverComponentNameSet gets the component name map with failover operation.
+func (r OpsRequestSpec) GetFailoverComponentNameSet() ComponentNameSet {
+	set := make(ComponentNameSet)
+	for _, v := range r.FailoverList {
+		set[v.ComponentName] = struct{}{}
+	}
+	return set
+}
+
 // GetVerticalScalingComponentNameSet gets the component name map with vertical scaling operation.
 func (r OpsRequestSpec) GetVerticalScalingComponentNameSet() ComponentNameSet {
 	set := make(ComponentNameSet)
@@ -1068,6 +1605,15 @@ func (r OpsRequestSpec) GetDataScriptComponentNameSet() ComponentNameSet {
 	return set
 }
 
+// GetVolumeMigrationComponentNameSet gets the component name map with volume migration operation.
+func (r OpsRequestSpec) GetVolumeMigrationComponentNameSet() ComponentNameSet {
+	set := make(ComponentNameSet)
+	for _, v := range r.VolumeMigrationList {
+		set[v.ComponentName] = struct{}{}
+	}
+	return set
+}
+
 // ToVolumeExpansionListToMap converts volumeExpansionList to map
 func (r OpsRequestSpec) ToVolumeExpansionListToMap() map[string]VolumeExpansion {
 	volumeExpansionMap := make(map[string]VolumeExpansion)
@@ -1127,6 +1673,10 @@ func (r *OpsRequest) GetComponentNameSet() ComponentNameSet {
 		return r.Spec.GetHorizontalScalingComponentNameSet()
 	case VolumeExpansionType:
 		return r.Spec.GetVolumeExpansionComponentNameSet()
+	case VolumeMigrationType:
+		return r.Spec.GetVolumeMigrationComponentNameSet()
+	case FailoverType:
+		return r.Spec.GetFailoverComponentNameSet()
 	case UpgradeType:
 		return r.GetUpgradeComponentNameSet()
 	case ReconfiguringType:
@@ -1142,6 +1692,11 @@ func (r *OpsRequest) GetComponentNameSet() ComponentNameSet {
 	}
 }
 
+// GetRollbackOpsRequestName returns the name of the OpsRequest which rolls back this OpsRequest.
+func (r *OpsRequest) GetRollbackOpsRequestName() string {
+	return r.Name + "-rollback"
+}
+
 func (p *ProgressStatusDetail) SetStatusAndMessage(status ProgressStatus, message string) {
 	p.Message = message
 	p.Status = status
@@ -18,6 +18,10 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var componentName = "mysql"
@@ -217,3 +221,29 @@ func TestSetStatusAndMessage(t *testing.T) {
 		t.Error("set progressDetail status and message failed")
 	}
 }
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	cluster := &Cluster{}
+	cluster.Spec.MaintenanceWindow = &MaintenanceWindow{
+		Schedule: "0 2 * * *",
+		Duration: metav1.Duration{Duration: time.Hour},
+		TimeZone: "UTC",
+	}
+	ops := mockRestartOps()
+	ops.Spec.ExpectedDurationSeconds = pointer.Int32(1800)
+	if err := ops.validateMaintenanceWindow(cluster); err != nil {
+		t.Errorf("expected the OpsRequest to fit in the maintenance window, but got: %v", err)
+	}
+	ops.Spec.ExpectedDurationSeconds = pointer.Int32(7200)
+	if err := ops.validateMaintenanceWindow(cluster); err == nil {
+		t.Error("expected the OpsRequest exceeding the maintenance window to be rejected")
+	}
+	ops.Spec.MaintenanceWindow = &MaintenanceWindow{
+		Schedule: "0 2 * * 6",
+		Duration: metav1.Duration{Duration: 3 * time.Hour},
+		TimeZone: "UTC",
+	}
+	if err := ops.validateMaintenanceWindow(cluster); err != nil {
+		t.Errorf("expected the window of the OpsRequest to take precedence, but got: %v", err)
+	}
+}
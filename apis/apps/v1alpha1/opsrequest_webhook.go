@@ -22,22 +22,29 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/exp/slices"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 const (
@@ -55,14 +62,168 @@ var (
 func (r *OpsRequest) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(&opsRequestAdmission{}).
+		WithValidator(&opsRequestAdmission{}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-apps-kubeblocks-io-v1alpha1-opsrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsrequests,verbs=create;update,versions=v1alpha1,name=mopsrequest.kb.io,admissionReviewVersions=v1
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:path=/validate-apps-kubeblocks-io-v1alpha1-opsrequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsrequests,verbs=create;update,versions=v1alpha1,name=vopsrequest.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &OpsRequest{}
 
+// opsRequestAdmission wraps the webhook.Validator of the OpsRequest with the user info of the admission request,
+// which is required to record the requester and to validate the approval of the OpsRequest.
+type opsRequestAdmission struct{}
+
+var _ admission.CustomDefaulter = &opsRequestAdmission{}
+var _ admission.CustomValidator = &opsRequestAdmission{}
+
+// Default records the user who creates the OpsRequest.
+func (a *opsRequestAdmission) Default(ctx context.Context, obj runtime.Object) error {
+	opsRequest, ok := obj.(*OpsRequest)
+	if !ok {
+		return fmt.Errorf("expected an OpsRequest but got a %T", obj)
+	}
+	return defaultRequester(ctx, opsRequest)
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (a *opsRequestAdmission) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	opsRequest, ok := obj.(*OpsRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpsRequest but got a %T", obj)
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = opsRequest.validateApproval(nil, req.UserInfo.Username); err != nil {
+		return nil, err
+	}
+	if webhookMgr != nil {
+		if err = opsRequest.validateCloneAccess(ctx, webhookMgr.client, req.UserInfo); err != nil {
+			return nil, err
+		}
+	}
+	return opsRequest.ValidateCreate()
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (a *opsRequestAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	opsRequest, ok := newObj.(*OpsRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpsRequest but got a %T", newObj)
+	}
+	lastOpsRequest, ok := oldObj.(*OpsRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpsRequest but got a %T", oldObj)
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = opsRequest.validateApproval(lastOpsRequest, req.UserInfo.Username); err != nil {
+		return nil, err
+	}
+	if webhookMgr != nil && !reflect.DeepEqual(lastOpsRequest.Spec.CloneSpec, opsRequest.Spec.CloneSpec) {
+		if err = opsRequest.validateCloneAccess(ctx, webhookMgr.client, req.UserInfo); err != nil {
+			return nil, err
+		}
+	}
+	return opsRequest.ValidateUpdate(oldObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (a *opsRequestAdmission) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	opsRequest, ok := obj.(*OpsRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpsRequest but got a %T", obj)
+	}
+	return opsRequest.ValidateDelete()
+}
+
+// opsRequesterAdmission records the user who creates the objects which create OpsRequests on behalf of the user,
+// e.g. OpsPipeline and OpsFleet, the requester is propagated to the OpsRequests created by them.
+type opsRequesterAdmission struct{}
+
+var _ admission.CustomDefaulter = &opsRequesterAdmission{}
+var _ admission.CustomValidator = &opsRequesterAdmission{}
+
+// Default records the user who creates the object.
+func (a *opsRequesterAdmission) Default(ctx context.Context, obj runtime.Object) error {
+	o, ok := obj.(client.Object)
+	if !ok {
+		return fmt.Errorf("expected a client.Object but got a %T", obj)
+	}
+	return defaultRequester(ctx, o)
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (a *opsRequesterAdmission) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (a *opsRequesterAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, ok := newObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("expected a client.Object but got a %T", newObj)
+	}
+	lastObj, ok := oldObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("expected a client.Object but got a %T", oldObj)
+	}
+	if lastObj.GetAnnotations()[constant.OpsRequestRequestedByAnnotationKey] != o.GetAnnotations()[constant.OpsRequestRequestedByAnnotationKey] {
+		return nil, fmt.Errorf(`the annotation "%s" of %s is immutable`, constant.OpsRequestRequestedByAnnotationKey, o.GetName())
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (a *opsRequesterAdmission) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// defaultRequester records the requester annotation of the object on creation.
+func defaultRequester(ctx context.Context, obj client.Object) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constant.OpsRequestRequestedByAnnotationKey] = requesterOf(annotations[constant.OpsRequestRequestedByAnnotationKey], req.UserInfo.Username)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// requesterOf returns the requester of the object created by the user. Only the KubeBlocks controller is trusted to
+// create objects on behalf of the originating user, and the objects originated by the controller itself
+// are requested by constant.OpsRequestRequestedBySystem.
+func requesterOf(originatingUser, username string) string {
+	if !isKubeBlocksController(username) {
+		return username
+	}
+	if originatingUser != "" {
+		return originatingUser
+	}
+	return constant.OpsRequestRequestedBySystem
+}
+
+// isKubeBlocksController checks if the user is the service account of the KubeBlocks controller.
+func isKubeBlocksController(username string) bool {
+	return username == serviceaccount.MakeUsername(viper.GetString(constant.CfgKeyCtrlrMgrNS),
+		viper.GetString(constant.KBServiceAccountName))
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *OpsRequest) ValidateCreate() (admission.Warnings, error) {
 	opsRequestLog.Info("validate create", "name", r.Name)
@@ -98,12 +259,46 @@ func (r *OpsRequest) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// validateApproval validates the approval annotation of the OpsRequest, the approver must be the user who sets
+// the annotation and must not be the requester of the OpsRequest or the KubeBlocks controller.
+// the approval can not be changed once granted.
+func (r *OpsRequest) validateApproval(lastOpsRequest *OpsRequest, username string) error {
+	requestedBy := r.Annotations[constant.OpsRequestRequestedByAnnotationKey]
+	approvedBy := r.Annotations[constant.OpsRequestApprovedByAnnotationKey]
+	if lastOpsRequest != nil {
+		if lastOpsRequest.Annotations[constant.OpsRequestRequestedByAnnotationKey] != requestedBy {
+			return fmt.Errorf(`the annotation "%s" of OpsRequest: %s is immutable`, constant.OpsRequestRequestedByAnnotationKey, r.Name)
+		}
+		lastApprovedBy := lastOpsRequest.Annotations[constant.OpsRequestApprovedByAnnotationKey]
+		if lastApprovedBy != "" {
+			if lastApprovedBy != approvedBy {
+				return fmt.Errorf(`OpsRequest: %s has been approved by "%s", the approval can not be changed`, r.Name, lastApprovedBy)
+			}
+			return nil
+		}
+	}
+	if approvedBy == "" {
+		return nil
+	}
+	if approvedBy != username {
+		return fmt.Errorf(`the annotation "%s" of OpsRequest: %s must be set to the user who approves it: "%s"`,
+			constant.OpsRequestApprovedByAnnotationKey, r.Name, username)
+	}
+	if isKubeBlocksController(username) {
+		return fmt.Errorf(`OpsRequest: %s can not be approved by the KubeBlocks controller "%s"`, r.Name, username)
+	}
+	if approvedBy == requestedBy {
+		return fmt.Errorf(`OpsRequest: %s can not be approved by the requester "%s"`, r.Name, requestedBy)
+	}
+	return nil
+}
+
 // IsComplete checks if opsRequest has been completed.
 func (r *OpsRequest) IsComplete(phases ...OpsPhase) bool {
 	if len(phases) == 0 {
-		return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsFailedPhase}, r.Status.Phase)
+		return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsFailedPhase, OpsDryRunCompletedPhase}, r.Status.Phase)
 	}
-	return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsFailedPhase}, phases[0])
+	return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsFailedPhase, OpsDryRunCompletedPhase}, phases[0])
 }
 
 // Force checks if the current opsRequest can be forcibly executed
@@ -112,6 +307,13 @@ func (r *OpsRequest) Force() bool {
 	return r.Spec.Force && r.Spec.Type != StartType
 }
 
+// IsRebalance checks if the OpsRequest is created by KubeBlocks to rebuild a healthy instance for rebalancing
+// the instances across the topology domains, which is allowed to run when the cluster is Running.
+func (r *OpsRequest) IsRebalance() bool {
+	return r.Spec.Type == RebuildInstanceType && r.Labels[constant.OpsRequestRebalanceLabelKey] != "" &&
+		r.Annotations[constant.OpsRequestRequestedByAnnotationKey] == constant.OpsRequestRequestedBySystem
+}
+
 // validateClusterPhase validates whether the current cluster state supports the OpsRequest
 func (r *OpsRequest) validateClusterPhase(cluster *Cluster) error {
 	opsBehaviour := OpsRequestBehaviourMapper[r.Spec.Type]
@@ -135,7 +337,8 @@ func (r *OpsRequest) validateClusterPhase(cluster *Cluster) error {
 		}
 	}
 	// check if the opsRequest can be executed in the current cluster.
-	if slices.Contains(opsBehaviour.FromClusterPhases, cluster.Status.Phase) {
+	if slices.Contains(opsBehaviour.FromClusterPhases, cluster.Status.Phase) ||
+		(r.IsRebalance() && cluster.Status.Phase == RunningClusterPhase) {
 		return nil
 	}
 	// check if this opsRequest needs to verify cluster phase before opsRequest starts running.
@@ -188,9 +391,46 @@ func (r *OpsRequest) Validate(ctx context.Context,
 			return err
 		}
 	}
+	if err := r.validateMaintenanceWindow(cluster); err != nil {
+		return err
+	}
+	if err := r.validateDependsOn(); err != nil {
+		return err
+	}
 	return r.validateOps(ctx, k8sClient, cluster)
 }
 
+// validateMaintenanceWindow validates the maintenance window which the OpsRequest follows.
+func (r *OpsRequest) validateMaintenanceWindow(cluster *Cluster) error {
+	window := r.Spec.MaintenanceWindow
+	if window == nil && cluster != nil {
+		window = cluster.Spec.MaintenanceWindow
+	}
+	if window == nil {
+		return nil
+	}
+	if err := window.Validate(); err != nil {
+		return err
+	}
+	// the OpsRequest would be held back forever if no window can cover its expected duration.
+	if r.Spec.ExpectedDurationSeconds != nil {
+		expectedDuration := time.Duration(*r.Spec.ExpectedDurationSeconds) * time.Second
+		if expectedDuration > window.Duration.Duration {
+			return fmt.Errorf("the expected duration %s of the OpsRequest exceeds the maintenance window duration %s",
+				expectedDuration, window.Duration.Duration)
+		}
+	}
+	return nil
+}
+
+// validateDependsOn validates the OpsRequests which this OpsRequest depends on.
+func (r *OpsRequest) validateDependsOn() error {
+	if slices.Contains(r.Spec.DependsOn, r.Name) {
+		return fmt.Errorf(`OpsRequest "%s" can not depend on itself`, r.Name)
+	}
+	return nil
+}
+
 // ValidateEntry OpsRequest webhook validate entry
 func (r *OpsRequest) validateEntry(isCreate bool) error {
 	if webhookMgr == nil || webhookMgr.client == nil {
@@ -219,12 +459,22 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 		return r.validateHorizontalScaling(ctx, k8sClient, cluster)
 	case VolumeExpansionType:
 		return r.validateVolumeExpansion(ctx, k8sClient, cluster)
+	case VolumeMigrationType:
+		return r.validateVolumeMigration(ctx, k8sClient, cluster)
 	case RestartType:
 		return r.validateRestart(cluster)
 	case ReconfiguringType:
 		return r.validateReconfigure(ctx, k8sClient, cluster)
 	case SwitchoverType:
 		return r.validateSwitchover(ctx, k8sClient, cluster)
+	case FailoverType:
+		return r.validateFailover(ctx, k8sClient, cluster)
+	case ShardScalingType:
+		return r.validateShardScaling(cluster)
+	case CloneType:
+		return r.validateClone()
+	case AdoptType:
+		return r.validateAdopt()
 	case DataScriptType:
 		return r.validateDataScript(ctx, k8sClient, cluster)
 	case ExposeType:
@@ -233,6 +483,88 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 	return nil
 }
 
+// validateClone validates clone api when spec.type is Clone
+func (r *OpsRequest) validateClone() error {
+	cloneSpec := r.Spec.CloneSpec
+	if cloneSpec == nil {
+		return notEmptyError("spec.clone")
+	}
+	sourceNamespace := cloneSpec.SourceClusterNamespace
+	if sourceNamespace == "" {
+		sourceNamespace = r.Namespace
+	}
+	if sourceNamespace == r.Namespace && cloneSpec.SourceClusterName == r.Spec.ClusterRef {
+		return fmt.Errorf(`the cluster "%s" can not be cloned from itself`, r.Spec.ClusterRef)
+	}
+	if cloneSpec.RestoreTime != "" {
+		if _, err := time.Parse(time.RFC3339, cloneSpec.RestoreTime); err != nil {
+			return fmt.Errorf(`invalid restoreTime "%s", it should be in RFC3339 format: %s`, cloneSpec.RestoreTime, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateCloneAccess validates that the requester has access to the source cluster and its backups when cloning
+// from another namespace, as the cluster is cloned by the KubeBlocks controller on behalf of the requester.
+// The OpsRequest created by the KubeBlocks controller is checked against its originating user.
+func (r *OpsRequest) validateCloneAccess(ctx context.Context, k8sClient client.Client, userInfo authenticationv1.UserInfo) error {
+	cloneSpec := r.Spec.CloneSpec
+	if r.Spec.Type != CloneType || cloneSpec == nil ||
+		cloneSpec.SourceClusterNamespace == "" || cloneSpec.SourceClusterNamespace == r.Namespace {
+		return nil
+	}
+	if isKubeBlocksController(userInfo.Username) {
+		requestedBy := r.Annotations[constant.OpsRequestRequestedByAnnotationKey]
+		if requestedBy == constant.OpsRequestRequestedBySystem {
+			return nil
+		}
+		userInfo = authenticationv1.UserInfo{Username: requestedBy}
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	for _, attr := range []authorizationv1.ResourceAttributes{
+		{Verb: "get", Group: GroupVersion.Group, Resource: "clusters", Name: cloneSpec.SourceClusterName},
+		{Verb: "list", Group: dpv1alpha1.GroupVersion.Group, Resource: "backups"},
+	} {
+		attr.Namespace = cloneSpec.SourceClusterNamespace
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: &attr,
+				User:               userInfo.Username,
+				Groups:             userInfo.Groups,
+				UID:                userInfo.UID,
+				Extra:              extra,
+			},
+		}
+		if err := k8sClient.Create(ctx, review); err != nil {
+			return err
+		}
+		if !review.Status.Allowed {
+			return fmt.Errorf(`user "%s" is not allowed to %s %s in the namespace "%s" of the source cluster "%s"`,
+				userInfo.Username, attr.Verb, attr.Resource, attr.Namespace, cloneSpec.SourceClusterName)
+		}
+	}
+	return nil
+}
+
+// validateAdopt validates adopt api when spec.type is Adopt
+func (r *OpsRequest) validateAdopt() error {
+	adoptSpec := r.Spec.AdoptSpec
+	if adoptSpec == nil {
+		return notEmptyError("spec.adopt")
+	}
+	volumeNames := map[string]string{}
+	for _, m := range adoptSpec.VolumeMappings {
+		if vct, ok := volumeNames[m.VolumeName]; ok {
+			return fmt.Errorf(`the volume "%s" is mapped by both volume claim templates "%s" and "%s"`, m.VolumeName, vct, m.VolumeClaimTemplate)
+		}
+		volumeNames[m.VolumeName] = m.VolumeClaimTemplate
+	}
+	return nil
+}
+
 // validateExpose validates expose api when spec.type is Expose
 func (r *OpsRequest) validateExpose(_ context.Context, cluster *Cluster) error {
 	exposeList := r.Spec.ExposeList
@@ -415,6 +747,76 @@ func (r *OpsRequest) validateVolumeExpansion(ctx context.Context, cli client.Cli
 	return r.checkVolumesAllowExpansion(ctx, cli, cluster)
 }
 
+// validateVolumeMigration validates volumeMigration api when spec.type is VolumeMigration.
+func (r *OpsRequest) validateVolumeMigration(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	volumeMigrationList := r.Spec.VolumeMigrationList
+	if len(volumeMigrationList) == 0 {
+		return notEmptyError("spec.volumeMigration")
+	}
+
+	componentNames := make([]string, len(volumeMigrationList))
+	for i, v := range volumeMigrationList {
+		componentNames[i] = v.ComponentName
+	}
+	if err := r.checkComponentExistence(cluster, componentNames); err != nil {
+		return err
+	}
+	for _, v := range volumeMigrationList {
+		vctNames := map[string]struct{}{}
+		for _, vct := range cluster.Spec.GetComponentByName(v.ComponentName).VolumeClaimTemplates {
+			vctNames[vct.Name] = struct{}{}
+		}
+		for _, vct := range v.VolumeClaimTemplates {
+			if vct.StorageClassName == nil && vct.Storage == nil {
+				return fmt.Errorf("either storageClassName or storage must be specified for the volumeClaimTemplate: %s in component: %s", vct.Name, v.ComponentName)
+			}
+			if _, ok := vctNames[vct.Name]; !ok {
+				return fmt.Errorf("volumeClaimTemplate: %s not found in component: %s, you can view infos by command: "+
+					"kbcli cluster describe %s -n %s", vct.Name, v.ComponentName, cluster.Name, r.Namespace)
+			}
+			if vct.StorageClassName == nil || *vct.StorageClassName == "" {
+				continue
+			}
+			if err := cli.Get(ctx, types.NamespacedName{Name: *vct.StorageClassName}, &storagev1.StorageClass{}); err != nil {
+				return err
+			}
+		}
+		if err := validateVolumeMigrationSource(ctx, cli, cluster, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateVolumeMigrationSource validates the data source of the new volumes, the data must be seeded from a backup or a peer,
+// otherwise the instances would be recreated with empty volumes.
+func validateVolumeMigrationSource(ctx context.Context, cli client.Client, cluster *Cluster, volumeMigration VolumeMigration) error {
+	if volumeMigration.BackupName != "" {
+		return nil
+	}
+	if !volumeMigration.FromPeer {
+		return fmt.Errorf("either backupName or fromPeer must be specified to migrate the volumes of component: %s", volumeMigration.ComponentName)
+	}
+	compSpec := cluster.Spec.GetComponentByName(volumeMigration.ComponentName)
+	if compSpec.Replicas <= 1 {
+		return fmt.Errorf("there is no peer to stream the data from as component: %s has only %d replica, please specify the backupName",
+			volumeMigration.ComponentName, compSpec.Replicas)
+	}
+	if compSpec.ComponentDef == "" {
+		return fmt.Errorf("component: %s does not support streaming the data from a peer, please specify the backupName", volumeMigration.ComponentName)
+	}
+	compDef, err := getComponentDefByName(ctx, cli, compSpec.ComponentDef)
+	if err != nil {
+		return err
+	}
+	actions := compDef.Spec.LifecycleActions
+	if actions == nil || actions.DataDump == nil || actions.DataLoad == nil {
+		return fmt.Errorf(`the dataDump and dataLoad actions are not defined in ComponentDefinition: %s to stream the data from a peer, `+
+			`please specify the backupName`, compDef.Name)
+	}
+	return nil
+}
+
 // validateSwitchover validates switchover api when spec.type is Switchover.
 func (r *OpsRequest) validateSwitchover(ctx context.Context, cli client.Client, cluster *Cluster) error {
 	switchoverList := r.Spec.SwitchoverList
@@ -432,6 +834,56 @@ func (r *OpsRequest) validateSwitchover(ctx context.Context, cli client.Client,
 	return validateSwitchoverResourceList(ctx, cli, cluster, switchoverList)
 }
 
+// validateFailover validates failover api when spec.type is Failover.
+func (r *OpsRequest) validateFailover(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	failoverList := r.Spec.FailoverList
+	if len(failoverList) == 0 {
+		return notEmptyError("spec.failover")
+	}
+	componentNames := make([]string, len(failoverList))
+	for i, v := range failoverList {
+		componentNames[i] = v.ComponentName
+	}
+	if err := r.checkComponentExistence(cluster, componentNames); err != nil {
+		return err
+	}
+	for _, failover := range failoverList {
+		if failover.InstanceName == "" {
+			continue
+		}
+		pod := &corev1.Pod{}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: failover.InstanceName}, pod); err != nil {
+			return fmt.Errorf("get instanceName %s failed, err: %s, and check the validity of the instanceName using \"kbcli cluster list-instances\"", failover.InstanceName, err.Error())
+		}
+		if pod.Labels[constant.KBAppComponentLabelKey] != failover.ComponentName || pod.Labels[constant.AppInstanceLabelKey] != cluster.Name {
+			return fmt.Errorf("instanceName %s does not belong to the current component, please check the validity of the instance using \"kbcli cluster list-instances\"", failover.InstanceName)
+		}
+	}
+	return nil
+}
+
+// validateShardScaling validates shardScaling api when spec.type is ShardScaling.
+func (r *OpsRequest) validateShardScaling(cluster *Cluster) error {
+	shardScalingList := r.Spec.ShardScalingList
+	if len(shardScalingList) == 0 {
+		return notEmptyError("spec.shardScaling")
+	}
+	shardingSpecNameMap := make(map[string]bool)
+	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
+		shardingSpecNameMap[shardingSpec.Name] = true
+	}
+	var notFoundShardingNames []string
+	for _, shardScaling := range shardScalingList {
+		if _, ok := shardingSpecNameMap[shardScaling.ShardingName]; !ok {
+			notFoundShardingNames = append(notFoundShardingNames, shardScaling.ShardingName)
+		}
+	}
+	if len(notFoundShardingNames) > 0 {
+		return fmt.Errorf("shardings: %v not found in cluster %s", notFoundShardingNames, cluster.Name)
+	}
+	return nil
+}
+
 // checkComponentExistence checks whether components to be operated exist in cluster spec.
 func (r *OpsRequest) checkComponentExistence(cluster *Cluster, compNames []string) error {
 	compSpecNameMap := make(map[string]bool)
@@ -24,7 +24,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/sethvargo/go-password/password"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("OpsRequest webhook", func() {
@@ -767,6 +770,123 @@ var _ = Describe("OpsRequest webhook", func() {
 			opsRequest.Spec.TTLSecondsBeforeAbort = int32Ptr(0)
 			Expect(testCtx.CheckedCreateObj(ctx, opsRequest)).Should(Succeed())
 		})
+
+		It("check the approval of opsRequest", func() {
+			opsRequest := createTestOpsRequest(clusterName, opsRequestName, StopType)
+			opsRequest.Annotations = map[string]string{constant.OpsRequestRequestedByAnnotationKey: "requester"}
+
+			By("By testing self-approval, should fail")
+			opsRequest.Annotations[constant.OpsRequestApprovedByAnnotationKey] = "requester"
+			Expect(opsRequest.validateApproval(nil, "requester")).Should(HaveOccurred())
+
+			By("By testing approval on behalf of another user, should fail")
+			lastOpsRequest := opsRequest.DeepCopy()
+			delete(lastOpsRequest.Annotations, constant.OpsRequestApprovedByAnnotationKey)
+			opsRequest.Annotations[constant.OpsRequestApprovedByAnnotationKey] = "approver"
+			Expect(opsRequest.validateApproval(lastOpsRequest, "requester")).Should(HaveOccurred())
+
+			By("By testing approval by another user, should succeed")
+			Expect(opsRequest.validateApproval(lastOpsRequest, "approver")).Should(Succeed())
+
+			By("By testing changing the requester or the granted approval, should fail")
+			lastOpsRequest = opsRequest.DeepCopy()
+			opsRequest.Annotations[constant.OpsRequestRequestedByAnnotationKey] = "approver"
+			Expect(opsRequest.validateApproval(lastOpsRequest, "approver")).Should(HaveOccurred())
+			opsRequest.Annotations[constant.OpsRequestRequestedByAnnotationKey] = "requester"
+			delete(opsRequest.Annotations, constant.OpsRequestApprovedByAnnotationKey)
+			Expect(opsRequest.validateApproval(lastOpsRequest, "approver")).Should(HaveOccurred())
+
+			By("By testing approval by the KubeBlocks controller, should fail")
+			viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
+			viper.Set(constant.KBServiceAccountName, "kubeblocks")
+			controller := "system:serviceaccount:kb-system:kubeblocks"
+			opsRequest.Annotations[constant.OpsRequestRequestedByAnnotationKey] = constant.OpsRequestRequestedBySystem
+			lastOpsRequest = opsRequest.DeepCopy()
+			opsRequest.Annotations[constant.OpsRequestApprovedByAnnotationKey] = controller
+			Expect(opsRequest.validateApproval(lastOpsRequest, controller)).Should(HaveOccurred())
+
+			By("By testing approval of the OpsRequest originated by KubeBlocks, should succeed")
+			opsRequest.Annotations[constant.OpsRequestApprovedByAnnotationKey] = "approver"
+			Expect(opsRequest.validateApproval(lastOpsRequest, "approver")).Should(Succeed())
+		})
+
+		It("check the data source of volumeMigration", func() {
+			cluster := &Cluster{Spec: ClusterSpec{ComponentSpecs: []ClusterComponentSpec{{Name: componentName, Replicas: 1}}}}
+			volumeMigration := VolumeMigration{ComponentOps: ComponentOps{ComponentName: componentName}}
+
+			By("By testing neither backupName nor fromPeer is specified, should fail")
+			Expect(validateVolumeMigrationSource(ctx, k8sClient, cluster, volumeMigration)).Should(HaveOccurred())
+
+			By("By testing backupName is specified, should succeed")
+			volumeMigration.BackupName = "backup"
+			Expect(validateVolumeMigrationSource(ctx, k8sClient, cluster, volumeMigration)).Should(Succeed())
+
+			By("By testing fromPeer without any peer, should fail")
+			volumeMigration.BackupName = ""
+			volumeMigration.FromPeer = true
+			Expect(validateVolumeMigrationSource(ctx, k8sClient, cluster, volumeMigration)).Should(HaveOccurred())
+
+			By("By testing fromPeer without the ComponentDefinition, should fail")
+			cluster.Spec.ComponentSpecs[0].Replicas = 3
+			Expect(validateVolumeMigrationSource(ctx, k8sClient, cluster, volumeMigration)).Should(HaveOccurred())
+		})
+
+		It("check the access to the source cluster of clone", func() {
+			sourceNamespace := testCtx.DefaultNamespace
+			user := authenticationv1.UserInfo{Username: "clone-user-" + randomStr}
+			opsRequest := createTestOpsRequest("clone-"+clusterName, opsRequestName, CloneType)
+			opsRequest.Namespace = "dev-" + randomStr
+			opsRequest.Spec.CloneSpec = &CloneSpec{SourceClusterName: clusterName, SourceClusterNamespace: sourceNamespace}
+
+			By("By testing the user without access to the source namespace, should fail")
+			Expect(opsRequest.validateCloneAccess(ctx, k8sClient, user)).Should(HaveOccurred())
+
+			By("By testing the originating user of the OpsRequest created by the controller, should fail")
+			viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
+			viper.Set(constant.KBServiceAccountName, "kubeblocks")
+			opsRequest.Annotations = map[string]string{constant.OpsRequestRequestedByAnnotationKey: user.Username}
+			controller := authenticationv1.UserInfo{Username: "system:serviceaccount:kb-system:kubeblocks"}
+			Expect(opsRequest.validateCloneAccess(ctx, k8sClient, controller)).Should(HaveOccurred())
+
+			By("By granting the user access to the source cluster and backups, should succeed")
+			role := &rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "clone-source-" + randomStr, Namespace: sourceNamespace},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{GroupVersion.Group}, Resources: []string{"clusters"}, Verbs: []string{"get"}},
+					{APIGroups: []string{"dataprotection.kubeblocks.io"}, Resources: []string{"backups"}, Verbs: []string{"list"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, role)).Should(Succeed())
+			roleBinding := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: role.Name, Namespace: sourceNamespace},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: user.Username}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.Name},
+			}
+			Expect(k8sClient.Create(ctx, roleBinding)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, roleBinding)).Should(Succeed())
+				Expect(k8sClient.Delete(ctx, role)).Should(Succeed())
+			})
+			Eventually(func() error {
+				return opsRequest.validateCloneAccess(ctx, k8sClient, user)
+			}).Should(Succeed())
+			Expect(opsRequest.validateCloneAccess(ctx, k8sClient, controller)).Should(Succeed())
+		})
+
+		It("check the requester of opsRequest", func() {
+			viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
+			viper.Set(constant.KBServiceAccountName, "kubeblocks")
+			controller := "system:serviceaccount:kb-system:kubeblocks"
+
+			By("By testing the requester set by a user, should be overwritten")
+			Expect(requesterOf("someone", "requester")).Should(Equal("requester"))
+
+			By("By testing the originating user propagated by the controller, should be kept")
+			Expect(requesterOf("requester", controller)).Should(Equal("requester"))
+
+			By("By testing the OpsRequest originated by the controller, should be requested by the system")
+			Expect(requesterOf("", controller)).Should(Equal(constant.OpsRequestRequestedBySystem))
+		})
 	})
 })
 
@@ -302,6 +302,7 @@ const (
 	ConditionTypeReplicasReady       = "ReplicasReady"       // ConditionTypeReplicasReady all pods of components are ready
 	ConditionTypeReady               = "Ready"               // ConditionTypeReady all components are running
 	ConditionTypeSwitchoverPrefix    = "Switchover-"         // ConditionTypeSwitchoverPrefix component status condition of switchover
+	ConditionTypeDriftDetected       = "DriftDetected"       // ConditionTypeDriftDetected the objects owned by the cluster or component are modified out of KubeBlocks
 )
 
 // Phase represents the current status of the ClusterDefinition and ClusterVersion CR.
@@ -320,17 +321,42 @@ const (
 
 // OpsPhase defines opsRequest phase.
 // +enum
-// +kubebuilder:validation:Enum={Pending,Creating,Running,Cancelling,Cancelled,Failed,Succeed}
+// +kubebuilder:validation:Enum={Pending,Scheduled,Creating,Running,Cancelling,Cancelled,Failed,Succeed,DryRunCompleted}
 type OpsPhase string
 
 const (
 	OpsPendingPhase    OpsPhase = "Pending"
+	OpsScheduledPhase  OpsPhase = "Scheduled"
 	OpsCreatingPhase   OpsPhase = "Creating"
 	OpsRunningPhase    OpsPhase = "Running"
 	OpsCancellingPhase OpsPhase = "Cancelling"
 	OpsSucceedPhase    OpsPhase = "Succeed"
 	OpsCancelledPhase  OpsPhase = "Cancelled"
 	OpsFailedPhase     OpsPhase = "Failed"
+
+	// OpsDryRunCompletedPhase indicates that the OpsRequest running in dry-run mode has rendered its impact.
+	OpsDryRunCompletedPhase OpsPhase = "DryRunCompleted"
+)
+
+// OpsPipelineFailurePolicy defines what to do when a step of the OpsPipeline fails.
+// +enum
+// +kubebuilder:validation:Enum={Abort,Continue}
+type OpsPipelineFailurePolicy string
+
+const (
+	OpsPipelineAbortOnFailure    OpsPipelineFailurePolicy = "Abort"    // OpsPipelineAbortOnFailure fails the OpsPipeline and skips the rest steps.
+	OpsPipelineContinueOnFailure OpsPipelineFailurePolicy = "Continue" // OpsPipelineContinueOnFailure records the failure and runs the next step.
+)
+
+// DryRunActionType defines the action that would be performed on an object by the OpsRequest in dry-run mode.
+// +enum
+// +kubebuilder:validation:Enum={Create,Update,Delete}
+type DryRunActionType string
+
+const (
+	DryRunCreateAction DryRunActionType = "Create"
+	DryRunUpdateAction DryRunActionType = "Update"
+	DryRunDeleteAction DryRunActionType = "Delete"
 )
 
 // PodSelectionPolicy pod selection strategy.
@@ -366,7 +392,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,Clone,Adopt,RebuildInstance,VolumeMigration,Failover,ShardScaling,Custom}
 type OpsType string
 
 const (
@@ -383,7 +409,12 @@ const (
 	DataScriptType        OpsType = "DataScript" // DataScriptType the data script operation will execute the data script against the cluster.
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
+	CloneType             OpsType = "Clone"           // CloneType creates a new cluster from the backups of an existing cluster, optionally as of a point in time.
+	AdoptType             OpsType = "Adopt"           // AdoptType creates a new cluster which takes over the pods and volumes of an existing StatefulSet.
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
+	VolumeMigrationType   OpsType = "VolumeMigration" // VolumeMigration rebuilds the instances one by one onto the volumes with new storage class or size.
+	FailoverType          OpsType = "Failover"        // Failover fences the unhealthy primary and promotes a candidate forcibly.
+	ShardScalingType      OpsType = "ShardScaling"    // ShardScaling adds or removes the shards of a sharding, and migrates the data between them.
 	CustomType            OpsType = "Custom"          // use opsDefinition
 )
 
@@ -414,10 +445,10 @@ const (
 
 // UpdateStrategy defines the update strategy for cluster components. This strategy determines how updates are applied
 // across the cluster.
-// The available strategies are `Serial`, `BestEffortParallel`, and `Parallel`.
+// The available strategies are `Serial`, `BestEffortParallel`, `Parallel`, and `BlueGreen`.
 //
 // +enum
-// +kubebuilder:validation:Enum={Serial,BestEffortParallel,Parallel}
+// +kubebuilder:validation:Enum={Serial,BestEffortParallel,Parallel,BlueGreen}
 type UpdateStrategy string
 
 const (
@@ -444,6 +475,12 @@ const (
 	//
 	// The `BestEffortParallel` strategy strikes a balance between update speed and component availability.
 	BestEffortParallelStrategy UpdateStrategy = "BestEffortParallel"
+
+	// BlueGreenStrategy indicates that a parallel set of replicas is brought up on the new revision, and the Component
+	// is switched to them after they are ready. The replicas of the new set are named after the workload with
+	// the `-green` suffix, or without the suffix if the replicas of the current set have it.
+	// The old replicas are kept for the rollback period before they are torn down.
+	BlueGreenStrategy UpdateStrategy = "BlueGreen"
 )
 
 var DefaultLeader = ConsensusMember{
@@ -721,7 +758,7 @@ const (
 
 // IssuerName defines the name of the TLS certificates issuer.
 // +enum
-// +kubebuilder:validation:Enum={KubeBlocks,UserProvided}
+// +kubebuilder:validation:Enum={KubeBlocks,UserProvided,CertManager}
 type IssuerName string
 
 const (
@@ -730,6 +767,23 @@ const (
 
 	// IssuerUserProvided indicates that the user has provided their own CA-signed certificates.
 	IssuerUserProvided IssuerName = "UserProvided"
+
+	// IssuerCertManager indicates that the certificates are issued by cert-manager through Certificate resources.
+	IssuerCertManager IssuerName = "CertManager"
+)
+
+// TLSCertReloadPolicy defines how the engine picks up the rotated TLS certificates.
+// +enum
+// +kubebuilder:validation:Enum={Restart,Reload}
+type TLSCertReloadPolicy string
+
+const (
+	// TLSCertReloadPolicyRestart restarts the instances in a rolling manner to pick up the rotated certificates.
+	TLSCertReloadPolicyRestart TLSCertReloadPolicy = "Restart"
+
+	// TLSCertReloadPolicyReload invokes the reconfigure action on the instances to reload the rotated certificates
+	// from the mounted files dynamically.
+	TLSCertReloadPolicyReload TLSCertReloadPolicy = "Reload"
 )
 
 // SwitchPolicyType defines the types of switch policies that can be applied to a cluster.
@@ -20,6 +20,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -233,6 +234,21 @@ type InstanceSetSpec struct {
 	// Note: This field will be removed in future version.
 	UpdateStrategy appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
 
+	// Specifies the maximum number of extra instances that can be created over the desired replicas during the rolling update.
+	// Value can be an absolute number (ex: 1) or a percentage of the replicas (ex: 10%), which is rounded up.
+	// Defaults to 0, which means the old instances are taken down before they are updated.
+	//
+	// The surge instances are created on the update revision from the default instance template, and joined to the
+	// replication group by the MemberJoin action before any old instance is taken down, so that the healthy members
+	// don't drop below the replicas during the update. They are removed by the MemberLeave action and torn down
+	// once the update completes.
+	//
+	// It takes effect when the UpdateStrategy.Type is RollingUpdate.
+	//
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
 	// A list of roles defined in the system.
 	//
 	// +optional
@@ -253,21 +269,129 @@ type InstanceSetSpec struct {
 	// - serial: update Members one by one that guarantee minimum component unavailable time.
 	// - bestEffortParallel: update Members in parallel that guarantee minimum component un-writable time.
 	// - parallel: force parallel
+	// - blueGreen: bring up a parallel set of Members on the new revision, and switch to them after they are ready.
 	//
-	// +kubebuilder:validation:Enum={Serial,BestEffortParallel,Parallel}
+	// +kubebuilder:validation:Enum={Serial,BestEffortParallel,Parallel,BlueGreen}
 	// +optional
 	MemberUpdateStrategy *MemberUpdateStrategy `json:"memberUpdateStrategy,omitempty"`
 
+	// Specifies the settings of the blue/green update, which takes effect when the MemberUpdateStrategy is BlueGreen.
+	//
+	// +optional
+	BlueGreenUpdate *BlueGreenUpdate `json:"blueGreenUpdate,omitempty"`
+
+	// Restricts the Pods which can be updated to the latest revision, which is used to update the Pods in steps,
+	// such as the canary update. The Pods are considered in the update order, from the lowest role priority to the highest.
+	// All Pods can be updated if it is nil.
+	//
+	// +optional
+	UpdatePartition *UpdatePartition `json:"updatePartition,omitempty"`
+
+	// Specifies whether the leader is protected from the voluntary disruptions, such as the evictions triggered by
+	// node drains. If it is true, the leader is selected by a PodDisruptionBudget with `maxUnavailable: 0`, and it can
+	// be evicted only after a switchover has moved the leadership to another member, so the node drains are blocked
+	// until then. The quorum of the voting members is protected regardless of it.
+	//
+	// +optional
+	ProtectLeaderFromEviction bool `json:"protectLeaderFromEviction,omitempty"`
+
 	// Indicates that the InstanceSet is paused, meaning the reconciliation of this InstanceSet object will be paused.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
 
+	// Specifies the instances which are held for maintenance, such as the investigation or manual repair,
+	// while the other instances keep reconciling.
+	//
+	// The held instances are not updated by the rolling update, and they are excluded from the Services which
+	// select the instances by roles. Optionally, they are excluded from the candidates of the leader role.
+	//
+	// +optional
+	HeldInstances []HeldInstance `json:"heldInstances,omitempty"`
+
+	// Specifies how the instances are spread across the topology domains, such as the zones.
+	//
+	// The topology spread constraints only take effect when the instances are scheduled, the instances may be
+	// concentrated in some of the domains after the node failures. If set, the domain of each instance is tracked
+	// and the imbalance is reported in the status, with a follower instance suggested to be rebuilt in the
+	// under-represented domain.
+	//
+	// +optional
+	PlacementPolicy *InstancePlacementPolicy `json:"placementPolicy,omitempty"`
+
 	// Credential used to connect to DB engine
 	//
 	// +optional
 	Credential *Credential `json:"credential,omitempty"`
 }
 
+// HeldInstance specifies an instance which is held for maintenance.
+type HeldInstance struct {
+	// Specifies the name of the instance (Pod).
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Records the reason why the instance is held.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Indicates whether the instance is excluded from the candidates of the leader role,
+	// the leader role is not switched over to the instance if it is true, neither by the Switchover
+	// and Failover OpsRequests nor by the HA of lorry.
+	//
+	// +optional
+	ExcludeFromLeaderCandidates bool `json:"excludeFromLeaderCandidates,omitempty"`
+}
+
+// InstancePlacementPolicy defines how the instances are spread across the topology domains.
+type InstancePlacementPolicy struct {
+	// Specifies the label key of the nodes whose values are the topology domains.
+	//
+	// +kubebuilder:default="topology.kubernetes.io/zone"
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// Specifies the maximum permitted difference between the numbers of the instances in any two domains.
+	// The domains are the ones of the schedulable nodes, including those without any instance.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+}
+
+// UpdatePartition restricts the Pods which can be updated.
+type UpdatePartition struct {
+	// Specifies the maximum number of the Pods which can be updated.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Specifies the roles of the Pods which can be updated. Pods of all roles can be updated if it is empty.
+	//
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+}
+
+// BlueGreenUpdate defines the settings of the blue/green update.
+//
+// The blue/green update brings up a parallel set of instances on the new revision, named after the InstanceSet
+// with the `-green` suffix, or without the suffix if the current instances have it. The new instances are joined
+// to the replication group by the MemberJoin action, and the leader role is moved to them by the Switchover action
+// after all of them are available and have their roles probed. The Services select the new instances after the switch.
+//
+// The old instances are kept for the rollback period, and the update can be rolled back by reverting the spec
+// during this period, which switches back to the old instances.
+type BlueGreenUpdate struct {
+	// Specifies how long the old instances are kept after the switch, before they are torn down.
+	//
+	// +kubebuilder:default="1h"
+	// +optional
+	RollbackPeriod *metav1.Duration `json:"rollbackPeriod,omitempty"`
+}
+
 // InstanceSetStatus defines the observed state of InstanceSet
 type InstanceSetStatus struct {
 	appsv1.StatefulSetStatus `json:",inline"`
@@ -303,6 +427,91 @@ type InstanceSetStatus struct {
 	//
 	// +optional
 	UpdateRevisions map[string]string `json:"updateRevisions,omitempty"`
+
+	// Lists the names of the existing instances which are held for maintenance.
+	//
+	// +optional
+	HeldInstances []string `json:"heldInstances,omitempty"`
+
+	// Provides the status of the placement of the instances across the topology domains.
+	//
+	// +optional
+	Placement *InstancePlacementStatus `json:"placement,omitempty"`
+
+	// Provides the status of the blue/green update.
+	//
+	// +optional
+	BlueGreen *BlueGreenUpdateStatus `json:"blueGreen,omitempty"`
+}
+
+// InstancePlacementStatus represents the placement of the instances across the topology domains.
+type InstancePlacementStatus struct {
+	// Specifies the label key of the nodes whose values are the topology domains.
+	//
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// Lists the instances in each domain, the domains are sorted by name.
+	//
+	// +optional
+	Domains []TopologyDomainStatus `json:"domains,omitempty"`
+
+	// Lists the instances which are not scheduled yet, or whose domain is unknown.
+	//
+	// +optional
+	UnplacedInstances []string `json:"unplacedInstances,omitempty"`
+
+	// Represents the difference between the numbers of the instances in the most and the least populated domains.
+	//
+	// +optional
+	Skew int32 `json:"skew,omitempty"`
+
+	// Indicates whether the skew exceeds the `maxSkew` of the placement policy.
+	//
+	// +optional
+	Imbalanced bool `json:"imbalanced,omitempty"`
+
+	// Suggests the instance to be rebuilt in another domain to reduce the skew.
+	// It is always a follower in the most populated domain, the leader is never suggested.
+	//
+	// +optional
+	RebalanceInstance string `json:"rebalanceInstance,omitempty"`
+
+	// Specifies the least populated domain, where the suggested instance is expected to be rebuilt.
+	//
+	// +optional
+	RebalanceTargetDomain string `json:"rebalanceTargetDomain,omitempty"`
+}
+
+// TopologyDomainStatus lists the instances in a topology domain.
+type TopologyDomainStatus struct {
+	// Specifies the name of the domain, which is the value of the topology key.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Lists the names of the instances in the domain.
+	//
+	// +optional
+	Instances []string `json:"instances,omitempty"`
+}
+
+// BlueGreenUpdateStatus represents the status of the blue/green update.
+type BlueGreenUpdateStatus struct {
+	// Specifies the set of instances which are active, the other set is the one being brought up or torn down.
+	//
+	// +optional
+	ActiveSet InstanceColor `json:"activeSet,omitempty"`
+
+	// Specifies the phase of the ongoing blue/green update, it is empty if there is no update in progress.
+	//
+	// +optional
+	Phase BlueGreenUpdatePhase `json:"phase,omitempty"`
+
+	// Specifies the time when the leader role was switched to the active set.
+	//
+	// +optional
+	SwitchTime *metav1.Time `json:"switchTime,omitempty"`
 }
 
 // +genclient
@@ -386,6 +595,29 @@ const (
 	SerialUpdateStrategy             MemberUpdateStrategy = "Serial"
 	BestEffortParallelUpdateStrategy MemberUpdateStrategy = "BestEffortParallel"
 	ParallelUpdateStrategy           MemberUpdateStrategy = "Parallel"
+	BlueGreenUpdateStrategy          MemberUpdateStrategy = "BlueGreen"
+)
+
+// InstanceColor distinguishes the two sets of instances of the blue/green update.
+// +enum
+type InstanceColor string
+
+const (
+	BlueInstanceColor  InstanceColor = "Blue"
+	GreenInstanceColor InstanceColor = "Green"
+)
+
+// BlueGreenUpdatePhase defines the phase of the blue/green update.
+// +enum
+type BlueGreenUpdatePhase string
+
+const (
+	// BlueGreenProvisioningPhase indicates the new instances are being created and joined to the replication group.
+	BlueGreenProvisioningPhase BlueGreenUpdatePhase = "Provisioning"
+	// BlueGreenSwitchingPhase indicates the leader role is being moved to the new instances.
+	BlueGreenSwitchingPhase BlueGreenUpdatePhase = "Switching"
+	// BlueGreenRetainingPhase indicates the old instances are kept for the rollback period.
+	BlueGreenRetainingPhase BlueGreenUpdatePhase = "Retaining"
 )
 
 // RoleUpdateMechanism defines the way how pod role label being updated.
@@ -28,6 +28,7 @@ import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -55,6 +56,45 @@ func (in *Action) DeepCopy() *Action {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenUpdate) DeepCopyInto(out *BlueGreenUpdate) {
+	*out = *in
+	if in.RollbackPeriod != nil {
+		in, out := &in.RollbackPeriod, &out.RollbackPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenUpdate.
+func (in *BlueGreenUpdate) DeepCopy() *BlueGreenUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenUpdateStatus) DeepCopyInto(out *BlueGreenUpdateStatus) {
+	*out = *in
+	if in.SwitchTime != nil {
+		in, out := &in.SwitchTime, &out.SwitchTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenUpdateStatus.
+func (in *BlueGreenUpdateStatus) DeepCopy() *BlueGreenUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Credential) DeepCopyInto(out *Credential) {
 	*out = *in
@@ -92,6 +132,63 @@ func (in *CredentialVar) DeepCopy() *CredentialVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeldInstance) DeepCopyInto(out *HeldInstance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeldInstance.
+func (in *HeldInstance) DeepCopy() *HeldInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(HeldInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancePlacementPolicy) DeepCopyInto(out *InstancePlacementPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancePlacementPolicy.
+func (in *InstancePlacementPolicy) DeepCopy() *InstancePlacementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancePlacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancePlacementStatus) DeepCopyInto(out *InstancePlacementStatus) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]TopologyDomainStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnplacedInstances != nil {
+		in, out := &in.UnplacedInstances, &out.UnplacedInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancePlacementStatus.
+func (in *InstancePlacementStatus) DeepCopy() *InstancePlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancePlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceSet) DeepCopyInto(out *InstanceSet) {
 	*out = *in
@@ -197,6 +294,11 @@ func (in *InstanceSetSpec) DeepCopyInto(out *InstanceSetSpec) {
 		}
 	}
 	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	if in.Roles != nil {
 		in, out := &in.Roles, &out.Roles
 		*out = make([]ReplicaRole, len(*in))
@@ -217,6 +319,26 @@ func (in *InstanceSetSpec) DeepCopyInto(out *InstanceSetSpec) {
 		*out = new(MemberUpdateStrategy)
 		**out = **in
 	}
+	if in.BlueGreenUpdate != nil {
+		in, out := &in.BlueGreenUpdate, &out.BlueGreenUpdate
+		*out = new(BlueGreenUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdatePartition != nil {
+		in, out := &in.UpdatePartition, &out.UpdatePartition
+		*out = new(UpdatePartition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeldInstances != nil {
+		in, out := &in.HeldInstances, &out.HeldInstances
+		*out = make([]HeldInstance, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlacementPolicy != nil {
+		in, out := &in.PlacementPolicy, &out.PlacementPolicy
+		*out = new(InstancePlacementPolicy)
+		**out = **in
+	}
 	if in.Credential != nil {
 		in, out := &in.Credential, &out.Credential
 		*out = new(Credential)
@@ -259,6 +381,21 @@ func (in *InstanceSetStatus) DeepCopyInto(out *InstanceSetStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.HeldInstances != nil {
+		in, out := &in.HeldInstances, &out.HeldInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(InstancePlacementStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceSetStatus.
@@ -463,3 +600,48 @@ func (in *RoleProbe) DeepCopy() *RoleProbe {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyDomainStatus) DeepCopyInto(out *TopologyDomainStatus) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyDomainStatus.
+func (in *TopologyDomainStatus) DeepCopy() *TopologyDomainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdatePartition) DeepCopyInto(out *UpdatePartition) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdatePartition.
+func (in *UpdatePartition) DeepCopy() *UpdatePartition {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdatePartition)
+	in.DeepCopyInto(out)
+	return out
+}
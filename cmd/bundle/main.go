@@ -0,0 +1,145 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/bundle"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(dpv1alpha1.AddToScheme(scheme))
+}
+
+func newClient() (client.Client, error) {
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+func newExportCmd() *cobra.Command {
+	var namespace, output string
+	cmd := &cobra.Command{
+		Use:   "export CLUSTER",
+		Short: "Export a cluster with the objects it depends on into a bundle.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli, err := newClient()
+			if err != nil {
+				return err
+			}
+			b, err := bundle.Export(context.Background(), cli, namespace, args[0])
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = args[0] + ".tar.gz"
+			}
+			// the bundle contains the passwords of the system accounts.
+			f, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err = b.Write(f); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "cluster %s/%s is exported to %s, backup repos: %v\n",
+				namespace, args[0], output, b.Manifest.BackupRepos)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", corev1.NamespaceDefault, "The namespace of the cluster.")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "The file to write the bundle to, defaults to <cluster>.tar.gz.")
+	return cmd
+}
+
+func newImportCmd() *cobra.Command {
+	var (
+		file string
+		opts bundle.ImportOptions
+	)
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a bundle and restore the cluster from the backups in the bundle.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			b, err := bundle.Read(f)
+			if err != nil {
+				return err
+			}
+			cli, err := newClient()
+			if err != nil {
+				return err
+			}
+			cluster, err := bundle.Import(context.Background(), cli, b, opts)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "cluster %s/%s is imported and being restored\n", cluster.Namespace, cluster.Name)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "The bundle file to import.")
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "The namespace to import the cluster into, defaults to the namespace of the exported cluster.")
+	cmd.Flags().StringToStringVar(&opts.StorageClassMapping, "storage-class-mapping", nil,
+		"Map the storage classes of the cluster to the ones of the target Kubernetes cluster, e.g. old-sc=new-sc.")
+	cmd.Flags().StringToStringVar(&opts.BackupRepoMapping, "backup-repo-mapping", nil,
+		"Map the backup repos of the bundle to the ones of the target Kubernetes cluster, e.g. old-repo=new-repo.")
+	cmd.Flags().StringVar(&opts.VolumeRestorePolicy, "volume-restore-policy", "", "The volume restore policy, Parallel or Serial.")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:          "kbbundle",
+		Short:        "Export and import KubeBlocks clusters for the migration between Kubernetes clusters.",
+		SilenceUsage: true,
+	}
+	rootCmd.AddCommand(newExportCmd(), newImportCmd())
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
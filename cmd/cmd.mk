@@ -73,6 +73,24 @@ cue-helper: test-go-generate build-checks ## Build cue-helper related binaries
 clean-cue-helper: ## Clean bin/cue-helper.
 	rm -f bin/cue-helper
 
+## kbbundle cmd
+
+KBBUNDLE_LD_FLAGS = "-s -w"
+
+bin/kbbundle.%: ## Cross build bin/kbbundle.$(OS).$(ARCH) .
+	GOOS=$(word 2,$(subst ., ,$@)) GOARCH=$(word 3,$(subst ., ,$@)) $(GO) build -ldflags=${KBBUNDLE_LD_FLAGS} -o $@ ./cmd/bundle/main.go
+
+.PHONY: kbbundle
+kbbundle: OS=$(shell $(GO) env GOOS)
+kbbundle: ARCH=$(shell $(GO) env GOARCH)
+kbbundle: build-checks ## Build kbbundle related binaries
+	$(MAKE) bin/kbbundle.${OS}.${ARCH}
+	mv bin/kbbundle.${OS}.${ARCH} bin/kbbundle
+
+.PHONY: clean-kbbundle
+clean-kbbundle: ## Clean bin/kbbundle.
+	rm -f bin/kbbundle
+
 ## lorry cmd
 
 LORRY_LD_FLAGS = "-s -w"
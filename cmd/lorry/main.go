@@ -79,6 +79,11 @@ func main() {
 	}
 	ctrl.SetLogger(kzap.New(kopts...))
 
+	// Load the password injected into the file from the external secret store
+	if err = loadServicePasswordFile(); err != nil {
+		panic(errors.Wrap(err, "load the service password failed"))
+	}
+
 	// Initialize DB Manager
 	err = register.InitDBManager(configDir)
 	if err != nil {
@@ -134,3 +139,17 @@ func main() {
 	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
 	<-stop
 }
+
+// loadServicePasswordFile sets the service password from the file, if the password is injected into the file
+// from the external secret store. It's set in the env, so the actions get it as well.
+func loadServicePasswordFile() error {
+	file := viper.GetString(constant.KBEnvServicePasswordFile)
+	if file == "" || viper.IsSet(constant.KBEnvServicePassword) {
+		return nil
+	}
+	password, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return os.Setenv(constant.KBEnvServicePassword, strings.TrimSpace(string(password)))
+}
@@ -58,6 +58,7 @@ import (
 	workloadsv1alpha1 "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	appscontrollers "github.com/apecloud/kubeblocks/controllers/apps"
 	"github.com/apecloud/kubeblocks/controllers/apps/configuration"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations"
 	extensionscontrollers "github.com/apecloud/kubeblocks/controllers/extensions"
 	k8scorecontrollers "github.com/apecloud/kubeblocks/controllers/k8score"
 	workloadscontrollers "github.com/apecloud/kubeblocks/controllers/workloads"
@@ -242,6 +243,14 @@ func validateRequiredToParseConfigs() error {
 	if err := validateAffinity(viper.GetString(constant.CfgKeyDataPlaneAffinity)); err != nil {
 		return err
 	}
+	policies, err := operations.ParseOpsApprovalPolicies(viper.GetString(constant.CfgKeyOpsApprovalPolicies))
+	if err != nil {
+		return err
+	}
+	// the approver is verified by the OpsRequest webhook, the approval can not be trusted without it.
+	if len(policies) > 0 && !viper.GetBool("enable_webhooks") {
+		return fmt.Errorf("%s requires the admission webhooks to be enabled", constant.CfgKeyOpsApprovalPolicies)
+	}
 	return nil
 }
 
@@ -420,6 +429,24 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appscontrollers.OpsPipelineReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ops-pipeline-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpsPipeline")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.OpsFleetReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ops-fleet-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpsFleet")
+			os.Exit(1)
+		}
+
 		if err = (&configuration.ConfigConstraintReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -546,6 +573,16 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appsv1alpha1.OpsPipeline{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpsPipeline")
+			os.Exit(1)
+		}
+
+		if err = (&appsv1alpha1.OpsFleet{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpsFleet")
+			os.Exit(1)
+		}
+
 		if err = (&workloadsv1alpha1.InstanceSet{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "InstanceSet")
 			os.Exit(1)
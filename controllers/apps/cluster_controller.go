@@ -35,6 +35,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -113,6 +114,22 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	// Build stage
+	plan, errBuild := buildClusterPlan(planBuilder, r.MultiClusterMgr)
+
+	// Execute stage
+	// errBuild not nil means build stage partial success or validation error
+	// execute the plan first, delay error handling
+	if errExec := plan.Execute(); errExec != nil {
+		return requeueError(errExec)
+	}
+	if errBuild != nil {
+		return requeueError(errBuild)
+	}
+	return intctrlutil.Reconciled()
+}
+
+// buildClusterPlan builds the plan of the cluster reconciliation with the transformers.
+func buildClusterPlan(planBuilder graph.PlanBuilder, multiClusterMgr multicluster.Manager) (graph.Plan, error) {
 	// what you should do in most cases is writing your transformer.
 	//
 	// here are the how-to tips:
@@ -125,7 +142,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	//    If you do need to create/update/delete object, make your intent operation a lifecycleVertex and put it into the DAG.
 	//
 	// TODO: transformers are vertices, theirs' dependencies are edges, make plan Build stage a DAG.
-	plan, errBuild := planBuilder.
+	return planBuilder.
 		AddTransformer(
 			// handle cluster deletion first
 			&clusterDeletionTransformer{},
@@ -138,7 +155,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			// normalize the cluster and component API
 			&ClusterAPINormalizationTransformer{},
 			// placement replicas across data-plane k8s clusters
-			&clusterPlacementTransformer{multiClusterMgr: r.MultiClusterMgr},
+			&clusterPlacementTransformer{multiClusterMgr: multiClusterMgr},
 			// handle cluster services
 			&clusterServiceTransformer{},
 			// handle the restore for cluster
@@ -160,17 +177,6 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			// always safe to put your transformer below
 		).
 		Build()
-
-	// Execute stage
-	// errBuild not nil means build stage partial success or validation error
-	// execute the plan first, delay error handling
-	if errExec := plan.Execute(); errExec != nil {
-		return requeueError(errExec)
-	}
-	if errBuild != nil {
-		return requeueError(errBuild)
-	}
-	return intctrlutil.Reconciled()
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -78,10 +78,11 @@ type clusterTransformContext struct {
 
 // clusterPlanBuilder a graph.PlanBuilder implementation for Cluster reconciliation
 type clusterPlanBuilder struct {
-	req          ctrl.Request
-	cli          client.Client
-	transCtx     *clusterTransformContext
-	transformers graph.TransformerChain
+	req           ctrl.Request
+	cli           client.Client
+	transCtx      *clusterTransformContext
+	transformers  graph.TransformerChain
+	driftDetector *driftDetector
 }
 
 // clusterPlan a graph.Plan implementation for Cluster reconciliation
@@ -173,6 +174,9 @@ func (c *clusterPlanBuilder) Build() (graph.Plan, error) {
 	dag := graph.NewDAG()
 	err = c.transformers.ApplyTo(c.transCtx, dag)
 	c.transCtx.Logger.V(1).Info(fmt.Sprintf("DAG: %s", dag))
+	if c.transCtx.Cluster != nil {
+		c.driftDetector = newDriftDetector(c.transCtx.GetRecorder(), c.transCtx.Cluster)
+	}
 
 	// construct execution plan
 	plan := &clusterPlan{
@@ -292,6 +296,11 @@ func (c *clusterPlanBuilder) reconcileCluster(node *model.ObjectVertex) error {
 
 func (c *clusterPlanBuilder) reconcileObject(node *model.ObjectVertex) error {
 	ctx := c.transCtx.Context
+	if _, ok := node.Obj.(*appsv1alpha1.Cluster); !ok {
+		if write, err := c.driftDetector.beforeWrite(node); err != nil || !write {
+			return err
+		}
+	}
 	switch *node.Action {
 	case model.CREATE:
 		return c.reconcileCreateObject(ctx, node)
@@ -362,6 +371,9 @@ func (c *clusterPlanBuilder) reconcileDeleteObject(ctx context.Context, node *mo
 }
 
 func (c *clusterPlanBuilder) reconcileStatusObject(ctx context.Context, node *model.ObjectVertex) error {
+	if cluster, ok := node.Obj.(*appsv1alpha1.Cluster); ok {
+		c.driftDetector.setCondition(&cluster.Status.Conditions, cluster.Generation)
+	}
 	patch := client.MergeFrom(node.OriObj)
 	if err := c.cli.Status().Patch(ctx, node.Obj, patch, clientOption(node)); err != nil {
 		return err
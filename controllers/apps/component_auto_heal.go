@@ -0,0 +1,266 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// componentAutoHeal the event reason indicates that the unrecoverable instances are rebuilt automatically.
+	componentAutoHeal = "AutoHeal"
+
+	// pvcSelectedNodeAnnotationKey is the annotation set by the scheduler to the PVC with the node it is bound to.
+	pvcSelectedNodeAnnotationKey = "volume.kubernetes.io/selected-node"
+
+	defaultAutoHealUnhealthyThreshold = 10 * time.Minute
+	defaultAutoHealMinInterval        = 30 * time.Minute
+)
+
+// reconcileAutoHeal creates a RebuildInstance OpsRequest for the unrecoverable instances if the auto-heal policy is enabled.
+// It returns the duration after which the instances should be checked again, zero means no need to requeue.
+func (r *componentStatusHandler) reconcileAutoHeal(pods []*corev1.Pod) (time.Duration, error) {
+	policy := r.comp.Spec.AutoHeal
+	if policy == nil {
+		return 0, nil
+	}
+	// the RebuildInstance OpsRequest only works for the component in these phases.
+	if !slices.Contains([]appsv1alpha1.ClusterComponentPhase{appsv1alpha1.FailedClusterCompPhase,
+		appsv1alpha1.AbnormalClusterCompPhase, appsv1alpha1.UpdatingClusterCompPhase}, r.comp.Status.Phase) {
+		return 0, nil
+	}
+
+	// rate limit: only one automatic rebuild is running at a time, and the rebuilds are separated by the min interval.
+	if running, wait, err := r.checkAutoRebuildOps(constant.OpsRequestAutoHealLabelKey,
+		durationOrDefault(policy.MinInterval, defaultAutoHealMinInterval)); err != nil || running || wait > 0 {
+		return wait, err
+	}
+
+	instances, reasons, requeueAfter, err := r.findUnrecoverableInstances(pods, durationOrDefault(policy.UnhealthyThreshold, defaultAutoHealUnhealthyThreshold))
+	if err != nil || len(instances) == 0 {
+		return requeueAfter, err
+	}
+	maxInstances := int(policy.MaxInstances)
+	if maxInstances <= 0 {
+		maxInstances = 1
+	}
+	if len(instances) > maxInstances {
+		instances = instances[:maxInstances]
+		reasons = reasons[:maxInstances]
+	}
+
+	ops := r.buildAutoHealOpsRequest(instances, policy.FromPeer)
+	model.NewGraphClient(r.cli).Create(r.dag, ops)
+	if r.reqCtx.Recorder != nil {
+		r.reqCtx.Recorder.Eventf(r.comp, corev1.EventTypeWarning, componentAutoHeal,
+			"create OpsRequest %s to rebuild the unrecoverable instances: %s", ops.Name, strings.Join(reasons, "; "))
+	}
+	return 0, nil
+}
+
+// checkAutoRebuildOps checks the OpsRequests of the component created automatically with the label,
+// it returns true if any of them is still running, or the duration to wait if the last one is created within the min interval.
+func (r *componentStatusHandler) checkAutoRebuildOps(labelKey string, minInterval time.Duration) (bool, time.Duration, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.cli.List(r.reqCtx.Ctx, opsList, client.InNamespace(r.cluster.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey: r.cluster.Name,
+		labelKey:                     r.synthesizeComp.Name,
+	}); err != nil {
+		return false, 0, err
+	}
+	var lastCreated time.Time
+	for _, ops := range opsList.Items {
+		if !ops.IsComplete() {
+			return true, 0, nil
+		}
+		if ops.CreationTimestamp.After(lastCreated) {
+			lastCreated = ops.CreationTimestamp.Time
+		}
+	}
+	if wait := time.Until(lastCreated.Add(minInterval)); wait > 0 {
+		return false, wait, nil
+	}
+	return false, 0, nil
+}
+
+// findUnrecoverableInstances finds the instances which should be rebuilt, the leader is always excluded,
+// and nothing is rebuilt if there is no other available instance to recover the data from.
+func (r *componentStatusHandler) findUnrecoverableInstances(pods []*corev1.Pod, threshold time.Duration) ([]string, []string, time.Duration, error) {
+	var (
+		instances       []string
+		reasons         []string
+		requeueAfter    time.Duration
+		hasAvailablePod bool
+	)
+	slices.SortFunc(pods, func(a, b *corev1.Pod) bool {
+		return a.Name < b.Name
+	})
+	for _, pod := range pods {
+		if podutils.IsPodAvailable(pod, r.synthesizeComp.MinReadySeconds, metav1.Now()) {
+			hasAvailablePod = true
+			continue
+		}
+		if r.isLeaderPod(pod) {
+			continue
+		}
+		reason, err := r.lostNodeOfPodVolumes(pod)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if reason == "" {
+			since := podUnhealthySince(pod)
+			if since == nil {
+				continue
+			}
+			if wait := time.Until(since.Add(threshold)); wait > 0 {
+				if requeueAfter == 0 || wait < requeueAfter {
+					requeueAfter = wait
+				}
+				continue
+			}
+			reason = fmt.Sprintf("it has been failed since %s", since.Format(time.RFC3339))
+		}
+		instances = append(instances, pod.Name)
+		reasons = append(reasons, fmt.Sprintf("instance %s is unrecoverable as %s", pod.Name, reason))
+	}
+	if !hasAvailablePod {
+		return nil, nil, 0, nil
+	}
+	return instances, reasons, requeueAfter, nil
+}
+
+// isLeaderPod checks if the pod has a writable role.
+func (r *componentStatusHandler) isLeaderPod(pod *corev1.Pod) bool {
+	roleName := pod.Labels[constant.RoleLabelKey]
+	for _, role := range r.synthesizeComp.Roles {
+		if role.Name == roleName && role.Writable {
+			return true
+		}
+	}
+	return false
+}
+
+// lostNodeOfPodVolumes checks if any PVC of the pod is bound to a node that no longer exists.
+func (r *componentStatusHandler) lostNodeOfPodVolumes(pod *corev1.Pod) (string, error) {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvcKey := client.ObjectKey{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}
+		if err := r.cli.Get(r.reqCtx.Ctx, pvcKey, pvc, inDataContext4C()); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		nodeName := pvc.Annotations[pvcSelectedNodeAnnotationKey]
+		if nodeName == "" {
+			continue
+		}
+		if err := r.cli.Get(r.reqCtx.Ctx, client.ObjectKey{Name: nodeName}, &corev1.Node{}, inDataContext4C()); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Sprintf("its PVC %s is bound to the node %s which no longer exists", pvc.Name, nodeName), nil
+			}
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// buildAutoHealOpsRequest builds the RebuildInstance OpsRequest to rebuild the instances.
+func (r *componentStatusHandler) buildAutoHealOpsRequest(instances []string, fromPeer bool) *appsv1alpha1.OpsRequest {
+	rebuildInstances := make([]appsv1alpha1.Instance, 0, len(instances))
+	for _, name := range instances {
+		rebuildInstances = append(rebuildInstances, appsv1alpha1.Instance{Name: name})
+	}
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-auto-heal-%s", common.CutString(r.comp.Name, 40),
+				strconv.FormatInt(time.Now().Unix(), 36)),
+			Namespace: r.cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:        r.cluster.Name,
+				constant.OpsRequestTypeLabelKey:     string(appsv1alpha1.RebuildInstanceType),
+				constant.OpsRequestAutoHealLabelKey: r.synthesizeComp.Name,
+			},
+			Annotations: map[string]string{
+				constant.OpsRequestRequestedByAnnotationKey: constant.OpsRequestRequestedBySystem,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterRef: r.cluster.Name,
+			Type:       appsv1alpha1.RebuildInstanceType,
+			RebuildFrom: []appsv1alpha1.RebuildInstance{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: r.synthesizeComp.Name},
+					Instances:    rebuildInstances,
+					FromPeer:     fromPeer,
+				},
+			},
+		},
+	}
+}
+
+// podUnhealthySince returns the time since when the pod has been failed, nil if the pod is not failed.
+func podUnhealthySince(pod *corev1.Pod) *metav1.Time {
+	if !pod.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if isFailed, _, _ := intctrlutil.IsPodFailedAndTimedOut(pod); !isFailed {
+		return nil
+	}
+	var since *metav1.Time
+	for i, cond := range pod.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case corev1.PodScheduled:
+			return &pod.Status.Conditions[i].LastTransitionTime
+		case corev1.PodReady:
+			since = &pod.Status.Conditions[i].LastTransitionTime
+		}
+	}
+	return since
+}
+
+func durationOrDefault(d metav1.Duration, defaultValue time.Duration) time.Duration {
+	if d.Duration <= 0 {
+		return defaultValue
+	}
+	return d.Duration
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("component auto heal", func() {
+	failedPod := func(name, role string, failedSince time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{constant.RoleLabelKey: role},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+					{Type: corev1.PodReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(failedSince)},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "main",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off restarting failed container"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	It("returns the time since when the pod has been failed", func() {
+		since := time.Now().Add(-time.Hour).Truncate(time.Second)
+		Expect(podUnhealthySince(failedPod("pod-0", "secondary", since)).Time).Should(Equal(since))
+		Expect(podUnhealthySince(&corev1.Pod{})).Should(BeNil())
+	})
+
+	It("finds the unrecoverable instances and excludes the leader", func() {
+		since := time.Now().Add(-time.Hour)
+		handler := &componentStatusHandler{
+			reqCtx: intctrlutil.RequestCtx{Ctx: ctx},
+			synthesizeComp: &component.SynthesizedComponent{
+				Roles: []appsv1alpha1.ReplicaRole{
+					{Name: "leader", Writable: true, Serviceable: true},
+					{Name: "follower", Serviceable: true},
+				},
+			},
+		}
+		pods := []*corev1.Pod{
+			failedPod("pod-2", "follower", since),
+			failedPod("pod-1", "leader", since),
+			failedPod("pod-0", "follower", time.Now()),
+		}
+
+		By("expect nothing to be rebuilt if there is no available instance")
+		instances, _, _, err := handler.findUnrecoverableInstances(pods, 10*time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(instances).Should(BeEmpty())
+
+		By("expect only the follower failed longer than the threshold to be rebuilt")
+		availablePod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-3"},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(since)},
+				},
+			},
+		}
+		instances, reasons, requeueAfter, err := handler.findUnrecoverableInstances(append(pods, availablePod), 10*time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(instances).Should(Equal([]string{"pod-2"}))
+		Expect(reasons).Should(HaveLen(1))
+		Expect(requeueAfter).Should(BeNumerically(">", 0))
+	})
+})
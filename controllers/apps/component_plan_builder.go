@@ -72,10 +72,11 @@ func (c *componentTransformContext) GetLogger() logr.Logger {
 
 // componentPlanBuilder a graph.PlanBuilder implementation for Component reconciliation
 type componentPlanBuilder struct {
-	req          ctrl.Request
-	cli          client.Client
-	transCtx     *componentTransformContext
-	transformers graph.TransformerChain
+	req           ctrl.Request
+	cli           client.Client
+	transCtx      *componentTransformContext
+	transformers  graph.TransformerChain
+	driftDetector *driftDetector
 }
 
 // clusterPlan a graph.Plan implementation for Cluster reconciliation
@@ -119,6 +120,9 @@ func (c *componentPlanBuilder) Build() (graph.Plan, error) {
 		c.transCtx.Logger.V(1).Info(fmt.Sprintf("build error: %s", err.Error()))
 	}
 	c.transCtx.Logger.V(1).Info(fmt.Sprintf("DAG: %s", dag))
+	if c.transCtx.Component != nil {
+		c.driftDetector = newDriftDetector(c.transCtx.GetRecorder(), c.transCtx.Component)
+	}
 
 	plan := &componentPlan{
 		dag:      dag,
@@ -179,6 +183,11 @@ func (c *componentPlanBuilder) defaultWalkFunc(v graph.Vertex) error {
 		return fmt.Errorf("vertex action can't be nil")
 	}
 	ctx := c.transCtx.Context
+	if _, ok := vertex.Obj.(*appsv1alpha1.Component); !ok {
+		if write, err := c.driftDetector.beforeWrite(vertex); err != nil || !write {
+			return err
+		}
+	}
 	switch *vertex.Action {
 	case model.CREATE:
 		return c.reconcileCreateObject(ctx, vertex)
@@ -256,5 +265,8 @@ func (c *componentPlanBuilder) reconcileDeleteObject(ctx context.Context, vertex
 }
 
 func (c *componentPlanBuilder) reconcileStatusObject(ctx context.Context, vertex *model.ObjectVertex) error {
+	if comp, ok := vertex.Obj.(*appsv1alpha1.Component); ok {
+		c.driftDetector.setCondition(&comp.Status.Conditions, comp.Generation)
+	}
 	return c.cli.Status().Update(ctx, vertex.Obj, clientOption(vertex))
 }
@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	// componentRebalance the event reason indicates that an instance is rebuilt to rebalance the instances across the zones.
+	componentRebalance = "Rebalance"
+
+	defaultRebalanceMinInterval = 30 * time.Minute
+)
+
+// reconcileRebalance creates a RebuildInstance OpsRequest to rebuild the instance suggested by the InstanceSet
+// in the least populated domain, if the rebalance of the instance placement policy is enabled.
+// It returns the duration after which the placement should be checked again, zero means no need to requeue.
+func (r *componentStatusHandler) reconcileRebalance() (time.Duration, error) {
+	policy := r.comp.Spec.InstancePlacement
+	if policy == nil || !policy.Rebalance || r.runningITS == nil {
+		return 0, nil
+	}
+	// the instances are rebalanced only if the component is working well.
+	if r.comp.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+		return 0, nil
+	}
+	placement := r.runningITS.Status.Placement
+	if placement == nil || placement.RebalanceInstance == "" || placement.RebalanceTargetDomain == "" {
+		return 0, nil
+	}
+
+	// rate limit: only one instance is rebuilt at a time, and the rebuilds are separated by the min interval.
+	if running, wait, err := r.checkAutoRebuildOps(constant.OpsRequestRebalanceLabelKey,
+		durationOrDefault(policy.MinInterval, defaultRebalanceMinInterval)); err != nil || running || wait > 0 {
+		return wait, err
+	}
+
+	nodeName, err := r.pickNodeInDomain(placement.TopologyKey, placement.RebalanceTargetDomain)
+	if err != nil || nodeName == "" {
+		return 0, err
+	}
+	ops := r.buildRebalanceOpsRequest(placement.RebalanceInstance, nodeName)
+	model.NewGraphClient(r.cli).Create(r.dag, ops)
+	if r.reqCtx.Recorder != nil {
+		r.reqCtx.Recorder.Eventf(r.comp, corev1.EventTypeNormal, componentRebalance,
+			"create OpsRequest %s to rebuild the instance %s on the node %s in the under-represented domain %s, skew: %d",
+			ops.Name, placement.RebalanceInstance, nodeName, placement.RebalanceTargetDomain, placement.Skew)
+	}
+	return 0, nil
+}
+
+// pickNodeInDomain picks a ready and schedulable node in the domain, which also matches the node selector of the instances.
+func (r *componentStatusHandler) pickNodeInDomain(topologyKey, domain string) (string, error) {
+	matchingLabels := client.MatchingLabels{topologyKey: domain}
+	for k, v := range r.runningITS.Spec.Template.Spec.NodeSelector {
+		matchingLabels[k] = v
+	}
+	nodes := &corev1.NodeList{}
+	if err := r.cli.List(r.reqCtx.Ctx, nodes, matchingLabels, inDataContext4C()); err != nil {
+		return "", err
+	}
+	slices.SortFunc(nodes.Items, func(a, b corev1.Node) bool {
+		return a.Name < b.Name
+	})
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable || !node.DeletionTimestamp.IsZero() || !isNodeReady(&node) {
+			continue
+		}
+		return node.Name, nil
+	}
+	return "", nil
+}
+
+// buildRebalanceOpsRequest builds the RebuildInstance OpsRequest to rebuild the instance on the target node.
+// The data is streamed from a healthy peer, and the OpsRequest is labeled as a rebalance to rebuild the healthy instance
+// of the running component, it is not forced so that the approval and the queue of the OpsRequests are respected.
+func (r *componentStatusHandler) buildRebalanceOpsRequest(instance, nodeName string) *appsv1alpha1.OpsRequest {
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-rebalance-%s", common.CutString(r.comp.Name, 40),
+				strconv.FormatInt(time.Now().Unix(), 36)),
+			Namespace: r.cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:         r.cluster.Name,
+				constant.OpsRequestTypeLabelKey:      string(appsv1alpha1.RebuildInstanceType),
+				constant.OpsRequestRebalanceLabelKey: r.synthesizeComp.Name,
+			},
+			Annotations: map[string]string{
+				constant.OpsRequestRequestedByAnnotationKey: constant.OpsRequestRequestedBySystem,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterRef: r.cluster.Name,
+			Type:       appsv1alpha1.RebuildInstanceType,
+			RebuildFrom: []appsv1alpha1.RebuildInstance{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: r.synthesizeComp.Name},
+					Instances:    []appsv1alpha1.Instance{{Name: instance, TargetNodeName: nodeName}},
+					FromPeer:     true,
+				},
+			},
+		},
+	}
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("component rebalance", func() {
+	buildNode := func(name, zone string, ready, unschedulable bool) *corev1.Node {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{corev1.LabelTopologyZone: zone, "disktype": "ssd"},
+			},
+			Spec: corev1.NodeSpec{Unschedulable: unschedulable},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			},
+		}
+	}
+
+	It("picks a ready and schedulable node in the target domain", func() {
+		cli := fake.NewClientBuilder().WithObjects(
+			buildNode("node-a", "zone-a", true, false),
+			buildNode("node-b0", "zone-b", false, false),
+			buildNode("node-b1", "zone-b", true, true),
+			buildNode("node-b2", "zone-b", true, false),
+		).Build()
+		handler := &componentStatusHandler{
+			cli:    cli,
+			reqCtx: intctrlutil.RequestCtx{Ctx: ctx},
+			runningITS: &workloads.InstanceSet{
+				Spec: workloads.InstanceSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{NodeSelector: map[string]string{"disktype": "ssd"}},
+					},
+				},
+			},
+		}
+
+		nodeName, err := handler.pickNodeInDomain(corev1.LabelTopologyZone, "zone-b")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(nodeName).Should(Equal("node-b2"))
+
+		nodeName, err = handler.pickNodeInDomain(corev1.LabelTopologyZone, "zone-c")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(nodeName).Should(BeEmpty())
+
+		By("expect the node selector of the instances to be respected")
+		handler.runningITS.Spec.Template.Spec.NodeSelector = map[string]string{"disktype": "hdd"}
+		nodeName, err = handler.pickNodeInDomain(corev1.LabelTopologyZone, "zone-b")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(nodeName).Should(BeEmpty())
+	})
+
+	It("builds the RebuildInstance OpsRequest to rebuild the instance from a peer on the target node", func() {
+		handler := &componentStatusHandler{
+			cluster:        &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"}},
+			comp:           &appsv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Name: "mycluster-mysql", Namespace: "default"}},
+			synthesizeComp: &component.SynthesizedComponent{Name: "mysql"},
+		}
+		ops := handler.buildRebalanceOpsRequest("mycluster-mysql-2", "node-b2")
+		Expect(ops.Namespace).Should(Equal("default"))
+		Expect(ops.Labels).Should(Equal(map[string]string{
+			constant.AppInstanceLabelKey:         "mycluster",
+			constant.OpsRequestTypeLabelKey:      string(appsv1alpha1.RebuildInstanceType),
+			constant.OpsRequestRebalanceLabelKey: "mysql",
+		}))
+		Expect(ops.Annotations).Should(HaveKeyWithValue(constant.OpsRequestRequestedByAnnotationKey, constant.OpsRequestRequestedBySystem))
+		Expect(ops.Spec).Should(Equal(appsv1alpha1.OpsRequestSpec{
+			ClusterRef: "mycluster",
+			Type:       appsv1alpha1.RebuildInstanceType,
+			RebuildFrom: []appsv1alpha1.RebuildInstance{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "mysql"},
+					Instances:    []appsv1alpha1.Instance{{Name: "mycluster-mysql-2", TargetNodeName: "node-b2"}},
+					FromPeer:     true,
+				},
+			},
+		}))
+		Expect(ops.Force()).Should(BeFalse())
+		Expect(ops.IsRebalance()).Should(BeTrue())
+
+		By("expect the rebalance label to be honored only if the OpsRequest is requested by the system")
+		ops.Annotations[constant.OpsRequestRequestedByAnnotationKey] = "alice"
+		Expect(ops.IsRebalance()).Should(BeFalse())
+	})
+})
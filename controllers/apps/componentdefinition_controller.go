@@ -330,6 +330,10 @@ func (r *ComponentDefinitionReconciler) validateLifecycleActions(cli client.Clie
 	if err := r.validateLifecycleActionBuiltInHandlers(cmpd.Spec.LifecycleActions); err != nil {
 		return err
 	}
+	if cmpd.Spec.TLSCertReloadPolicy == appsv1alpha1.TLSCertReloadPolicyReload &&
+		(cmpd.Spec.LifecycleActions == nil || cmpd.Spec.LifecycleActions.Reconfigure == nil) {
+		return fmt.Errorf("the Reconfigure action is needed to reload the TLS certificates")
+	}
 	return nil
 }
 
@@ -361,6 +365,9 @@ func (r *ComponentDefinitionReconciler) validateLifecycleActionBuiltInHandlers(l
 		{lifecycleActions.DataLoad},
 		{lifecycleActions.Reconfigure},
 		{lifecycleActions.AccountProvision},
+		{lifecycleActions.ShardAdd},
+		{lifecycleActions.ShardRemove},
+		{lifecycleActions.Rebalance},
 	}
 
 	for _, action := range actions {
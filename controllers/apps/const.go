@@ -39,6 +39,14 @@ const (
 	reasonOpsCancelActionFailed       = "CancelActionFailed"
 	reasonOpsReconcileStatusFailed    = "ReconcileStatusFailed"
 	reasonOpsDoActionFailed           = "DoActionFailed"
+	reasonOpsPipelineStepStarted      = "StepStarted"
+	reasonOpsPipelineSucceed          = "PipelineSucceed"
+	reasonOpsPipelineFailed           = "PipelineFailed"
+	reasonOpsFleetClusterStarted      = "ClusterStarted"
+	reasonOpsFleetSucceed             = "FleetSucceed"
+	reasonOpsFleetFailed              = "FleetFailed"
+	reasonOpsRollbackCreated          = "RollbackCreated"
+	reasonOpsRollbackSkipped          = "RollbackSkipped"
 )
 
 const (
@@ -0,0 +1,361 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	// maxDriftFieldsInMessage limits the number of drifted fields shown in the event and condition message.
+	maxDriftFieldsInMessage = 10
+	// maxDriftValueLength limits the length of a drifted value shown in the message.
+	maxDriftValueLength = 64
+)
+
+// fieldDrift describes a top-level spec field whose live value differs from the value last applied by KubeBlocks.
+type fieldDrift struct {
+	// field is the name of the top-level spec field.
+	field string
+	// live is the live value of the field.
+	live any
+	// changes are the drifted sub-fields, which are going to be overwritten by the desired value.
+	changes []fieldChange
+}
+
+// driftDetector detects the drift of the objects owned by the cluster or the component before they are overwritten,
+// and handles it according to the drift policy of the object.
+type driftDetector struct {
+	recorder record.EventRecorder
+	owner    client.Object
+	messages []string
+}
+
+func newDriftDetector(recorder record.EventRecorder, owner client.Object) *driftDetector {
+	return &driftDetector{recorder: recorder, owner: owner}
+}
+
+// beforeWrite is called before the object of the vertex is created, updated or patched.
+// It returns false if the write should be skipped.
+func (d *driftDetector) beforeWrite(vertex *model.ObjectVertex) (bool, error) {
+	if d == nil || vertex.Action == nil {
+		return true, nil
+	}
+	switch *vertex.Action {
+	case model.CREATE:
+		return true, setLastAppliedSpec(vertex.Obj, nil, nil)
+	case model.UPDATE, model.PATCH:
+	default:
+		return true, nil
+	}
+	if vertex.OriObj == nil {
+		return true, setLastAppliedSpec(vertex.Obj, nil, nil)
+	}
+	drifts, err := detectSpecDrift(vertex.OriObj, vertex.Obj)
+	if err != nil || len(drifts) == 0 {
+		if err == nil {
+			err = setLastAppliedSpec(vertex.Obj, nil, nil)
+		}
+		return true, err
+	}
+
+	policy := vertex.OriObj.GetAnnotations()[constant.DriftPolicyAnnotationKey]
+	switch policy {
+	case constant.DriftPolicyKeep, constant.DriftPolicyReport:
+	default:
+		policy = constant.DriftPolicyRevert
+	}
+	// the drifts left untouched by the Report policy are reported by event only once.
+	reported := vertex.OriObj.GetAnnotations()[constant.DriftReportedAnnotationKey] == driftsHash(drifts)
+	d.report(vertex.Obj, drifts, policy, !reported)
+	switch policy {
+	case constant.DriftPolicyReport:
+		// the drifted fields are left untouched and reported until they are reverted, the other changes are applied.
+		if err = keepDriftedFields(vertex.Obj, drifts); err != nil {
+			return false, err
+		}
+		return true, setLastAppliedSpec(vertex.Obj, lastAppliedSpecOf(vertex.OriObj), drifts)
+	case constant.DriftPolicyKeep:
+		if err = keepDriftedFields(vertex.Obj, drifts); err != nil {
+			return false, err
+		}
+	}
+	return true, setLastAppliedSpec(vertex.Obj, nil, nil)
+}
+
+// report records the drift for the condition, and emits the event of it if required.
+func (d *driftDetector) report(obj client.Object, drifts []fieldDrift, policy string, emitEvent bool) {
+	var fields []string
+	for _, drift := range drifts {
+		for _, change := range drift.changes {
+			fields = append(fields, fmt.Sprintf("%s=%s",
+				fieldPathString(append([]any{"spec", drift.field}, change.path...)), driftValueString(change.oldValue)))
+		}
+	}
+	if len(fields) > maxDriftFieldsInMessage {
+		fields = append(fields[:maxDriftFieldsInMessage], fmt.Sprintf("and %d more", len(fields)-maxDriftFieldsInMessage))
+	}
+	message := fmt.Sprintf("%s %s is drifted from the spec applied by KubeBlocks, policy: %s, fields: %s",
+		objectKind(obj), obj.GetName(), policy, strings.Join(fields, "; "))
+	d.messages = append(d.messages, message)
+	if d.recorder != nil && emitEvent {
+		d.recorder.Event(d.owner, corev1.EventTypeWarning, appsv1alpha1.ConditionTypeDriftDetected, message)
+	}
+}
+
+// setCondition sets the DriftDetected condition if any drift is detected in this reconciliation,
+// and removes it otherwise.
+func (d *driftDetector) setCondition(conditions *[]metav1.Condition, generation int64) {
+	if d == nil {
+		return
+	}
+	if len(d.messages) == 0 {
+		meta.RemoveStatusCondition(conditions, appsv1alpha1.ConditionTypeDriftDetected)
+		return
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeDriftDetected,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             appsv1alpha1.ConditionTypeDriftDetected,
+		Message:            strings.Join(d.messages, "\n"),
+	})
+}
+
+// setLastAppliedSpec records the hashes of the top-level spec fields of the object to be applied in its annotations.
+// The hashes of the drifted fields are taken from lastApplied if drifts are given, so they are detected again next time,
+// and the drifts are recorded as reported.
+func setLastAppliedSpec(obj client.Object, lastApplied map[string]string, drifts []fieldDrift) error {
+	spec, err := specOf(obj)
+	if err != nil || spec == nil {
+		return err
+	}
+	fields := map[string]string{}
+	for field, value := range spec {
+		if value != nil {
+			fields[field] = hashOf(value)
+		}
+	}
+	for _, drift := range drifts {
+		if hash, ok := lastApplied[drift.field]; ok {
+			fields[drift.field] = hash
+		} else {
+			delete(fields, drift.field)
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constant.LastAppliedSpecAnnotationKey] = string(data)
+	if len(drifts) > 0 {
+		annotations[constant.DriftReportedAnnotationKey] = driftsHash(drifts)
+	} else {
+		delete(annotations, constant.DriftReportedAnnotationKey)
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// lastAppliedSpecOf returns the hashes of the spec fields last applied by KubeBlocks, nil if they are not recorded.
+func lastAppliedSpecOf(obj client.Object) map[string]string {
+	data, ok := obj.GetAnnotations()[constant.LastAppliedSpecAnnotationKey]
+	if !ok {
+		return nil
+	}
+	var lastApplied map[string]string
+	if err := json.Unmarshal([]byte(data), &lastApplied); err != nil {
+		// the annotation is broken, it will be overwritten by the next write.
+		return nil
+	}
+	return lastApplied
+}
+
+// detectSpecDrift compares the live spec of the object with the hashes of the spec last applied, which are recorded
+// in its annotations, and returns the drifted fields which are going to be overwritten by the desired object.
+// The live value of a field is compared in the shape of the desired value, so the sub-fields defaulted by the API
+// server are not drifts. The fields changed by KubeBlocks itself are not compared, its changes take precedence.
+func detectSpecDrift(live, desired client.Object) ([]fieldDrift, error) {
+	lastApplied := lastAppliedSpecOf(live)
+	if lastApplied == nil {
+		return nil, nil
+	}
+	liveSpec, err := specOf(live)
+	if err != nil {
+		return nil, err
+	}
+	desiredSpec, err := specOf(desired)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(lastApplied))
+	for field := range lastApplied {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var overwritten []fieldDrift
+	for _, field := range fields {
+		hash := lastApplied[field]
+		desiredValue := desiredSpec[field]
+		if hashOf(desiredValue) != hash {
+			continue
+		}
+		liveValue := projectFields(liveSpec[field], desiredValue)
+		if hashOf(liveValue) == hash {
+			continue
+		}
+		overwritten = append(overwritten, fieldDrift{
+			field:   field,
+			live:    liveSpec[field],
+			changes: diffFields(nil, liveValue, desiredValue),
+		})
+	}
+	return overwritten, nil
+}
+
+// projectFields returns the value in the shape of the given one, the map keys not in the shape are dropped.
+// A list of another length is returned as a whole.
+func projectFields(value, shape any) any {
+	switch s := shape.(type) {
+	case map[string]any:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		projected := make(map[string]any, len(s))
+		for k, e := range s {
+			if v, ok := m[k]; ok {
+				projected[k] = projectFields(v, e)
+			}
+		}
+		return projected
+	case []any:
+		l, ok := value.([]any)
+		if !ok || len(l) != len(s) {
+			return value
+		}
+		projected := make([]any, len(l))
+		for i := range l {
+			projected[i] = projectFields(l[i], s[i])
+		}
+		return projected
+	}
+	return value
+}
+
+func hashOf(value any) string {
+	data, _ := json.Marshal(value)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// driftsHash returns the hash of the drifted fields and their live values.
+func driftsHash(drifts []fieldDrift) string {
+	fields := make(map[string]any, len(drifts))
+	for _, drift := range drifts {
+		fields[drift.field] = drift.live
+	}
+	return hashOf(fields)
+}
+
+// keepDriftedFields sets the drifted fields of the object to be applied to their live values.
+func keepDriftedFields(obj client.Object, drifts []fieldDrift) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var content map[string]any
+	if err = json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	spec, ok := content["spec"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	for _, drift := range drifts {
+		if drift.live == nil {
+			delete(spec, drift.field)
+		} else {
+			spec[drift.field] = drift.live
+		}
+	}
+	if data, err = json.Marshal(content); err != nil {
+		return err
+	}
+	// reset the object before unmarshalling, otherwise the removed map keys are kept.
+	objValue := reflect.ValueOf(obj).Elem()
+	objValue.Set(reflect.Zero(objValue.Type()))
+	return json.Unmarshal(data, obj)
+}
+
+// specOf returns the spec of the object in the form of JSON values, nil if the object has no spec.
+func specOf(obj client.Object) (map[string]any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]any
+	if err = json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	spec, _ := content["spec"].(map[string]any)
+	return spec, nil
+}
+
+func objectKind(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.TypeOf(obj).Elem().Name()
+}
+
+func driftValueString(value any) string {
+	if value == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	if len(data) > maxDriftValueLength {
+		return string(data[:maxDriftValueLength]) + "..."
+	}
+	return string(data)
+}
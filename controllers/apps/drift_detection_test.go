@@ -0,0 +1,181 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+var _ = Describe("drift detection", func() {
+	var (
+		recorder *record.FakeRecorder
+		detector *driftDetector
+	)
+
+	newService := func(port int32, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "mycluster-mysql", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Ports:    []corev1.ServicePort{{Name: "mysql", Port: port}},
+				Selector: selector,
+			},
+		}
+	}
+
+	// appliedService returns the live service which has been applied by KubeBlocks and defaulted by the API server.
+	appliedService := func() *corev1.Service {
+		svc := newService(3306, map[string]string{"app": "mysql"})
+		Expect(setLastAppliedSpec(svc, nil, nil)).Should(Succeed())
+		svc.Spec.ClusterIP = "10.0.0.1"
+		svc.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		return svc
+	}
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		detector = newDriftDetector(recorder, &appsv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Name: "mycluster-mysql"}})
+	})
+
+	It("ignores the fields defaulted by the API server", func() {
+		live := appliedService()
+		desired := newService(3306, map[string]string{"app": "mysql", "role": "primary"})
+		drifts, err := detectSpecDrift(live, desired)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(drifts).Should(BeEmpty())
+	})
+
+	It("reverts the drifted fields and reports them by default", func() {
+		live := appliedService()
+		live.Spec.Ports[0].Port = 3307
+		desired := newService(3306, map[string]string{"app": "mysql"})
+
+		write, err := detector.beforeWrite(&model.ObjectVertex{OriObj: live, Obj: desired, Action: model.ActionUpdatePtr()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(write).Should(BeTrue())
+		Expect(desired.Spec.Ports[0].Port).Should(BeEquivalentTo(3306))
+		Expect(recorder.Events).Should(HaveLen(1))
+		Expect(<-recorder.Events).Should(ContainSubstring("spec.ports[0].port=3307"))
+
+		var conditions []metav1.Condition
+		detector.setCondition(&conditions, 1)
+		cond := meta.FindStatusCondition(conditions, appsv1alpha1.ConditionTypeDriftDetected)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+
+		By("expect the condition to be removed if no drift is detected")
+		detector = newDriftDetector(recorder, &appsv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Name: "mycluster-mysql"}})
+		write, err = detector.beforeWrite(&model.ObjectVertex{OriObj: desired, Obj: desired.DeepCopy(), Action: model.ActionUpdatePtr()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(write).Should(BeTrue())
+		detector.setCondition(&conditions, 2)
+		Expect(meta.FindStatusCondition(conditions, appsv1alpha1.ConditionTypeDriftDetected)).Should(BeNil())
+	})
+
+	It("records the hashes of the applied top-level fields rather than their values", func() {
+		svc := newService(3306, map[string]string{"app": "mysql"})
+		Expect(setLastAppliedSpec(svc, nil, nil)).Should(Succeed())
+		lastApplied := lastAppliedSpecOf(svc)
+		Expect(lastApplied).Should(HaveLen(2))
+		Expect(lastApplied).Should(HaveKey("ports"))
+		Expect(lastApplied).Should(HaveKey("selector"))
+		Expect(svc.Annotations[constant.LastAppliedSpecAnnotationKey]).ShouldNot(ContainSubstring("mysql"))
+
+		By("expect a list of another length to be drifted as a whole")
+		live := appliedService()
+		live.Spec.Ports = append(live.Spec.Ports, corev1.ServicePort{Name: "admin", Port: 33062})
+		drifts, err := detectSpecDrift(live, newService(3306, map[string]string{"app": "mysql"}))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(drifts).Should(HaveLen(1))
+		Expect(drifts[0].field).Should(Equal("ports"))
+		Expect(drifts[0].changes).Should(HaveLen(1))
+		Expect(drifts[0].changes[0].path).Should(BeEmpty())
+	})
+
+	It("keeps the drifted fields with the Keep policy", func() {
+		live := appliedService()
+		live.Annotations[constant.DriftPolicyAnnotationKey] = constant.DriftPolicyKeep
+		live.Spec.Ports[0].Port = 3307
+		live.Spec.Selector = map[string]string{"tier": "db"}
+		desired := newService(3306, map[string]string{"app": "mysql", "role": "primary"})
+
+		write, err := detector.beforeWrite(&model.ObjectVertex{OriObj: live, Obj: desired, Action: model.ActionUpdatePtr()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(write).Should(BeTrue())
+		Expect(desired.Spec.Ports[0].Port).Should(BeEquivalentTo(3307))
+		// the selector is changed by KubeBlocks, its change takes precedence over the drift.
+		Expect(desired.Spec.Selector).Should(Equal(map[string]string{"app": "mysql", "role": "primary"}))
+		Expect(desired.Annotations).Should(HaveKey(constant.LastAppliedSpecAnnotationKey))
+
+		By("expect the kept fields not to be reported again")
+		drifts, err := detectSpecDrift(desired, desired.DeepCopy())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(drifts).Should(BeEmpty())
+	})
+
+	It("reports the drifted fields and applies the other changes with the Report policy", func() {
+		live := appliedService()
+		live.Annotations[constant.DriftPolicyAnnotationKey] = constant.DriftPolicyReport
+		live.Spec.Ports[0].Port = 3307
+		desired := newService(3306, map[string]string{"app": "mysql", "role": "primary"})
+		desired.Annotations = map[string]string{constant.DriftPolicyAnnotationKey: constant.DriftPolicyReport}
+
+		write, err := detector.beforeWrite(&model.ObjectVertex{OriObj: live, Obj: desired, Action: model.ActionUpdatePtr()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(write).Should(BeTrue())
+		Expect(recorder.Events).Should(HaveLen(1))
+		Expect(<-recorder.Events).Should(ContainSubstring("spec.ports[0].port=3307"))
+		Expect(desired.Spec.Ports[0].Port).Should(BeEquivalentTo(3307))
+		Expect(desired.Spec.Selector).Should(HaveKeyWithValue("role", "primary"))
+		Expect(desired.Annotations).Should(HaveKey(constant.DriftReportedAnnotationKey))
+
+		By("expect the same drift to be reported by the condition but not by event again")
+		next := newService(3306, map[string]string{"app": "mysql", "role": "primary"})
+		next.Annotations = map[string]string{constant.DriftPolicyAnnotationKey: constant.DriftPolicyReport}
+		detector = newDriftDetector(recorder, &appsv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Name: "mycluster-mysql"}})
+		write, err = detector.beforeWrite(&model.ObjectVertex{OriObj: desired, Obj: next, Action: model.ActionUpdatePtr()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(write).Should(BeTrue())
+		Expect(recorder.Events).Should(BeEmpty())
+		Expect(next.Spec.Ports[0].Port).Should(BeEquivalentTo(3307))
+		var conditions []metav1.Condition
+		detector.setCondition(&conditions, 2)
+		Expect(meta.FindStatusCondition(conditions, appsv1alpha1.ConditionTypeDriftDetected)).ShouldNot(BeNil())
+
+		By("expect a changed drift to be reported by event")
+		next.Spec.Ports[0].Port = 3308
+		desired = newService(3306, map[string]string{"app": "mysql", "role": "primary"})
+		desired.Annotations = map[string]string{constant.DriftPolicyAnnotationKey: constant.DriftPolicyReport}
+		write, err = detector.beforeWrite(&model.ObjectVertex{OriObj: next, Obj: desired, Action: model.ActionUpdatePtr()})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(write).Should(BeTrue())
+		Expect(recorder.Events).Should(HaveLen(1))
+		Expect(<-recorder.Events).Should(ContainSubstring("spec.ports[0].port=3308"))
+	})
+})
@@ -0,0 +1,653 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// adoptFromAnnotation records the OpsRequest which adopts the PV or the PVC.
+	adoptFromAnnotation = "apps.kubeblocks.io/adopt-from"
+	// adoptPVCNameLabel records the name of the PVC which the PV is re-bound to, used for idempotent reentry.
+	adoptPVCNameLabel = "apps.kubeblocks.io/adopt-pvc"
+)
+
+type adoptOpsHandler struct{}
+
+var _ OpsHandler = adoptOpsHandler{}
+
+func init() {
+	// register adopt operation, it will create a new cluster
+	adoptBehaviour := OpsBehaviour{
+		OpsHandler:        adoptOpsHandler{},
+		IsClusterCreation: true,
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.AdoptType, adoptBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the adopt request.
+func (r adoptOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewAdoptCondition(opsRes.OpsRequest), nil
+}
+
+// Action validates the StatefulSet against the ComponentDefinition, and creates the new cluster with all instances offline.
+// The instances are brought online one by one in ReconcileAction after their volumes are re-bound.
+func (r adoptOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	adoptSpec := opsRequest.Spec.AdoptSpec
+	sts := &appsv1.StatefulSet{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: adoptSpec.StatefulSetName, Namespace: opsRequest.Namespace}, sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the StatefulSet "%s" is not found`, adoptSpec.StatefulSetName))
+		}
+		return err
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: adoptSpec.ComponentDef}, compDef); err != nil {
+		if apierrors.IsNotFound(err) {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the ComponentDefinition "%s" is not found`, adoptSpec.ComponentDef))
+		}
+		return err
+	}
+	if err := r.validateVolumeLayout(adoptSpec, sts, compDef); err != nil {
+		return intctrlutil.NewFatalError(err.Error())
+	}
+	if err := r.validateReplicas(sts, compDef); err != nil {
+		return intctrlutil.NewFatalError(err.Error())
+	}
+	if err := r.validateSystemAccounts(adoptSpec, compDef); err != nil {
+		return intctrlutil.NewFatalError(err.Error())
+	}
+	// import the account secrets before the cluster is created, the existing account secrets are kept by the component.
+	if err := r.importAccountSecrets(reqCtx, cli, opsRequest); err != nil {
+		return err
+	}
+	cluster := r.buildAdoptCluster(opsRequest, sts, compDef)
+	util.SetOpsRequestToCluster(cluster, []appsv1alpha1.OpsRecorder{
+		{
+			Name: opsRequest.Name,
+			Type: opsRequest.Spec.Type,
+		},
+	})
+	return createClusterOfOpsRequest(reqCtx, cli, opsRes, cluster)
+}
+
+// ReconcileAction adopts the instances of the StatefulSet one by one from the highest ordinal,
+// and deletes the StatefulSet after all instances are adopted.
+func (r adoptOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequest      = opsRes.OpsRequest
+		opsRequestPhase = opsRequest.Status.Phase
+		adoptSpec       = opsRequest.Spec.AdoptSpec
+		completedCount  int
+		failed          bool
+	)
+	cluster := &appsv1alpha1.Cluster{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRequest.Spec.ClusterRef, Namespace: opsRequest.Namespace}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			_ = PatchClusterNotFound(reqCtx.Ctx, cli, opsRes)
+		}
+		return appsv1alpha1.OpsFailedPhase, 0, err
+	}
+	opsRes.Cluster = cluster
+	compSpec := cluster.Spec.GetComponentByName(adoptSpec.ComponentName)
+	if compSpec == nil {
+		return appsv1alpha1.OpsFailedPhase, 0, intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" is not found`, adoptSpec.ComponentName))
+	}
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRequest.Status.Components[adoptSpec.ComponentName]
+	expectCount := int(compSpec.Replicas) + len(compSpec.OfflineInstances)
+	for ordinal := expectCount - 1; ordinal >= 0; ordinal-- {
+		instanceName := constant.GeneratePodName(cluster.Name, adoptSpec.ComponentName, ordinal)
+		progressDetail := r.getInstanceProgressDetail(compStatus, instanceName)
+		if isCompletedProgressStatus(progressDetail.Status) {
+			completedCount += 1
+			continue
+		}
+		completed, err := r.adoptInstance(reqCtx, cli, opsRes, compSpec, ordinal, &progressDetail)
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, err.Error())
+			failed = true
+		} else if err != nil {
+			return opsRequestPhase, 0, err
+		} else if completed {
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+				fmt.Sprintf("Adopt pod %s successfully", instanceName))
+			completedCount += 1
+		}
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+		// the instances are adopted one by one.
+		if !completed {
+			break
+		}
+	}
+	opsRequest.Status.Components[adoptSpec.ComponentName] = compStatus
+	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if failed {
+		return appsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	if completedCount != expectCount {
+		return opsRequestPhase, 0, nil
+	}
+	// all instances have been adopted, the StatefulSet which has been scaled to zero is no longer needed.
+	sts := &appsv1.StatefulSet{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: adoptSpec.StatefulSetName, Namespace: opsRequest.Namespace}, sts); err == nil {
+		if err = intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, sts); err != nil {
+			return opsRequestPhase, 0, err
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return opsRequestPhase, 0, err
+	}
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return opsRequestPhase, 0, nil
+	}
+	return appsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration saves last configuration to the OpsRequest.status.lastConfiguration
+func (r adoptOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error {
+	return nil
+}
+
+func (r adoptOpsHandler) getInstanceProgressDetail(compStatus appsv1alpha1.OpsRequestComponentStatus, instance string) appsv1alpha1.ProgressStatusDetail {
+	objectKey := getProgressObjectKey(constant.PodKind, instance)
+	progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+	if progressDetail != nil {
+		return *progressDetail
+	}
+	return appsv1alpha1.ProgressStatusDetail{
+		ObjectKey: objectKey,
+		Status:    appsv1alpha1.ProcessingProgressStatus,
+		Message:   fmt.Sprintf("Start to adopt pod %s", instance),
+	}
+}
+
+// volumeClaimTemplateOf returns the name of the volume claim template of the StatefulSet which maps to the volume.
+func (r adoptOpsHandler) volumeClaimTemplateOf(adoptSpec *appsv1alpha1.AdoptSpec, volumeName string) string {
+	for _, m := range adoptSpec.VolumeMappings {
+		if m.VolumeName == volumeName {
+			return m.VolumeClaimTemplate
+		}
+	}
+	return volumeName
+}
+
+// volumeNameOf returns the name of the volume of the ComponentDefinition which the volume claim template maps to.
+func (r adoptOpsHandler) volumeNameOf(adoptSpec *appsv1alpha1.AdoptSpec, vctName string) string {
+	for _, m := range adoptSpec.VolumeMappings {
+		if m.VolumeClaimTemplate == vctName {
+			return m.VolumeName
+		}
+	}
+	return vctName
+}
+
+// validateVolumeLayout checks that the volume claim templates of the StatefulSet match the volumes of the ComponentDefinition,
+// and the data directories are mounted at the same paths, so the new instances can run on the existing volumes.
+func (r adoptOpsHandler) validateVolumeLayout(adoptSpec *appsv1alpha1.AdoptSpec,
+	sts *appsv1.StatefulSet,
+	compDef *appsv1alpha1.ComponentDefinition) error {
+	vctNames := map[string]bool{}
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		vctNames[vct.Name] = true
+	}
+	for _, m := range adoptSpec.VolumeMappings {
+		if !vctNames[m.VolumeClaimTemplate] {
+			return fmt.Errorf(`the volume claim template "%s" is not found in the StatefulSet "%s"`, m.VolumeClaimTemplate, sts.Name)
+		}
+	}
+	compDefVolumes := map[string]bool{}
+	for _, v := range compDef.Spec.Volumes {
+		compDefVolumes[v.Name] = true
+	}
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		if volumeName := r.volumeNameOf(adoptSpec, vct.Name); !compDefVolumes[volumeName] {
+			return fmt.Errorf(`the volume claim template "%s" of the StatefulSet does not match any volume of the ComponentDefinition "%s"`,
+				vct.Name, compDef.Name)
+		}
+	}
+	for _, v := range compDef.Spec.Volumes {
+		if !vctNames[r.volumeClaimTemplateOf(adoptSpec, v.Name)] {
+			return fmt.Errorf(`the volume "%s" of the ComponentDefinition is not provided by the StatefulSet "%s"`, v.Name, sts.Name)
+		}
+	}
+
+	// the mounts of the persistent volumes by the ComponentDefinition should be found in the StatefulSet,
+	// prefer the container with the same name.
+	stsMounts := func(containerName, vctName string) []corev1.VolumeMount {
+		var mounts, namedMounts []corev1.VolumeMount
+		for _, c := range sts.Spec.Template.Spec.Containers {
+			for _, m := range c.VolumeMounts {
+				if m.Name != vctName {
+					continue
+				}
+				mounts = append(mounts, m)
+				if c.Name == containerName {
+					namedMounts = append(namedMounts, m)
+				}
+			}
+		}
+		if len(namedMounts) > 0 {
+			return namedMounts
+		}
+		return mounts
+	}
+	for _, c := range compDef.Spec.Runtime.Containers {
+		for _, m := range c.VolumeMounts {
+			if !compDefVolumes[m.Name] {
+				continue
+			}
+			vctName := r.volumeClaimTemplateOf(adoptSpec, m.Name)
+			if !slices.ContainsFunc(stsMounts(c.Name, vctName), func(sm corev1.VolumeMount) bool {
+				return sm.MountPath == m.MountPath && sm.SubPath == m.SubPath
+			}) {
+				return fmt.Errorf(`the volume "%s" is mounted at "%s" by the container "%s" of the ComponentDefinition, `+
+					`but the volume claim template "%s" is not mounted at the same path in the StatefulSet`,
+					m.Name, strings.TrimSuffix(m.MountPath+"/"+m.SubPath, "/"), c.Name, vctName)
+			}
+		}
+	}
+	return nil
+}
+
+// validateReplicas checks that the replicas of the StatefulSet are within the replicas limit of the ComponentDefinition,
+// all the replicas, including the instances offline before they are adopted, are taken over by the component.
+func (r adoptOpsHandler) validateReplicas(sts *appsv1.StatefulSet, compDef *appsv1alpha1.ComponentDefinition) error {
+	replicasLimit := compDef.Spec.ReplicasLimit
+	if replicasLimit == nil {
+		return nil
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if replicas < replicasLimit.MinReplicas || replicas > replicasLimit.MaxReplicas {
+		return fmt.Errorf(`the replicas %d of the StatefulSet "%s" are out of the limit [%d, %d] of the ComponentDefinition "%s"`,
+			replicas, sts.Name, replicasLimit.MinReplicas, replicasLimit.MaxReplicas, compDef.Name)
+	}
+	return nil
+}
+
+// validateSystemAccounts checks that the existing password of each system account is provided, otherwise a new password
+// is generated for the account, which does not work with the adopted database.
+func (r adoptOpsHandler) validateSystemAccounts(adoptSpec *appsv1alpha1.AdoptSpec, compDef *appsv1alpha1.ComponentDefinition) error {
+	accounts := map[string]bool{}
+	for _, account := range compDef.Spec.SystemAccounts {
+		accounts[account.Name] = true
+		if account.SecretRef != nil || slices.ContainsFunc(adoptSpec.SystemAccounts, func(a appsv1alpha1.AdoptSystemAccount) bool {
+			return a.Name == account.Name
+		}) {
+			continue
+		}
+		return fmt.Errorf(`the password of the system account "%s" is required to adopt the database`, account.Name)
+	}
+	for _, account := range adoptSpec.SystemAccounts {
+		if !accounts[account.Name] {
+			return fmt.Errorf(`the system account "%s" is not defined in the ComponentDefinition "%s"`, account.Name, compDef.Name)
+		}
+	}
+	return nil
+}
+
+// importAccountSecrets imports the existing passwords of the system accounts as the account secrets of the component.
+func (r adoptOpsHandler) importAccountSecrets(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) error {
+	adoptSpec := opsRequest.Spec.AdoptSpec
+	for _, account := range adoptSpec.SystemAccounts {
+		source := &corev1.Secret{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: account.SecretName, Namespace: opsRequest.Namespace}, source); err != nil {
+			if apierrors.IsNotFound(err) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the secret "%s" of the system account "%s" is not found`,
+					account.SecretName, account.Name))
+			}
+			return err
+		}
+		passwordKey := account.PasswordKey
+		if passwordKey == "" {
+			passwordKey = constant.AccountPasswdForSecret
+		}
+		password := source.Data[passwordKey]
+		if len(password) == 0 {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the key "%s" is not found in the secret "%s" of the system account "%s"`,
+				passwordKey, account.SecretName, account.Name))
+		}
+		secretName := constant.GenerateAccountSecretName(opsRequest.Spec.ClusterRef, adoptSpec.ComponentName, account.Name)
+		existing := &corev1.Secret{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: secretName, Namespace: opsRequest.Namespace}, existing); err == nil {
+			if existing.Annotations[adoptFromAnnotation] != opsRequest.Name {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the account secret "%s" already exists`, secretName))
+			}
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		labels := constant.GetComponentWellKnownLabels(opsRequest.Spec.ClusterRef, adoptSpec.ComponentName)
+		labels[constant.ClusterAccountLabelKey] = account.Name
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName,
+				Namespace:   opsRequest.Namespace,
+				Labels:      labels,
+				Annotations: map[string]string{adoptFromAnnotation: opsRequest.Name},
+			},
+			Immutable: pointer.Bool(true),
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte(account.Name),
+				constant.AccountPasswdForSecret: password,
+			},
+		}
+		if err := cli.Create(reqCtx.Ctx, secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildAdoptCluster builds the cluster which takes over the StatefulSet.
+// The cluster is created with all instances offline, no instance is created until its volumes are re-bound.
+func (r adoptOpsHandler) buildAdoptCluster(opsRequest *appsv1alpha1.OpsRequest,
+	sts *appsv1.StatefulSet,
+	compDef *appsv1alpha1.ComponentDefinition) *appsv1alpha1.Cluster {
+	adoptSpec := opsRequest.Spec.AdoptSpec
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	compSpec := appsv1alpha1.ClusterComponentSpec{
+		Name:           adoptSpec.ComponentName,
+		ComponentDef:   adoptSpec.ComponentDef,
+		ServiceVersion: adoptSpec.ServiceVersion,
+		Replicas:       0,
+	}
+	for i := int32(0); i < replicas; i++ {
+		compSpec.OfflineInstances = append(compSpec.OfflineInstances,
+			constant.GeneratePodName(opsRequest.Spec.ClusterRef, adoptSpec.ComponentName, int(i)))
+	}
+	// inherit the resources of the main container.
+	if len(compDef.Spec.Runtime.Containers) > 0 {
+		mainContainerName := compDef.Spec.Runtime.Containers[0].Name
+		for _, c := range sts.Spec.Template.Spec.Containers {
+			if c.Name == mainContainerName {
+				compSpec.Resources = c.Resources
+				break
+			}
+		}
+	}
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		compSpec.VolumeClaimTemplates = append(compSpec.VolumeClaimTemplates, appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+			Name: r.volumeNameOf(adoptSpec, vct.Name),
+			Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+				AccessModes:      vct.Spec.AccessModes,
+				Resources:        corev1.ResourceRequirements{Requests: vct.Spec.Resources.Requests, Limits: vct.Spec.Resources.Limits},
+				StorageClassName: vct.Spec.StorageClassName,
+				VolumeMode:       vct.Spec.VolumeMode,
+			},
+		})
+	}
+	return &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opsRequest.Spec.ClusterRef,
+			Namespace: opsRequest.Namespace,
+		},
+		Spec: appsv1alpha1.ClusterSpec{
+			// the data of the adopted database should not be deleted with the cluster by accident.
+			TerminationPolicy: appsv1alpha1.DoNotTerminate,
+			ComponentSpecs:    []appsv1alpha1.ClusterComponentSpec{compSpec},
+		},
+	}
+}
+
+// adoptInstance adopts the instance of the ordinal: the pod of the StatefulSet is scaled in, its PVCs are re-bound
+// with the InstanceSet naming convention, and then the instance is brought online on the same volumes.
+func (r adoptOpsHandler) adoptInstance(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	ordinal int,
+	progressDetail *appsv1alpha1.ProgressStatusDetail) (bool, error) {
+	var (
+		opsRequest  = opsRes.OpsRequest
+		adoptSpec   = opsRequest.Spec.AdoptSpec
+		namespace   = opsRequest.Namespace
+		offlineName = constant.GeneratePodName(opsRes.Cluster.Name, adoptSpec.ComponentName, ordinal)
+	)
+	// the volumes are bound to the active instance, which is named after the green set if the blue/green update switched to it.
+	instanceName, err := getActiveInstanceName(reqCtx.Ctx, cli, opsRes.Cluster, adoptSpec.ComponentName, offlineName)
+	if err != nil {
+		return false, err
+	}
+	if slices.Contains(compSpec.OfflineInstances, offlineName) {
+		// 1. scale in the StatefulSet to release the pod of the ordinal.
+		stsPodName := fmt.Sprintf("%s-%d", adoptSpec.StatefulSetName, ordinal)
+		sts := &appsv1.StatefulSet{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: adoptSpec.StatefulSetName, Namespace: namespace}, sts); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return false, err
+			}
+		} else if sts.Spec.Replicas == nil || *sts.Spec.Replicas > int32(ordinal) {
+			patch := client.MergeFrom(sts.DeepCopy())
+			sts.Spec.Replicas = pointer.Int32(int32(ordinal))
+			if err := cli.Patch(reqCtx.Ctx, sts, patch); err != nil {
+				return false, err
+			}
+		}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: stsPodName, Namespace: namespace}, &corev1.Pod{}); err == nil {
+			progressDetail.Message = fmt.Sprintf(`Waiting for the pod "%s" of the StatefulSet to be terminated`, stsPodName)
+			return false, nil
+		} else if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		// 2. re-bind the PVs of the pod to the PVCs with the InstanceSet naming convention.
+		for _, vct := range compSpec.VolumeClaimTemplates {
+			sourcePVCName := fmt.Sprintf("%s-%s", r.volumeClaimTemplateOf(adoptSpec, vct.Name), stsPodName)
+			targetPVCName := fmt.Sprintf("%s-%s", vct.Name, instanceName)
+			if err := r.rebindPVC(reqCtx, cli, opsRes, sourcePVCName, targetPVCName, vct.Name); err != nil {
+				return false, err
+			}
+		}
+		// 3. bring the instance online.
+		for i := range opsRes.Cluster.Spec.ComponentSpecs {
+			spec := &opsRes.Cluster.Spec.ComponentSpecs[i]
+			if spec.Name != adoptSpec.ComponentName {
+				continue
+			}
+			if index := slices.Index(spec.OfflineInstances, offlineName); index >= 0 {
+				spec.OfflineInstances = slices.Delete(spec.OfflineInstances, index, index+1)
+			}
+			spec.Replicas += 1
+		}
+		if err := cli.Update(reqCtx.Ctx, opsRes.Cluster); err != nil {
+			return false, err
+		}
+		progressDetail.Message = fmt.Sprintf(`Waiting for the instance "%s" to be available`, instanceName)
+		return false, nil
+	}
+
+	// 4. wait for the instance to be available.
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: instanceName, Namespace: namespace}, pod); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if isFailed, isTimeout, _ := intctrlutil.IsPodFailedAndTimedOut(pod); isFailed && isTimeout {
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`the instance "%s" is failed on the adopted volumes, please check it`, instanceName))
+	}
+	if !pod.DeletionTimestamp.IsZero() || !podutils.IsPodAvailable(pod, 0, metav1.Now()) {
+		return false, nil
+	}
+	// 5. restore the reclaim policies of the PVs.
+	for _, vct := range compSpec.VolumeClaimTemplates {
+		if err := r.restorePVReclaimPolicy(reqCtx, cli, fmt.Sprintf("%s-%s", vct.Name, instanceName)); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// rebindPVC re-binds the PV of the source PVC to the target PVC.
+// The PV is retained and labeled with the target PVC name firstly, so the process can be reentered after the source PVC is deleted.
+func (r adoptOpsHandler) rebindPVC(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	sourcePVCName, targetPVCName, vctName string) error {
+	opsRequest := opsRes.OpsRequest
+	targetPVC := &corev1.PersistentVolumeClaim{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: targetPVCName, Namespace: opsRequest.Namespace}, targetPVC); err == nil {
+		if targetPVC.Annotations[adoptFromAnnotation] != opsRequest.Name {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the pvc "%s" already exists`, targetPVCName))
+		}
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	pv, sourcePVC, err := r.getSourcePV(reqCtx, cli, opsRequest.Namespace, sourcePVCName, targetPVCName)
+	if err != nil {
+		return err
+	}
+	// 1. retain and label the pv.
+	if pv.Labels[adoptPVCNameLabel] != targetPVCName {
+		patchPV := client.MergeFrom(pv.DeepCopy())
+		if pv.Labels == nil {
+			pv.Labels = map[string]string{}
+		}
+		if pv.Annotations == nil {
+			pv.Annotations = map[string]string{}
+		}
+		pv.Labels[adoptPVCNameLabel] = targetPVCName
+		pv.Annotations[adoptFromAnnotation] = opsRequest.Name
+		pv.Annotations[constant.PVLastClaimPolicyAnnotationKey] = string(pv.Spec.PersistentVolumeReclaimPolicy)
+		pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+		if err = cli.Patch(reqCtx.Ctx, pv, patchPV); err != nil {
+			return err
+		}
+	}
+	// 2. delete the source pvc, and release the pv.
+	if sourcePVC != nil {
+		if err = intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, sourcePVC); err != nil {
+			return err
+		}
+		patch := client.MergeFrom(sourcePVC.DeepCopy())
+		sourcePVC.Finalizers = nil
+		if err = client.IgnoreNotFound(cli.Patch(reqCtx.Ctx, sourcePVC, patch)); err != nil {
+			return err
+		}
+	}
+	if pv.Spec.ClaimRef != nil {
+		patchPV := client.MergeFrom(pv.DeepCopy())
+		pv.Spec.ClaimRef = nil
+		if err = cli.Patch(reqCtx.Ctx, pv, patchPV); err != nil {
+			return err
+		}
+	}
+	// 3. create the target pvc bound to the pv.
+	labels := constant.GetKBWellKnownLabelsWithCompDef(opsRequest.Spec.AdoptSpec.ComponentDef,
+		opsRes.Cluster.Name, opsRequest.Spec.AdoptSpec.ComponentName)
+	labels[constant.VolumeClaimTemplateNameLabelKey] = vctName
+	targetPVC = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      targetPVCName,
+			Namespace: opsRequest.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				adoptFromAnnotation: opsRequest.Name,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pv.Spec.AccessModes,
+			Resources:        corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: pv.Spec.Capacity[corev1.ResourceStorage]}},
+			StorageClassName: pointer.String(pv.Spec.StorageClassName),
+			VolumeMode:       pv.Spec.VolumeMode,
+			VolumeName:       pv.Name,
+		},
+	}
+	return cli.Create(reqCtx.Ctx, targetPVC)
+}
+
+// getSourcePV gets the PV bound to the source PVC, or the PV labeled with the target PVC name if the source PVC has been deleted.
+func (r adoptOpsHandler) getSourcePV(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	namespace, sourcePVCName, targetPVCName string) (*corev1.PersistentVolume, *corev1.PersistentVolumeClaim, error) {
+	sourcePVC := &corev1.PersistentVolumeClaim{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: sourcePVCName, Namespace: namespace}, sourcePVC); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, nil, err
+		}
+		pvList := &corev1.PersistentVolumeList{}
+		if err = cli.List(reqCtx.Ctx, pvList, client.MatchingLabels{adoptPVCNameLabel: targetPVCName}, client.Limit(1)); err != nil {
+			return nil, nil, err
+		}
+		if len(pvList.Items) == 0 {
+			return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf(`the pvc "%s" of the StatefulSet is not found`, sourcePVCName))
+		}
+		return &pvList.Items[0], nil, nil
+	}
+	if sourcePVC.Spec.VolumeName == "" {
+		return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf(`the pvc "%s" of the StatefulSet is not bound`, sourcePVCName))
+	}
+	pv := &corev1.PersistentVolume{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: sourcePVC.Spec.VolumeName}, pv); err != nil {
+		return nil, nil, err
+	}
+	return pv, sourcePVC, nil
+}
+
+// restorePVReclaimPolicy restores the reclaim policy of the PV after it is bound to the target PVC.
+func (r adoptOpsHandler) restorePVReclaimPolicy(reqCtx intctrlutil.RequestCtx, cli client.Client, targetPVCName string) error {
+	pvList := &corev1.PersistentVolumeList{}
+	if err := cli.List(reqCtx.Ctx, pvList, client.MatchingLabels{adoptPVCNameLabel: targetPVCName}); err != nil {
+		return err
+	}
+	for i := range pvList.Items {
+		pv := &pvList.Items[i]
+		patchPV := client.MergeFrom(pv.DeepCopy())
+		if policy := pv.Annotations[constant.PVLastClaimPolicyAnnotationKey]; policy != "" {
+			pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimPolicy(policy)
+		}
+		delete(pv.Annotations, constant.PVLastClaimPolicyAnnotationKey)
+		delete(pv.Labels, adoptPVCNameLabel)
+		if err := cli.Patch(reqCtx.Ctx, pv, patchPV); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("Adopt OpsRequest", func() {
+	newStatefulSet := func(mountPath string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Int32(3),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:         "mysql",
+								VolumeMounts: []corev1.VolumeMount{{Name: "mysql-data", MountPath: mountPath}},
+								Resources: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+								},
+							},
+						},
+					},
+				},
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "mysql-data"},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-8.0"},
+		Spec: appsv1alpha1.ComponentDefinitionSpec{
+			Runtime: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:         "mysql",
+						VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/mysql"}},
+					},
+				},
+			},
+			Volumes: []appsv1alpha1.ComponentVolume{{Name: "data"}},
+		},
+	}
+	ops := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "adopt-ops", Namespace: "default"},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterRef: "mycluster",
+			Type:       appsv1alpha1.AdoptType,
+			AdoptSpec: &appsv1alpha1.AdoptSpec{
+				StatefulSetName: "mysql",
+				ComponentName:   "mysql",
+				ComponentDef:    compDef.Name,
+				VolumeMappings: []appsv1alpha1.AdoptVolumeMapping{
+					{VolumeClaimTemplate: "mysql-data", VolumeName: "data"},
+				},
+			},
+		},
+	}
+
+	Context("Test Adopt OpsRequest", func() {
+		It("validates the data directory layout of the StatefulSet", func() {
+			By("expect the validation to pass if the volumes are mounted at the same paths")
+			Expect(adoptOpsHandler{}.validateVolumeLayout(ops.Spec.AdoptSpec, newStatefulSet("/var/lib/mysql"), compDef)).Should(Succeed())
+
+			By("expect the validation to fail if the data directory is mounted at another path")
+			Expect(adoptOpsHandler{}.validateVolumeLayout(ops.Spec.AdoptSpec, newStatefulSet("/data"), compDef)).Should(HaveOccurred())
+
+			By("expect the validation to fail if the volume claim template is not mapped")
+			Expect(adoptOpsHandler{}.validateVolumeLayout(&appsv1alpha1.AdoptSpec{}, newStatefulSet("/var/lib/mysql"), compDef)).Should(HaveOccurred())
+		})
+
+		It("validates the replicas and the system accounts against the ComponentDefinition", func() {
+			By("expect the validation to fail if the replicas of the StatefulSet are out of the limit")
+			limitedCompDef := compDef.DeepCopy()
+			limitedCompDef.Spec.ReplicasLimit = &appsv1alpha1.ReplicasLimit{MinReplicas: 1, MaxReplicas: 2}
+			Expect(adoptOpsHandler{}.validateReplicas(newStatefulSet("/var/lib/mysql"), limitedCompDef)).Should(HaveOccurred())
+			limitedCompDef.Spec.ReplicasLimit.MaxReplicas = 5
+			Expect(adoptOpsHandler{}.validateReplicas(newStatefulSet("/var/lib/mysql"), limitedCompDef)).Should(Succeed())
+
+			By("expect the validation to fail if the password of a system account is not provided")
+			accountCompDef := compDef.DeepCopy()
+			accountCompDef.Spec.SystemAccounts = []appsv1alpha1.SystemAccount{{Name: "root", InitAccount: true}}
+			adoptSpec := ops.Spec.AdoptSpec.DeepCopy()
+			Expect(adoptOpsHandler{}.validateSystemAccounts(adoptSpec, accountCompDef)).Should(HaveOccurred())
+
+			By("expect the validation to fail if the system account is not defined")
+			adoptSpec.SystemAccounts = []appsv1alpha1.AdoptSystemAccount{{Name: "root", SecretName: "mysql-root"}, {Name: "admin", SecretName: "mysql-admin"}}
+			Expect(adoptOpsHandler{}.validateSystemAccounts(adoptSpec, accountCompDef)).Should(HaveOccurred())
+
+			By("expect the validation to pass if the passwords of all system accounts are provided")
+			adoptSpec.SystemAccounts = adoptSpec.SystemAccounts[:1]
+			Expect(adoptOpsHandler{}.validateSystemAccounts(adoptSpec, accountCompDef)).Should(Succeed())
+		})
+
+		It("builds the cluster with all instances offline", func() {
+			cluster := adoptOpsHandler{}.buildAdoptCluster(ops, newStatefulSet("/var/lib/mysql"), compDef)
+			Expect(cluster.Name).Should(Equal("mycluster"))
+			compSpec := cluster.Spec.ComponentSpecs[0]
+			Expect(compSpec.ComponentDef).Should(Equal(compDef.Name))
+			Expect(compSpec.Replicas).Should(BeEquivalentTo(0))
+			Expect(compSpec.OfflineInstances).Should(Equal([]string{"mycluster-mysql-0", "mycluster-mysql-1", "mycluster-mysql-2"}))
+			Expect(compSpec.Resources.Limits.Cpu().String()).Should(Equal("1"))
+			Expect(compSpec.VolumeClaimTemplates).Should(HaveLen(1))
+			Expect(compSpec.VolumeClaimTemplates[0].Name).Should(Equal("data"))
+			Expect(compSpec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String()).Should(Equal("20Gi"))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// OpsApprovalPolicy specifies the OpsRequest types which need approval before running in the matched namespaces.
+type OpsApprovalPolicy struct {
+	// OpsTypes specifies the OpsRequest types which need approval.
+	OpsTypes []appsv1alpha1.OpsType `json:"opsTypes"`
+	// Namespaces specifies the namespaces which the policy applies to.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces which the policy applies to by the labels of the namespace.
+	// the policy applies to all namespaces if both namespaces and namespaceSelector are empty.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ParseOpsApprovalPolicies parses the approval policies from the manager config.
+func ParseOpsApprovalPolicies(policiesJSON string) ([]OpsApprovalPolicy, error) {
+	if policiesJSON == "" {
+		return nil, nil
+	}
+	var policies []OpsApprovalPolicy
+	if err := json.Unmarshal([]byte(policiesJSON), &policies); err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if policy.NamespaceSelector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(policy.NamespaceSelector); err != nil {
+			return nil, err
+		}
+	}
+	return policies, nil
+}
+
+// matches checks if the policy applies to the OpsRequest.
+func (p OpsApprovalPolicy) matches(opsRequest *appsv1alpha1.OpsRequest, namespace *corev1.Namespace) (bool, error) {
+	if !slices.Contains(p.OpsTypes, opsRequest.Spec.Type) {
+		return false, nil
+	}
+	if len(p.Namespaces) == 0 && p.NamespaceSelector == nil {
+		return true, nil
+	}
+	if slices.Contains(p.Namespaces, opsRequest.Namespace) {
+		return true, nil
+	}
+	if p.NamespaceSelector == nil || namespace == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+// needApproval checks if the OpsRequest needs approval according to the approval policies.
+func needApproval(ctx context.Context, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (bool, error) {
+	policies, err := ParseOpsApprovalPolicies(viper.GetString(constant.CfgKeyOpsApprovalPolicies))
+	if err != nil || len(policies) == 0 {
+		return false, err
+	}
+	var namespace *corev1.Namespace
+	for _, policy := range policies {
+		if policy.NamespaceSelector != nil && namespace == nil {
+			namespace = &corev1.Namespace{}
+			if err = cli.Get(ctx, client.ObjectKey{Name: opsRequest.Namespace}, namespace); err != nil {
+				return false, err
+			}
+		}
+		matched, err := policy.matches(opsRequest, namespace)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// handleApproval keeps the OpsRequest in the Pending phase until it is approved, and records the approval in the status.
+// it returns a non-nil result if the OpsRequest should wait.
+func handleApproval(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*ctrl.Result, error) {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Approval != nil {
+		return nil, nil
+	}
+	required, err := needApproval(reqCtx.Ctx, cli, opsRequest)
+	if err != nil || !required {
+		return nil, err
+	}
+	var (
+		requestedBy = opsRequest.Annotations[constant.OpsRequestRequestedByAnnotationKey]
+		approvedBy  = opsRequest.Annotations[constant.OpsRequestApprovedByAnnotationKey]
+		condition   *metav1.Condition
+	)
+	// the approval is checked by the webhook as well, it is re-checked here in case the annotations are set
+	// without the webhook.
+	switch {
+	case approvedBy == "":
+		condition = appsv1alpha1.NewWaitForApprovalCondition(opsRequest)
+	case requestedBy == "":
+		condition = appsv1alpha1.NewInvalidApprovalCondition(opsRequest,
+			fmt.Sprintf(`the requester is unknown as the annotation "%s" is missing`, constant.OpsRequestRequestedByAnnotationKey))
+	case requestedBy == approvedBy:
+		condition = appsv1alpha1.NewInvalidApprovalCondition(opsRequest,
+			fmt.Sprintf(`it can not be approved by the requester "%s"`, requestedBy))
+	}
+	if condition != nil {
+		oldCondition := meta.FindStatusCondition(opsRequest.Status.Conditions, condition.Type)
+		if oldCondition == nil || oldCondition.Reason != condition.Reason || oldCondition.Message != condition.Message {
+			if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase, condition); err != nil {
+				return nil, err
+			}
+		}
+		// the OpsRequest will be enqueued again when the approval annotation is changed.
+		return intctrlutil.ResultToP(intctrlutil.Reconciled())
+	}
+	opsRequestDeepCopy := opsRequest.DeepCopy()
+	opsRequest.Status.Approval = &appsv1alpha1.OpsApproval{
+		ApprovedBy:   approvedBy,
+		RequestedBy:  requestedBy,
+		ApprovalTime: metav1.Now(),
+	}
+	if err = PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsRequestDeepCopy, appsv1alpha1.OpsPendingPhase,
+		appsv1alpha1.NewApprovedCondition(opsRequest)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
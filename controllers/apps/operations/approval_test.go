@@ -0,0 +1,170 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("OpsRequest Approval", func() {
+
+	var (
+		randomStr             = testCtx.GetRandomStr()
+		clusterDefinitionName = "cluster-definition-for-ops-" + randomStr
+		clusterVersionName    = "clusterversion-for-ops-" + randomStr
+		clusterName           = "cluster-for-ops-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeyOpsApprovalPolicies, "")
+		cleanEnv()
+	})
+
+	Context("Test approval policies", func() {
+		It("matches the OpsRequest by the type and the namespace", func() {
+			policies, err := ParseOpsApprovalPolicies(`[{"opsTypes":["Stop","Upgrade"],"namespaces":["prod"]},` +
+				`{"opsTypes":["Restore"],"namespaceSelector":{"matchLabels":{"env":"production"}}}]`)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(policies).Should(HaveLen(2))
+
+			ops := &appsv1alpha1.OpsRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "prod"}}
+			ops.Spec.Type = appsv1alpha1.StopType
+			Expect(policies[0].matches(ops, nil)).Should(BeTrue())
+			ops.Namespace = "dev"
+			Expect(policies[0].matches(ops, nil)).Should(BeFalse())
+
+			ops.Spec.Type = appsv1alpha1.RestoreType
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "production"}}}
+			Expect(policies[1].matches(ops, namespace)).Should(BeTrue())
+			namespace.Labels["env"] = "test"
+			Expect(policies[1].matches(ops, namespace)).Should(BeFalse())
+
+			By("expect an error if the policies are invalid")
+			_, err = ParseOpsApprovalPolicies(`{"opsTypes":["Stop"]}`)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Test OpsRequest with approval", func() {
+		var (
+			opsRes *OpsResource
+			reqCtx intctrlutil.RequestCtx
+		)
+		BeforeEach(func() {
+			By("init operations resources ")
+			opsRes, _, _ = initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+		})
+
+		It("keeps the Restart OpsRequest in Pending phase until it is approved", func() {
+			viper.Set(constant.CfgKeyOpsApprovalPolicies, `[{"opsTypes":["Restart"]}]`)
+
+			By("create Restart opsRequest")
+			opsRes.OpsRequest = createRestartOpsObj(clusterName, "restart-ops-"+randomStr)
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Phase).To(Equal(appsv1alpha1.OpsPendingPhase))
+					g.Expect(fetched.Status.Approval).Should(BeNil())
+					condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeApproval)
+					g.Expect(condition).ShouldNot(BeNil())
+					g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonWaitForApproval))
+				})).Should(Succeed())
+
+			By("expect the approval without the requester to be rejected")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Annotations = map[string]string{constant.OpsRequestApprovedByAnnotationKey: "approver"}
+			})).Should(Succeed())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Phase).To(Equal(appsv1alpha1.OpsPendingPhase))
+					g.Expect(fetched.Status.Approval).Should(BeNil())
+					condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeApproval)
+					g.Expect(condition).ShouldNot(BeNil())
+					g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonInvalidApproval))
+				})).Should(Succeed())
+
+			By("expect the self-approval to be rejected")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Annotations[constant.OpsRequestRequestedByAnnotationKey] = "approver"
+			})).Should(Succeed())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Approval).Should(BeNil())
+					condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeApproval)
+					g.Expect(condition).ShouldNot(BeNil())
+					g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonInvalidApproval))
+					g.Expect(condition.Message).Should(ContainSubstring("requester"))
+				})).Should(Succeed())
+
+			By("approve the opsRequest")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Annotations[constant.OpsRequestRequestedByAnnotationKey] = "requester"
+			})).Should(Succeed())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Approval).ShouldNot(BeNil())
+					g.Expect(fetched.Status.Approval.ApprovedBy).Should(Equal("approver"))
+					g.Expect(fetched.Status.Approval.RequestedBy).Should(Equal("requester"))
+					condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeApproval)
+					g.Expect(condition).ShouldNot(BeNil())
+					g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonApproved))
+				})).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,290 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+type cloneOpsHandler struct{}
+
+var _ OpsHandler = cloneOpsHandler{}
+
+func init() {
+	// register clone operation, it will create a new cluster
+	cloneBehaviour := OpsBehaviour{
+		OpsHandler:        cloneOpsHandler{},
+		IsClusterCreation: true,
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.CloneType, cloneBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the clone request.
+func (r cloneOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewCloneCondition(opsRes.OpsRequest), nil
+}
+
+// Action creates the new cluster from the backups of the source cluster.
+func (r cloneOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	cloneSpec := opsRequest.Spec.CloneSpec
+	sourceCluster := &appsv1alpha1.Cluster{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: cloneSpec.SourceClusterName,
+		Namespace: r.sourceNamespace(opsRequest)}, sourceCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the source cluster "%s" is not found`, cloneSpec.SourceClusterName))
+		}
+		return err
+	}
+	backups, err := r.selectBackups(reqCtx, cli, sourceCluster, cloneSpec)
+	if err != nil {
+		return err
+	}
+	cluster, err := r.buildCloneCluster(opsRequest, backups)
+	if err != nil {
+		return err
+	}
+	util.SetOpsRequestToCluster(cluster, []appsv1alpha1.OpsRecorder{
+		{
+			Name: opsRequest.Name,
+			Type: opsRequest.Spec.Type,
+		},
+	})
+	return createClusterOfOpsRequest(reqCtx, cli, opsRes, cluster)
+}
+
+// ReconcileAction checks the status of the new cluster, which is the same as the restore operation.
+func (r cloneOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	return RestoreOpsHandler{}.ReconcileAction(reqCtx, cli, opsRes)
+}
+
+// SaveLastConfiguration saves last configuration to the OpsRequest.status.lastConfiguration
+func (r cloneOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error {
+	return nil
+}
+
+func (r cloneOpsHandler) sourceNamespace(opsRequest *appsv1alpha1.OpsRequest) string {
+	if ns := opsRequest.Spec.CloneSpec.SourceClusterNamespace; ns != "" {
+		return ns
+	}
+	return opsRequest.Namespace
+}
+
+// selectBackups selects a backup for each backup policy of the source cluster.
+// The latest completed full backup is selected if the restore time is not specified,
+// otherwise the continuous backup covering the restore time is selected.
+func (r cloneOpsHandler) selectBackups(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	sourceCluster *appsv1alpha1.Cluster,
+	cloneSpec *appsv1alpha1.CloneSpec) ([]*dpv1alpha1.Backup, error) {
+	backupPolicyList := &dpv1alpha1.BackupPolicyList{}
+	if err := cli.List(reqCtx.Ctx, backupPolicyList, client.InNamespace(sourceCluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: sourceCluster.Name}); err != nil {
+		return nil, err
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(reqCtx.Ctx, backupList, client.InNamespace(sourceCluster.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey: sourceCluster.Name,
+		dptypes.ClusterUIDLabelKey:   string(sourceCluster.UID),
+	}); err != nil {
+		return nil, err
+	}
+	var restoreTime time.Time
+	if cloneSpec.RestoreTime != "" {
+		var err error
+		if restoreTime, err = time.Parse(time.RFC3339, cloneSpec.RestoreTime); err != nil {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`invalid restoreTime "%s": %s`, cloneSpec.RestoreTime, err.Error()))
+		}
+	}
+	var selectedBackups []*dpv1alpha1.Backup
+	for _, policy := range backupPolicyList.Items {
+		var policyBackups []*dpv1alpha1.Backup
+		for i := range backupList.Items {
+			if backupList.Items[i].Labels[dptypes.BackupPolicyLabelKey] == policy.Name {
+				policyBackups = append(policyBackups, &backupList.Items[i])
+			}
+		}
+		var backup *dpv1alpha1.Backup
+		if restoreTime.IsZero() {
+			backup = r.latestFullBackup(policyBackups, nil)
+		} else {
+			backup = r.continuousBackupForTime(policyBackups, restoreTime)
+		}
+		if backup != nil {
+			selectedBackups = append(selectedBackups, backup)
+		}
+	}
+	if len(selectedBackups) == 0 {
+		if restoreTime.IsZero() {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`no completed full backup found for the source cluster "%s"`, sourceCluster.Name))
+		}
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`no continuous backup and full backup found to restore the source cluster "%s" to %s`,
+			sourceCluster.Name, cloneSpec.RestoreTime))
+	}
+	return selectedBackups, nil
+}
+
+// latestFullBackup returns the latest completed full backup, which is completed before the given time if it is not nil.
+func (r cloneOpsHandler) latestFullBackup(backups []*dpv1alpha1.Backup, before *time.Time) *dpv1alpha1.Backup {
+	var latest *dpv1alpha1.Backup
+	for _, backup := range backups {
+		if backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeFull) ||
+			backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		endTime := backup.GetEndTime()
+		if endTime.IsZero() || (before != nil && endTime.After(*before)) {
+			continue
+		}
+		if latest == nil || endTime.After(latest.GetEndTime().Time) {
+			latest = backup
+		}
+	}
+	return latest
+}
+
+// continuousBackupForTime returns the continuous backup covering the time,
+// and a completed full backup taken during the continuous backup before the time is required as the base backup.
+func (r cloneOpsHandler) continuousBackupForTime(backups []*dpv1alpha1.Backup, restoreTime time.Time) *dpv1alpha1.Backup {
+	for _, backup := range backups {
+		if backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeContinuous) {
+			continue
+		}
+		startTime, endTime := backup.GetStartTime(), backup.GetEndTime()
+		if startTime.IsZero() || endTime.IsZero() || restoreTime.Before(startTime.Time) || restoreTime.After(endTime.Time) {
+			continue
+		}
+		fullBackup := r.latestFullBackup(backups, &restoreTime)
+		if fullBackup == nil || !fullBackup.GetEndTime().After(startTime.Time) {
+			continue
+		}
+		return backup
+	}
+	return nil
+}
+
+// buildCloneCluster builds the new cluster from the cluster snapshot of the backups, with the restore annotation
+// which is handled by the cluster restore transformer.
+func (r cloneOpsHandler) buildCloneCluster(opsRequest *appsv1alpha1.OpsRequest, backups []*dpv1alpha1.Backup) (*appsv1alpha1.Cluster, error) {
+	cloneSpec := opsRequest.Spec.CloneSpec
+	// use the cluster snapshot of the latest backup
+	snapshotBackup := backups[0]
+	for _, backup := range backups[1:] {
+		if backup.CreationTimestamp.After(snapshotBackup.CreationTimestamp.Time) {
+			snapshotBackup = backup
+		}
+	}
+	clusterString, ok := snapshotBackup.Annotations[constant.ClusterSnapshotAnnotationKey]
+	if !ok {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("missing snapshot annotation in backup %s, %s is empty in Annotations",
+			snapshotBackup.Name, constant.ClusterSnapshotAnnotationKey))
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err := json.Unmarshal([]byte(clusterString), cluster); err != nil {
+		return nil, err
+	}
+
+	// merge the restore info of all backups.
+	restoreInfo := map[string]map[string]string{}
+	for _, backup := range backups {
+		annotation, err := restore.GetRestoreFromBackupAnnotation(backup, cluster, cloneSpec.VolumeRestorePolicy, cloneSpec.RestoreTime, false)
+		if err != nil {
+			return nil, err
+		}
+		backupRestoreInfo := map[string]map[string]string{}
+		if err = json.Unmarshal([]byte(annotation), &backupRestoreInfo); err != nil {
+			return nil, err
+		}
+		for compName, info := range backupRestoreInfo {
+			if _, ok := restoreInfo[compName]; ok {
+				continue
+			}
+			// the restored data carries the system accounts of the source cluster, keep their password,
+			// so that the new cluster can connect to the restored database.
+			restoreInfo[compName] = info
+		}
+	}
+	restoreAnnotation, err := json.Marshal(restoreInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.ObjectMeta = metav1.ObjectMeta{
+		Name:        opsRequest.Spec.ClusterRef,
+		Namespace:   opsRequest.Namespace,
+		Annotations: map[string]string{constant.RestoreFromBackupAnnotationKey: string(restoreAnnotation)},
+	}
+	if v, ok := snapshotBackup.Annotations[constant.ExtraEnvAnnotationKey]; ok {
+		cluster.Annotations[constant.ExtraEnvAnnotationKey] = v
+	}
+	resetClusterServices(cluster)
+	if err = r.applyComponentOverrides(cluster, cloneSpec.ComponentOverrides); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// applyComponentOverrides applies the overrides to the components and shardings of the new cluster.
+func (r cloneOpsHandler) applyComponentOverrides(cluster *appsv1alpha1.Cluster, overrides []appsv1alpha1.CloneComponentOverride) error {
+	for _, override := range overrides {
+		var compSpec *appsv1alpha1.ClusterComponentSpec
+		for i := range cluster.Spec.ComponentSpecs {
+			if cluster.Spec.ComponentSpecs[i].Name == override.ComponentName {
+				compSpec = &cluster.Spec.ComponentSpecs[i]
+			}
+		}
+		for i := range cluster.Spec.ShardingSpecs {
+			if cluster.Spec.ShardingSpecs[i].Name == override.ComponentName {
+				compSpec = &cluster.Spec.ShardingSpecs[i].Template
+			}
+		}
+		if compSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found in the source cluster`, override.ComponentName))
+		}
+		if override.Replicas != nil {
+			compSpec.Replicas = *override.Replicas
+		}
+		if override.Resources != nil {
+			compSpec.Resources = *override.Resources
+		}
+		if override.StorageClassName != nil {
+			for i := range compSpec.VolumeClaimTemplates {
+				compSpec.VolumeClaimTemplates[i].Spec.StorageClassName = override.StorageClassName
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Clone OpsRequest", func() {
+	var (
+		now        = time.Now().Truncate(time.Second)
+		compName   = "mysql"
+		sourceName = "source-cluster"
+	)
+
+	newBackup := func(name string, backupType dpv1alpha1.BackupType, phase dpv1alpha1.BackupPhase, start, end time.Time) *dpv1alpha1.Backup {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: sourceName, Namespace: "prod"},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+					{
+						Name:     compName,
+						Replicas: 3,
+						VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+							{Name: "data"},
+						},
+					},
+				},
+			},
+		}
+		clusterBytes, _ := json.Marshal(cluster)
+		return &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "prod",
+				Labels: map[string]string{
+					dptypes.BackupTypeLabelKey:      string(backupType),
+					constant.KBAppComponentLabelKey: compName,
+				},
+				Annotations: map[string]string{
+					constant.ClusterSnapshotAnnotationKey:   string(clusterBytes),
+					dptypes.ConnectionPasswordAnnotationKey: "encrypted",
+				},
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase: phase,
+				TimeRange: &dpv1alpha1.BackupTimeRange{
+					Start: &metav1.Time{Time: start},
+					End:   &metav1.Time{Time: end},
+				},
+			},
+		}
+	}
+
+	Context("Test Clone OpsRequest", func() {
+		It("selects the backups by the restore time", func() {
+			older := newBackup("full-older", dpv1alpha1.BackupTypeFull, dpv1alpha1.BackupPhaseCompleted, now.Add(-5*time.Hour), now.Add(-4*time.Hour))
+			latest := newBackup("full-latest", dpv1alpha1.BackupTypeFull, dpv1alpha1.BackupPhaseCompleted, now.Add(-2*time.Hour), now.Add(-time.Hour))
+			failed := newBackup("full-failed", dpv1alpha1.BackupTypeFull, dpv1alpha1.BackupPhaseFailed, now.Add(-time.Hour), now)
+			continuous := newBackup("continuous", dpv1alpha1.BackupTypeContinuous, dpv1alpha1.BackupPhaseRunning, now.Add(-6*time.Hour), now)
+			backups := []*dpv1alpha1.Backup{older, latest, failed, continuous}
+
+			By("expect the latest completed full backup to be selected")
+			Expect(cloneOpsHandler{}.latestFullBackup(backups, nil)).Should(Equal(latest))
+
+			By("expect the continuous backup to be selected if there is a full backup before the restore time")
+			Expect(cloneOpsHandler{}.continuousBackupForTime(backups, now.Add(-3*time.Hour))).Should(Equal(continuous))
+			Expect(cloneOpsHandler{}.continuousBackupForTime(backups, now.Add(-4*time.Hour-30*time.Minute))).Should(BeNil())
+		})
+
+		It("builds the new cluster with the overrides and the source passwords", func() {
+			backup := newBackup("full", dpv1alpha1.BackupTypeFull, dpv1alpha1.BackupPhaseCompleted, now.Add(-time.Hour), now)
+			ops := &appsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "clone-ops", Namespace: "dev"},
+				Spec: appsv1alpha1.OpsRequestSpec{
+					ClusterRef: "dev-cluster",
+					Type:       appsv1alpha1.CloneType,
+					CloneSpec: &appsv1alpha1.CloneSpec{
+						SourceClusterName:      sourceName,
+						SourceClusterNamespace: "prod",
+						ComponentOverrides: []appsv1alpha1.CloneComponentOverride{
+							{
+								ComponentOps:     appsv1alpha1.ComponentOps{ComponentName: compName},
+								Replicas:         pointer.Int32(1),
+								StorageClassName: pointer.String("standard"),
+							},
+						},
+					},
+				},
+			}
+			cluster, err := cloneOpsHandler{}.buildCloneCluster(ops, []*dpv1alpha1.Backup{backup})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cluster.Name).Should(Equal("dev-cluster"))
+			Expect(cluster.Namespace).Should(Equal("dev"))
+			Expect(cluster.Spec.ComponentSpecs[0].Replicas).Should(BeEquivalentTo(1))
+			Expect(*cluster.Spec.ComponentSpecs[0].VolumeClaimTemplates[0].Spec.StorageClassName).Should(Equal("standard"))
+
+			restoreInfo := map[string]map[string]string{}
+			Expect(json.Unmarshal([]byte(cluster.Annotations[constant.RestoreFromBackupAnnotationKey]), &restoreInfo)).Should(Succeed())
+			Expect(restoreInfo[compName][constant.BackupNameKeyForRestore]).Should(Equal(backup.Name))
+			Expect(restoreInfo[compName][constant.BackupNamespaceKeyForRestore]).Should(Equal("prod"))
+			Expect(restoreInfo[compName][constant.ConnectionPassword]).Should(Equal("encrypted"))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// handleDependencies keeps the OpsRequest in the Pending phase until all the OpsRequests it depends on succeed.
+// it returns a non-nil result if the OpsRequest should wait or has been completed.
+func handleDependencies(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*ctrl.Result, error) {
+	opsRequest := opsRes.OpsRequest
+	if len(opsRequest.Spec.DependsOn) == 0 {
+		return nil, nil
+	}
+	var pendingDependencies []string
+	for _, name := range opsRequest.Spec.DependsOn {
+		dependency := &appsv1alpha1.OpsRequest{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: name, Namespace: opsRequest.Namespace}, dependency); err != nil {
+			if apierrors.IsNotFound(err) {
+				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes,
+					fmt.Sprintf(`the dependent OpsRequest "%s" is not found`, name))
+			}
+			return nil, err
+		}
+		switch dependency.Status.Phase {
+		case appsv1alpha1.OpsSucceedPhase:
+			continue
+		case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase,
+				appsv1alpha1.NewDependencyNotSucceedCondition(opsRequest, name, dependency.Status.Phase))
+		default:
+			pendingDependencies = append(pendingDependencies, name)
+		}
+	}
+	if len(pendingDependencies) == 0 {
+		return nil, nil
+	}
+	hasCycle, err := hasDependencyCycle(reqCtx.Ctx, cli, opsRequest)
+	if err != nil {
+		return nil, err
+	}
+	if hasCycle {
+		return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes,
+			fmt.Sprintf(`the dependencies of OpsRequest "%s" form a cycle`, opsRequest.Name))
+	}
+	condition := appsv1alpha1.NewWaitForDependenciesCondition(opsRequest, pendingDependencies)
+	oldCondition := meta.FindStatusCondition(opsRequest.Status.Conditions, condition.Type)
+	if oldCondition == nil || oldCondition.Message != condition.Message {
+		if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase, condition); err != nil {
+			return nil, err
+		}
+	}
+	// the OpsRequest will be enqueued again when the status of the dependencies changes.
+	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+}
+
+// hasDependencyCycle checks if the OpsRequest can be reached again by walking through its dependencies.
+func hasDependencyCycle(ctx context.Context, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (bool, error) {
+	visited := map[string]bool{}
+	stack := append([]string{}, opsRequest.Spec.DependsOn...)
+	for len(stack) > 0 {
+		name := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if name == opsRequest.Name {
+			return true, nil
+		}
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+		dependency := &appsv1alpha1.OpsRequest{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: name, Namespace: opsRequest.Namespace}, dependency); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		stack = append(stack, dependency.Spec.DependsOn...)
+	}
+	return false, nil
+}
@@ -0,0 +1,392 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const (
+	failoverFencingMessage   = "Waiting for the old primary to be fenced"
+	failoverFencedMessage    = "The old primary has been fenced"
+	failoverPromotingMessage = "Waiting for the candidate to be promoted"
+)
+
+type failoverOpsHandler struct{}
+
+var _ OpsHandler = failoverOpsHandler{}
+
+func init() {
+	failoverBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        failoverOpsHandler{},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.FailoverType, failoverBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the failover request.
+func (r failoverOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewFailoverCondition(opsRes.OpsRequest), nil
+}
+
+// Action selects the candidate of each component and records it in the status.
+// the old primary is fenced and the candidate is promoted after the fencing is confirmed in ReconcileAction.
+func (r failoverOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, failover := range opsRequest.Spec.FailoverList {
+		compSpec := opsRes.Cluster.Spec.GetComponentByName(failover.ComponentName)
+		synthesizedComp, err := component.BuildSynthesizedComponentWrapper(reqCtx, cli, opsRes.Cluster, compSpec)
+		if err != nil {
+			return err
+		}
+		oldPrimary, err := getServiceableNWritablePod(reqCtx.Ctx, cli, *opsRes.Cluster, *synthesizedComp)
+		if err != nil {
+			return intctrlutil.NewFatalError(err.Error())
+		}
+		candidate, lag, err := r.selectCandidate(reqCtx, cli, opsRes, synthesizedComp, failover, oldPrimary)
+		if err != nil {
+			return err
+		}
+		opsRequest.Status.Components[failover.ComponentName] = appsv1alpha1.OpsRequestComponentStatus{
+			Phase: appsv1alpha1.UpdatingClusterCompPhase,
+			Failover: &appsv1alpha1.FailoverStatus{
+				OldPrimary:        oldPrimary.Name,
+				NewPrimary:        candidate.Name,
+				ReplicationLag:    lag,
+				PotentialDataLoss: lag == nil || *lag > 0,
+			},
+		}
+	}
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for failover opsRequest.
+func (r failoverOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+		expectCount     int
+		completedCount  int
+		failedCount     int
+	)
+	for _, failover := range opsRes.OpsRequest.Spec.FailoverList {
+		expectCount += 1
+		compStatus := opsRes.OpsRequest.Status.Components[failover.ComponentName]
+		if compStatus.Failover == nil {
+			return opsRequestPhase, 0, intctrlutil.NewFatalError(fmt.Sprintf(`the failover status of component "%s" is missing`, failover.ComponentName))
+		}
+		progressDetail := r.getProgressDetail(compStatus)
+		if isCompletedProgressStatus(progressDetail.Status) {
+			completedCount += 1
+			if progressDetail.Status == appsv1alpha1.FailedProgressStatus {
+				failedCount += 1
+			}
+			continue
+		}
+		completed, err := r.failoverComponent(reqCtx, cli, opsRes, failover, compStatus.Failover, &progressDetail)
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, err.Error())
+			completedCount += 1
+			failedCount += 1
+		} else if err != nil {
+			return opsRequestPhase, 0, err
+		} else if completed {
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+				fmt.Sprintf("Promote %s as the new primary successfully", compStatus.Failover.NewPrimary))
+			compStatus.Phase = appsv1alpha1.RunningClusterCompPhase
+			completedCount += 1
+		}
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+		opsRes.OpsRequest.Status.Components[failover.ComponentName] = compStatus
+	}
+	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if completedCount != expectCount {
+		return opsRequestPhase, time.Second, nil
+	}
+	if failedCount > 0 {
+		return appsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	return appsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration this operation does not change the Cluster.spec except the offline instances.
+// empty implementation here.
+func (r failoverOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// selectCandidate selects the candidate to promote, it is the specified instance or the healthy instance with the least replication lag.
+func (r failoverOpsHandler) selectCandidate(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	synthesizedComp *component.SynthesizedComponent,
+	failover appsv1alpha1.Failover,
+	oldPrimary *corev1.Pod) (*corev1.Pod, *int64, error) {
+	if failover.InstanceName != "" {
+		// reuse the checks of switchover to validate the specified candidate.
+		needSwitchover, err := needDoSwitchover(reqCtx.Ctx, cli, opsRes.Cluster, synthesizedComp, &appsv1alpha1.Switchover{
+			ComponentOps: failover.ComponentOps,
+			InstanceName: failover.InstanceName,
+		})
+		if err != nil {
+			return nil, nil, intctrlutil.NewFatalError(err.Error())
+		}
+		if !needSwitchover {
+			return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf(`instance "%s" is already the primary`, failover.InstanceName))
+		}
+		candidate := &corev1.Pod{}
+		if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: failover.InstanceName, Namespace: opsRes.Cluster.Namespace}, candidate); err != nil {
+			return nil, nil, err
+		}
+		if !r.isHealthyCandidate(candidate) {
+			return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf(`instance "%s" is not healthy, can not promote it`, failover.InstanceName))
+		}
+		return candidate, getReplicationLag(reqCtx, candidate), nil
+	}
+	podList, err := component.GetComponentPodList(reqCtx.Ctx, cli, *opsRes.Cluster, synthesizedComp.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	its, err := getComponentInstanceSet(reqCtx.Ctx, cli, opsRes.Cluster, synthesizedComp.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		candidate    *corev1.Pod
+		candidateLag *int64
+	)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Name == oldPrimary.Name || !r.isHealthyCandidate(pod) {
+			continue
+		}
+		// the instances held for maintenance can be excluded from the candidates.
+		if its != nil && !instanceset.IsLeaderCandidate(its, pod) {
+			continue
+		}
+		lag := getReplicationLag(reqCtx, pod)
+		// prefer the instance whose lag is known and smaller.
+		if candidate == nil || (lag != nil && (candidateLag == nil || *lag < *candidateLag)) {
+			candidate, candidateLag = pod, lag
+		}
+	}
+	if candidate == nil {
+		return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf(`no healthy candidate found in component "%s"`, synthesizedComp.Name))
+	}
+	return candidate, candidateLag, nil
+}
+
+// isHealthyCandidate checks if the pod can be promoted.
+func (r failoverOpsHandler) isHealthyCandidate(pod *corev1.Pod) bool {
+	if !pod.DeletionTimestamp.IsZero() || !podutils.IsPodReady(pod) {
+		return false
+	}
+	_, ok := pod.Labels[constant.RoleLabelKey]
+	return ok
+}
+
+// getReplicationLag gets the replication lag of the instance through lorry, returns nil if the lag is unknown.
+func getReplicationLag(reqCtx intctrlutil.RequestCtx, pod *corev1.Pod) *int64 {
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil || intctrlutil.IsNil(lorryCli) {
+		return nil
+	}
+	lag, err := lorryCli.GetLag(reqCtx.Ctx)
+	if err != nil {
+		reqCtx.Log.Info("failed to get the replication lag", "pod", pod.Name, "error", err.Error())
+		return nil
+	}
+	return &lag
+}
+
+func (r failoverOpsHandler) getProgressDetail(compStatus appsv1alpha1.OpsRequestComponentStatus) appsv1alpha1.ProgressStatusDetail {
+	objectKey := getProgressObjectKey(constant.PodKind, compStatus.Failover.NewPrimary)
+	progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+	if progressDetail != nil {
+		return *progressDetail
+	}
+	return appsv1alpha1.ProgressStatusDetail{
+		ObjectKey: objectKey,
+		Status:    appsv1alpha1.ProcessingProgressStatus,
+		Message:   fmt.Sprintf("Start to fence the old primary %s", compStatus.Failover.OldPrimary),
+	}
+}
+
+// failoverComponent fences the old primary, promotes the candidate after the old primary is confirmed to be fenced,
+// and waits for it to be the new primary.
+func (r failoverOpsHandler) failoverComponent(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	failover appsv1alpha1.Failover,
+	failoverStatus *appsv1alpha1.FailoverStatus,
+	progressDetail *appsv1alpha1.ProgressStatusDetail) (bool, error) {
+	candidate := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: failoverStatus.NewPrimary, Namespace: opsRes.Cluster.Namespace}, candidate); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	// 1. fence the old primary.
+	oldPrimary, err := r.getOldPrimary(reqCtx, cli, opsRes, failoverStatus.OldPrimary)
+	if err != nil {
+		return false, err
+	}
+	if oldPrimary != nil {
+		// the candidate is not promoted until the old primary is gone, otherwise both of them may serve the writes.
+		progressDetail.Message = failoverFencingMessage
+		return false, r.fenceOldPrimary(reqCtx, cli, opsRes, failover, oldPrimary)
+	}
+	// 2. promote the candidate.
+	if progressDetail.Message != failoverPromotingMessage {
+		progressDetail.Message = failoverFencedMessage
+		if err = r.promoteCandidate(reqCtx, candidate); err != nil {
+			return false, err
+		}
+		progressDetail.Message = failoverPromotingMessage
+		return false, nil
+	}
+	// 3. wait for the candidate to be the new primary.
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(failover.ComponentName)
+	synthesizedComp, err := component.BuildSynthesizedComponentWrapper(reqCtx, cli, opsRes.Cluster, compSpec)
+	if err != nil {
+		return false, err
+	}
+	roleName := candidate.Labels[constant.RoleLabelKey]
+	for _, role := range synthesizedComp.Roles {
+		if role.Name == roleName && role.Serviceable && role.Writable {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getOldPrimary gets the pod of the old primary, it returns nil if the old primary is gone,
+// the instance recreated after the failover started is not the old primary.
+func (r failoverOpsHandler) getOldPrimary(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	oldPrimary string) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: oldPrimary, Namespace: opsRes.Cluster.Namespace}, pod); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	startTimestamp := opsRes.OpsRequest.Status.StartTimestamp
+	if !startTimestamp.IsZero() && pod.CreationTimestamp.After(startTimestamp.Time) {
+		return nil, nil
+	}
+	return pod, nil
+}
+
+// fenceOldPrimary fences the old primary according to the fencing policy.
+func (r failoverOpsHandler) fenceOldPrimary(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	failover appsv1alpha1.Failover,
+	oldPrimary *corev1.Pod) error {
+	if failover.FencingPolicy == appsv1alpha1.FailoverFencingCordon {
+		return r.deleteOldPrimary(reqCtx, cli, oldPrimary)
+	}
+	// take the old primary offline, the InstanceSet will delete it and create a new instance to replace it.
+	for i := range opsRes.Cluster.Spec.ComponentSpecs {
+		compSpec := &opsRes.Cluster.Spec.ComponentSpecs[i]
+		if compSpec.Name != failover.ComponentName {
+			continue
+		}
+		if !slices.Contains(compSpec.OfflineInstances, oldPrimary.Name) {
+			compSpec.OfflineInstances = append(compSpec.OfflineInstances, oldPrimary.Name)
+			return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+		}
+	}
+	// the deletion issued by the InstanceSet never completes if the kubelet is gone.
+	if oldPrimary.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	return r.deleteOldPrimary(reqCtx, cli, oldPrimary)
+}
+
+// deleteOldPrimary deletes the old primary so that it stops serving, the InstanceSet recreates the instance
+// which joins as a secondary. It is deleted forcibly if its node is not ready, as the kubelet can not confirm the deletion.
+func (r failoverOpsHandler) deleteOldPrimary(reqCtx intctrlutil.RequestCtx, cli client.Client, oldPrimary *corev1.Pod) error {
+	nodeReady, err := isPodNodeReady(reqCtx, cli, oldPrimary)
+	if err != nil {
+		return err
+	}
+	if nodeReady {
+		if !oldPrimary.DeletionTimestamp.IsZero() {
+			return nil
+		}
+		return client.IgnoreNotFound(cli.Delete(reqCtx.Ctx, oldPrimary))
+	}
+	return client.IgnoreNotFound(cli.Delete(reqCtx.Ctx, oldPrimary, client.GracePeriodSeconds(0)))
+}
+
+// isPodNodeReady checks if the node of the pod is ready, the pod not bound to a node is regarded as ready.
+func isPodNodeReady(reqCtx intctrlutil.RequestCtx, cli client.Client, pod *corev1.Pod) (bool, error) {
+	if pod.Spec.NodeName == "" {
+		return true, nil
+	}
+	node := &corev1.Node{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
+// promoteCandidate promotes the candidate forcibly through the lorry on it, the old primary is not involved.
+func (r failoverOpsHandler) promoteCandidate(reqCtx intctrlutil.RequestCtx, candidate *corev1.Pod) error {
+	lorryCli, err := lorry.NewClient(*candidate)
+	if err != nil {
+		return err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`lorry is not available on the candidate "%s"`, candidate.Name))
+	}
+	err = lorryCli.Switchover(reqCtx.Ctx, "", candidate.Name, true)
+	if err == lorry.NotImplemented {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the candidate "%s" does not support to be promoted`, candidate.Name))
+	}
+	return err
+}
@@ -0,0 +1,222 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+)
+
+var _ = Describe("Failover OpsRequest", func() {
+
+	var (
+		randomStr             = testCtx.GetRandomStr()
+		clusterDefinitionName = "cluster-definition-for-ops-" + randomStr
+		clusterVersionName    = "clusterversion-for-ops-" + randomStr
+		clusterName           = "cluster-for-ops-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		// default GracePeriod is 30s
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		lorry.UnsetMockClient()
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	mockLorryClient := func(lag int64) {
+		mockCli := lorry.NewMockClient(gomock.NewController(GinkgoT()))
+		mockCli.EXPECT().GetLag(gomock.Any()).Return(lag, nil).AnyTimes()
+		mockCli.EXPECT().Switchover(gomock.Any(), "", gomock.Any(), true).Return(nil).AnyTimes()
+		lorry.SetMockClient(mockCli, nil)
+	}
+
+	Context("Test Failover opsRequest", func() {
+		It("fences the old primary and promotes the healthiest follower", func() {
+			By("init operations resources")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			podList := initConsensusPods(ctx, k8sClient, opsRes, clusterName)
+			var oldPrimary string
+			for i := range podList {
+				pod := &podList[i]
+				if pod.Labels[constant.RoleLabelKey] == "leader" {
+					oldPrimary = pod.Name
+				}
+				Expect(testapps.ChangeObjStatus(&testCtx, pod, func() {
+					testk8s.MockPodAvailable(pod, metav1.NewTime(time.Now()))
+				})).Should(Succeed())
+			}
+			mockLorryClient(0)
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("create Failover opsRequest")
+			ops := testapps.NewOpsRequestObj("failover-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.FailoverType)
+			ops.Spec.FailoverList = []appsv1alpha1.Failover{
+				{
+					ComponentOps:  appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					FencingPolicy: appsv1alpha1.FailoverFencingOffline,
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsCreatingPhase
+
+			By("expect a follower to be selected as the candidate")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			failoverStatus := opsRes.OpsRequest.Status.Components[consensusComp].Failover
+			Expect(failoverStatus).ShouldNot(BeNil())
+			Expect(failoverStatus.OldPrimary).Should(Equal(oldPrimary))
+			Expect(failoverStatus.NewPrimary).ShouldNot(Equal(oldPrimary))
+			Expect(failoverStatus.PotentialDataLoss).Should(BeFalse())
+
+			By("expect the old primary to be taken offline and the candidate not to be promoted before it is gone")
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.GetComponentByName(consensusComp).OfflineInstances).Should(ContainElement(oldPrimary))
+			})).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), opsRes.Cluster)).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			progressDetail := opsRes.OpsRequest.Status.Components[consensusComp].ProgressDetails[0]
+			Expect(progressDetail.Message).Should(Equal(failoverFencingMessage))
+
+			By("mock the old primary to be deleted by the InstanceSet, expect the candidate to be promoted")
+			testapps.DeleteObject(&testCtx, client.ObjectKey{Name: oldPrimary, Namespace: testCtx.DefaultNamespace}, &corev1.Pod{})
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKey{Name: oldPrimary, Namespace: testCtx.DefaultNamespace}, &corev1.Pod{}, false)).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			progressDetail = opsRes.OpsRequest.Status.Components[consensusComp].ProgressDetails[0]
+			Expect(progressDetail.Message).Should(Equal(failoverPromotingMessage))
+
+			By("mock the candidate to be the new primary, expect the opsRequest to succeed")
+			candidate := &corev1.Pod{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: failoverStatus.NewPrimary, Namespace: testCtx.DefaultNamespace}, candidate)).Should(Succeed())
+			Expect(testapps.ChangeObj(&testCtx, candidate, func(pod *corev1.Pod) {
+				pod.Labels[constant.RoleLabelKey] = "leader"
+			})).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), opsRes.Cluster)).Should(Succeed())
+			phase, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+		})
+
+		It("deletes the old primary forcibly if its node is not ready without cordoning the node", func() {
+			By("init operations resources")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			podList := initConsensusPods(ctx, k8sClient, opsRes, clusterName)
+			for i := range podList {
+				pod := &podList[i]
+				Expect(testapps.ChangeObjStatus(&testCtx, pod, func() {
+					testk8s.MockPodAvailable(pod, metav1.NewTime(time.Now()))
+				})).Should(Succeed())
+			}
+			mockLorryClient(0)
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("recreate the old primary on a node which is not ready")
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "failover-node-" + randomStr}}
+			Expect(k8sClient.Create(ctx, node)).Should(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, node))).Should(Succeed())
+			})
+			var oldPrimary *corev1.Pod
+			for i := range podList {
+				if podList[i].Labels[constant.RoleLabelKey] == "leader" {
+					oldPrimary = podList[i].DeepCopy()
+				}
+			}
+			Expect(oldPrimary).ShouldNot(BeNil())
+			testapps.DeleteObject(&testCtx, client.ObjectKeyFromObject(oldPrimary), &corev1.Pod{})
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(oldPrimary), &corev1.Pod{}, false)).Should(Succeed())
+			oldPrimary.ResourceVersion = ""
+			oldPrimary.UID = ""
+			oldPrimary.Finalizers = nil
+			oldPrimary.Spec.NodeName = node.Name
+			Expect(k8sClient.Create(ctx, oldPrimary)).Should(Succeed())
+
+			By("create Failover opsRequest with the Cordon fencing policy")
+			ops := testapps.NewOpsRequestObj("failover-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.FailoverType)
+			ops.Spec.FailoverList = []appsv1alpha1.Failover{
+				{
+					ComponentOps:  appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					FencingPolicy: appsv1alpha1.FailoverFencingCordon,
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsCreatingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Components[consensusComp].Failover.OldPrimary).Should(Equal(oldPrimary.Name))
+
+			By("expect the old primary to be deleted forcibly and the node not to be cordoned")
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			opsRes.OpsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(time.Minute))
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(oldPrimary), &corev1.Pod{}, false)).Should(Succeed())
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(node), func(g Gomega, fetched *corev1.Node) {
+				g.Expect(fetched.Spec.Unschedulable).Should(BeFalse())
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.GetComponentByName(consensusComp).OfflineInstances).ShouldNot(ContainElement(oldPrimary.Name))
+			})).Should(Succeed())
+
+			By("expect the candidate to be promoted after the old primary is gone")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			progressDetail := opsRes.OpsRequest.Status.Components[consensusComp].ProgressDetails[0]
+			Expect(progressDetail.Message).Should(Equal(failoverPromotingMessage))
+		})
+	})
+})
@@ -43,6 +43,7 @@ func init() {
 		QueueByCluster:    true,
 		OpsHandler:        hsHandler,
 		CancelFunc:        hsHandler.Cancel,
+		SupportDryRun:     true,
 	}
 	opsMgr := GetOpsManager()
 	opsMgr.RegisterOps(appsv1alpha1.HorizontalScalingType, horizontalScalingBehaviour)
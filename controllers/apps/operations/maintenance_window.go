@@ -0,0 +1,145 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"golang.org/x/exp/slices"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// getMaintenanceWindow gets the maintenance window which the OpsRequest follows.
+// the window of the OpsRequest takes precedence over the window of the cluster.
+func getMaintenanceWindow(opsRes *OpsResource) *appsv1alpha1.MaintenanceWindow {
+	if opsRes.OpsRequest.Spec.MaintenanceWindow != nil {
+		return opsRes.OpsRequest.Spec.MaintenanceWindow
+	}
+	if opsRes.Cluster != nil {
+		return opsRes.Cluster.Spec.MaintenanceWindow
+	}
+	return nil
+}
+
+// needWaitForMaintenanceWindow checks if the OpsRequest can only be started within the maintenance window.
+func needWaitForMaintenanceWindow(opsRes *OpsResource, opsBehaviour OpsBehaviour) bool {
+	if !opsBehaviour.RespectMaintenanceWindow || opsRes.OpsRequest.Force() {
+		return false
+	}
+	if getMaintenanceWindow(opsRes) == nil {
+		return false
+	}
+	if opsRes.OpsRequest.Spec.Type == appsv1alpha1.ReconfiguringType {
+		return reconfigureMayRestart(opsRes.OpsRequest)
+	}
+	return true
+}
+
+// reconfigureMayRestart checks if the reconfiguring may restart the pods.
+// only the configurations explicitly updated by dynamic reloading will not restart the pods.
+func reconfigureMayRestart(opsRequest *appsv1alpha1.OpsRequest) bool {
+	var configurations []appsv1alpha1.ConfigurationItem
+	if opsRequest.Spec.Reconfigure != nil {
+		configurations = append(configurations, opsRequest.Spec.Reconfigure.Configurations...)
+	}
+	for _, v := range opsRequest.Spec.Reconfigures {
+		configurations = append(configurations, v.Configurations...)
+	}
+	dynamicPolicies := []appsv1alpha1.UpgradePolicy{appsv1alpha1.AsyncDynamicReloadPolicy, appsv1alpha1.SyncDynamicReloadPolicy}
+	for _, v := range configurations {
+		if v.Policy == nil || !slices.Contains(dynamicPolicies, *v.Policy) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextMaintenanceWindow returns the maintenance window which contains the time, or the next window if the time is not within any window.
+func nextMaintenanceWindow(window appsv1alpha1.MaintenanceWindow, now time.Time) (time.Time, time.Time, error) {
+	if err := window.Validate(); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	schedule, _ := cron.ParseStandard(window.Schedule)
+	location, _ := time.LoadLocation(window.TimeZone)
+	now = now.In(location)
+	// the first start time after (now - duration) is the start time of the current window if it is not after now.
+	start := schedule.Next(now.Add(-window.Duration.Duration))
+	if start.After(now) {
+		start = schedule.Next(now)
+	}
+	return start, start.Add(window.Duration.Duration), nil
+}
+
+// checkMaintenanceWindow checks if the OpsRequest can be started now.
+// it returns the duration to wait for the next maintenance window. if the duration is zero, the OpsRequest can be started.
+func checkMaintenanceWindow(opsRes *OpsResource, now time.Time) (time.Duration, time.Time, error) {
+	window := getMaintenanceWindow(opsRes)
+	var expectedDuration time.Duration
+	if opsRes.OpsRequest.Spec.ExpectedDurationSeconds != nil {
+		expectedDuration = time.Duration(*opsRes.OpsRequest.Spec.ExpectedDurationSeconds) * time.Second
+	}
+	if expectedDuration > window.Duration.Duration {
+		return 0, time.Time{}, fmt.Errorf("the expected duration %s of the OpsRequest exceeds the maintenance window duration %s",
+			expectedDuration, window.Duration.Duration)
+	}
+	start, end, err := nextMaintenanceWindow(*window, now)
+	if err != nil {
+		return 0, start, err
+	}
+	if start.After(now) {
+		return start.Sub(now), start, nil
+	}
+	if now.Add(expectedDuration).After(end) {
+		// the rest of the current window can not cover the operation, hold it back until the next window.
+		if start, _, err = nextMaintenanceWindow(*window, end); err != nil {
+			return 0, start, err
+		}
+		return start.Sub(now), start, nil
+	}
+	return 0, start, nil
+}
+
+// handleMaintenanceWindow keeps the OpsRequest in the Scheduled phase until the maintenance window opens.
+// it returns a non-nil result if the OpsRequest should wait.
+func handleMaintenanceWindow(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) (*ctrl.Result, error) {
+	if !needWaitForMaintenanceWindow(opsRes, opsBehaviour) {
+		return nil, nil
+	}
+	waitDuration, nextStart, err := checkMaintenanceWindow(opsRes, time.Now())
+	if err != nil {
+		return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+	}
+	if waitDuration == 0 {
+		return nil, nil
+	}
+	if opsRes.OpsRequest.Status.Phase != appsv1alpha1.OpsScheduledPhase {
+		if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsScheduledPhase,
+			appsv1alpha1.NewScheduledCondition(opsRes.OpsRequest, nextStart)); err != nil {
+			return nil, err
+		}
+	}
+	return intctrlutil.ResultToP(intctrlutil.RequeueAfter(waitDuration, reqCtx.Log, "wait for the maintenance window"))
+}
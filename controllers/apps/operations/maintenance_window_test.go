@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("Maintenance Window", func() {
+
+	var (
+		randomStr             = testCtx.GetRandomStr()
+		clusterDefinitionName = "cluster-definition-for-ops-" + randomStr
+		clusterVersionName    = "clusterversion-for-ops-" + randomStr
+		clusterName           = "cluster-for-ops-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("Test maintenance window calculation", func() {
+		// a window opens at 02:00 every Saturday and stays open for 2 hours.
+		window := appsv1alpha1.MaintenanceWindow{
+			Schedule: "0 2 * * 6",
+			Duration: metav1.Duration{Duration: 2 * time.Hour},
+			TimeZone: "Asia/Shanghai",
+		}
+		location, _ := time.LoadLocation(window.TimeZone)
+
+		It("returns the current window when the time is within the window", func() {
+			now := time.Date(2024, 6, 1, 3, 0, 0, 0, location)
+			start, end, err := nextMaintenanceWindow(window, now)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(start.Equal(time.Date(2024, 6, 1, 2, 0, 0, 0, location))).Should(BeTrue())
+			Expect(end.Equal(time.Date(2024, 6, 1, 4, 0, 0, 0, location))).Should(BeTrue())
+		})
+
+		It("returns the next window when the time is out of the window", func() {
+			now := time.Date(2024, 6, 1, 5, 0, 0, 0, location)
+			start, _, err := nextMaintenanceWindow(window, now)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(start.Equal(time.Date(2024, 6, 8, 2, 0, 0, 0, location))).Should(BeTrue())
+		})
+
+		It("holds back the OpsRequest which can not finish before the window closes", func() {
+			opsRes := &OpsResource{OpsRequest: &appsv1alpha1.OpsRequest{}}
+			opsRes.OpsRequest.Spec.MaintenanceWindow = &window
+			now := time.Date(2024, 6, 1, 3, 0, 0, 0, location)
+
+			By("expect to start now if the rest of the window is enough")
+			opsRes.OpsRequest.Spec.ExpectedDurationSeconds = pointer.Int32(1800)
+			waitDuration, _, err := checkMaintenanceWindow(opsRes, now)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(waitDuration).Should(BeZero())
+
+			By("expect to wait for the next window if the rest of the window is not enough")
+			opsRes.OpsRequest.Spec.ExpectedDurationSeconds = pointer.Int32(5400)
+			waitDuration, nextStart, err := checkMaintenanceWindow(opsRes, now)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(nextStart.Equal(time.Date(2024, 6, 8, 2, 0, 0, 0, location))).Should(BeTrue())
+			Expect(waitDuration).Should(Equal(nextStart.Sub(now)))
+
+			By("expect an error if the expected duration exceeds the window duration")
+			opsRes.OpsRequest.Spec.ExpectedDurationSeconds = pointer.Int32(3 * 3600)
+			_, _, err = checkMaintenanceWindow(opsRes, now)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("only the reconfiguring with restart respects the maintenance window", func() {
+			ops := &appsv1alpha1.OpsRequest{}
+			ops.Spec.Type = appsv1alpha1.ReconfiguringType
+			policy := appsv1alpha1.AsyncDynamicReloadPolicy
+			ops.Spec.Reconfigures = []appsv1alpha1.Reconfigure{{
+				Configurations: []appsv1alpha1.ConfigurationItem{{Name: "mysql-config", Policy: &policy}},
+			}}
+			Expect(reconfigureMayRestart(ops)).Should(BeFalse())
+			ops.Spec.Reconfigures[0].Configurations[0].Policy = nil
+			Expect(reconfigureMayRestart(ops)).Should(BeTrue())
+		})
+	})
+
+	Context("Test OpsRequest with maintenance window", func() {
+		var (
+			opsRes *OpsResource
+			reqCtx intctrlutil.RequestCtx
+		)
+		BeforeEach(func() {
+			By("init operations resources ")
+			opsRes, _, _ = initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+		})
+
+		It("keeps the Restart OpsRequest in Scheduled phase until the window opens", func() {
+			By("declare a maintenance window which opens half a year later")
+			windowStart := time.Now().UTC().AddDate(0, 6, 0)
+			opsRes.Cluster.Spec.MaintenanceWindow = &appsv1alpha1.MaintenanceWindow{
+				Schedule: windowStart.Format("4 15 2 1 *"),
+				Duration: metav1.Duration{Duration: time.Hour},
+			}
+
+			By("create Restart opsRequest")
+			opsRes.OpsRequest = createRestartOpsObj(clusterName, "restart-ops-"+randomStr)
+			res, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).ShouldNot(BeNil())
+			Expect(res.RequeueAfter).Should(BeNumerically(">", 0))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Phase).To(Equal(appsv1alpha1.OpsScheduledPhase))
+					condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeScheduled)
+					g.Expect(condition).ShouldNot(BeNil())
+					g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonWaitForMaintenanceWindow))
+				})).Should(Succeed())
+		})
+	})
+})
@@ -20,9 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
+	"golang.org/x/exp/slices"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -69,10 +71,22 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		}
 	}
 
-	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase {
+	if slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsScheduledPhase}, opsRequest.Status.Phase) {
 		if opsRequest.Spec.Cancel {
 			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase)
 		}
+		// wait for the OpsRequests which this OpsRequest depends on to succeed.
+		if res, err := handleDependencies(reqCtx, cli, opsRes); res != nil || err != nil {
+			return res, err
+		}
+		// wait for the approval if the OpsRequest type needs approval in the namespace.
+		if res, err := handleApproval(reqCtx, cli, opsRes); res != nil || err != nil {
+			return res, err
+		}
+		// keep the OpsRequest in Scheduled phase until the maintenance window opens.
+		if res, err := handleMaintenanceWindow(reqCtx, cli, opsRes, opsBehaviour); res != nil || err != nil {
+			return res, err
+		}
 		// validate entry condition for OpsRequest, check if the cluster is in the right phase
 		if err = validateOpsWaitingPhase(opsRes.Cluster, opsRequest, opsBehaviour); err != nil {
 			// check if the error is caused by WaitForClusterPhaseErr  error
@@ -116,6 +130,52 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 	return nil, nil
 }
 
+// DryRun validates the OpsRequest and performs its Action with a client which records the changes instead of applying them.
+// It returns the messages of the failed pre-checks, the Action is not performed if any pre-check fails.
+// The dependencies, the maintenance window and the queue are not considered in dry-run mode.
+func (opsMgr *OpsManager) DryRun(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) ([]string, error) {
+	opsRequest := opsRes.OpsRequest
+	opsBehaviour, ok := opsMgr.OpsMap[opsRequest.Spec.Type]
+	if !ok || opsBehaviour.OpsHandler == nil || !opsBehaviour.SupportDryRun {
+		return []string{fmt.Sprintf("OpsRequest type %s does not support dry-run", opsRequest.Spec.Type)}, nil
+	}
+
+	var preCheckFailures []string
+	if err := opsRequest.Validate(reqCtx.Ctx, cli, opsRes.Cluster, !opsBehaviour.IsClusterCreation); err != nil {
+		preCheckFailures = append(preCheckFailures, err.Error())
+	}
+	if !opsRequest.Force() && len(opsBehaviour.FromClusterPhases) > 0 &&
+		!slices.Contains(opsBehaviour.FromClusterPhases, opsRes.Cluster.Status.Phase) &&
+		!(opsRequest.IsRebalance() && opsRes.Cluster.Status.Phase == appsv1alpha1.RunningClusterPhase) {
+		preCheckFailures = append(preCheckFailures, (&WaitForClusterPhaseErr{
+			clusterName:   opsRes.Cluster.Name,
+			currentPhase:  opsRes.Cluster.Status.Phase,
+			expectedPhase: opsBehaviour.FromClusterPhases,
+		}).Error())
+	}
+	if len(preCheckFailures) > 0 {
+		return preCheckFailures, nil
+	}
+
+	// the Action modifies the OpsResource in place, which should not be seen by the caller in dry-run mode.
+	dryRunRes := &OpsResource{
+		OpsDef:     opsRes.OpsDef,
+		OpsRequest: opsRequest.DeepCopy(),
+		Cluster:    opsRes.Cluster.DeepCopy(),
+		Recorder:   reqCtx.Recorder,
+	}
+	var err error
+	if dryRunHandler, ok := opsBehaviour.OpsHandler.(OpsDryRunHandler); ok {
+		err = dryRunHandler.DryRunAction(reqCtx, cli, dryRunRes)
+	} else {
+		err = opsBehaviour.OpsHandler.Action(reqCtx, cli, dryRunRes)
+	}
+	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		return []string{err.Error()}, nil
+	}
+	return nil, err
+}
+
 // Reconcile entry function when OpsRequest.status.phase is Running.
 // loops till the operation is completed.
 func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (time.Duration, error) {
@@ -143,6 +203,12 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 		// if the opsRequest phase is not failed, skipped
 		return requeueAfter, err
 	}
+	// the update partitions of the rollout must not outlive the opsRequest, whether it succeeds, fails or is cancelled.
+	if opsRequest.Spec.Rollout != nil && opsRequest.IsComplete(opsRequestPhase) {
+		if rolloutErr := removeRolloutPartitions(reqCtx, cli, opsRes); rolloutErr != nil {
+			return 0, rolloutErr
+		}
+	}
 	switch opsRequestPhase {
 	case appsv1alpha1.OpsSucceedPhase:
 		if opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
@@ -175,7 +175,12 @@ func handleComponentStatusProgress(
 	expectReplicas := clusterComponent.Replicas
 	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		// only rollback the actual re-created pod during cancelling.
-		expectReplicas = int32(len(compStatus.ProgressDetails))
+		expectReplicas = 0
+		for _, v := range compStatus.ProgressDetails {
+			if !isRolloutProgressDetail(v) {
+				expectReplicas += 1
+			}
+		}
 	}
 	return expectReplicas, completedCount, err
 }
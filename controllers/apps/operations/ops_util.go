@@ -36,6 +36,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -385,19 +386,21 @@ func cancelComponentOps(ctx context.Context,
 }
 
 // validateOpsWaitingPhase validates whether the current cluster phase is expected, and whether the waiting time exceeds the limit.
-// only requests with `Pending` phase will be validated.
+// only requests with `Pending` or `Scheduled` phase will be validated.
 func validateOpsWaitingPhase(cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.OpsRequest, opsBehaviour OpsBehaviour) error {
 	if ops.Force() {
 		return nil
 	}
 	// if opsRequest don't need to wait for the cluster phase
-	// or opsRequest status.phase is not Pending,
+	// or opsRequest status.phase is not Pending or Scheduled,
 	// or opsRequest will create cluster,
 	// we don't validate the cluster phase.
-	if len(opsBehaviour.FromClusterPhases) == 0 || ops.Status.Phase != appsv1alpha1.OpsPendingPhase || opsBehaviour.IsClusterCreation {
+	if len(opsBehaviour.FromClusterPhases) == 0 || opsBehaviour.IsClusterCreation ||
+		!slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsScheduledPhase}, ops.Status.Phase) {
 		return nil
 	}
-	if slices.Contains(opsBehaviour.FromClusterPhases, cluster.Status.Phase) {
+	if slices.Contains(opsBehaviour.FromClusterPhases, cluster.Status.Phase) ||
+		(ops.IsRebalance() && cluster.Status.Phase == appsv1alpha1.RunningClusterPhase) {
 		return nil
 	}
 	// check if entry-condition is met
@@ -524,3 +527,23 @@ func syncOverrideByOpsForScaleReplicas(reqCtx intctrlutil.RequestCtx, cli client
 	}
 	return nil
 }
+
+// getComponentInstanceSet returns the InstanceSet of the component, or nil if it is not found.
+func getComponentInstanceSet(ctx context.Context, cli client.Client, cluster *appsv1alpha1.Cluster, compName string) (*workloads.InstanceSet, error) {
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateWorkloadNamePattern(cluster.Name, compName)}
+	if err := cli.Get(ctx, itsKey, its); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return its, nil
+}
+
+// getActiveInstanceName resolves the instance name to the active instance of the component, as the instances are
+// named after the green set of the blue/green update after the switch.
+func getActiveInstanceName(ctx context.Context, cli client.Client, cluster *appsv1alpha1.Cluster, compName, instanceName string) (string, error) {
+	its, err := getComponentInstanceSet(ctx, cli, cluster, compName)
+	if err != nil || its == nil {
+		return instanceName, err
+	}
+	return instanceset.GetActiveInstanceName(its, instanceName), nil
+}
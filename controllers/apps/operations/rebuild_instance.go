@@ -97,6 +97,10 @@ func (r rebuildInstanceOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli cli
 		if !ok {
 			continue
 		}
+		// the rebalance rebuilds a healthy instance of the running component.
+		if opsRes.OpsRequest.IsRebalance() {
+			continue
+		}
 		// check if the component has matched the `Phase` condition
 		if !slices.Contains([]appsv1alpha1.ClusterComponentPhase{appsv1alpha1.FailedClusterCompPhase,
 			appsv1alpha1.AbnormalClusterCompPhase, appsv1alpha1.UpdatingClusterCompPhase}, compStatus.Phase) {
@@ -108,8 +112,12 @@ func (r rebuildInstanceOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli cli
 			return err
 		}
 		for _, ins := range v.Instances {
+			insName, err := getActiveInstanceName(reqCtx.Ctx, cli, opsRes.Cluster, v.ComponentName, ins.Name)
+			if err != nil {
+				return err
+			}
 			targetPod := &corev1.Pod{}
-			if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: ins.Name, Namespace: opsRes.Cluster.Namespace}, targetPod); err != nil {
+			if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: insName, Namespace: opsRes.Cluster.Namespace}, targetPod); err != nil {
 				return err
 			}
 			isAvailable, err := r.instanceIsAvailable(synthesizedComp, targetPod)
@@ -168,7 +176,7 @@ func (r rebuildInstanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx
 				continue
 			}
 			// rebuild instance
-			completed, err := r.rebuildInstance(reqCtx, cli, opsRes, comp, v.EnvForRestore, &progressDetail, instance, v.BackupName, i)
+			completed, err := r.rebuildInstance(reqCtx, cli, opsRes, comp, v, &progressDetail, instance, i)
 			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
 				// If a fatal error occurs, this instance rebuilds failed.
 				progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, err.Error())
@@ -205,19 +213,22 @@ func (r rebuildInstanceOpsHandler) rebuildInstance(reqCtx intctrlutil.RequestCtx
 	cli client.Client,
 	opsRes *OpsResource,
 	comp *appsv1alpha1.ClusterComponentSpec,
-	envForRestore []corev1.EnvVar,
+	rebuildFrom appsv1alpha1.RebuildInstance,
 	progressDetail *appsv1alpha1.ProgressStatusDetail,
 	instance appsv1alpha1.Instance,
-	backupName string,
 	index int) (bool, error) {
-	insHelper, err := r.prepareInstanceHelper(reqCtx, cli, opsRes, comp, envForRestore, instance, backupName, index)
+	insHelper, err := r.prepareInstanceHelper(reqCtx, cli, opsRes, comp, rebuildFrom.EnvForRestore, instance, rebuildFrom.BackupName, index)
 	if err != nil {
 		return false, err
 	}
-	if backupName == "" {
+	switch {
+	case rebuildFrom.FromPeer:
+		return r.rebuildInstanceFromPeer(reqCtx, cli, opsRes, insHelper, rebuildFrom, progressDetail)
+	case rebuildFrom.BackupName == "":
 		return r.rebuildInstanceWithNoBackup(reqCtx, cli, opsRes, insHelper, progressDetail)
+	default:
+		return r.rebuildInstanceWithBackup(reqCtx, cli, opsRes, insHelper, progressDetail)
 	}
-	return r.rebuildInstanceWithBackup(reqCtx, cli, opsRes, insHelper, progressDetail)
 }
 
 func (r rebuildInstanceOpsHandler) prepareInstanceHelper(reqCtx intctrlutil.RequestCtx,
@@ -253,8 +264,12 @@ func (r rebuildInstanceOpsHandler) prepareInstanceHelper(reqCtx intctrlutil.Requ
 			return nil, err
 		}
 	}
+	insName, err := getActiveInstanceName(reqCtx.Ctx, cli, opsRes.Cluster, comp.Name, instance.Name)
+	if err != nil {
+		return nil, err
+	}
 	targetPod := &corev1.Pod{}
-	if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: instance.Name, Namespace: opsRes.Cluster.Namespace}, targetPod); err != nil {
+	if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: insName, Namespace: opsRes.Cluster.Namespace}, targetPod); err != nil {
 		return nil, err
 	}
 	synthesizedComp, err := component.BuildSynthesizedComponentWrapper(reqCtx, cli, opsRes.Cluster, comp)
@@ -371,6 +386,214 @@ func (r rebuildInstanceOpsHandler) rebuildInstanceWithBackup(reqCtx intctrlutil.
 	return r.instanceIsAvailable(insHelper.synthesizedComp, insHelper.targetPod)
 }
 
+// rebuildInstanceFromPeer rebuilds the instance by streaming the data from a healthy peer.
+func (r rebuildInstanceOpsHandler) rebuildInstanceFromPeer(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	insHelper *instanceHelper,
+	rebuildFrom appsv1alpha1.RebuildInstance,
+	progressDetail *appsv1alpha1.ProgressStatusDetail) (bool, error) {
+	// 1. stream the data from the peer into the new pvs.
+	completed, err := r.streamDataFromPeer(reqCtx, cli, opsRes, insHelper, rebuildFrom, progressDetail)
+	if err != nil || !completed {
+		return false, err
+	}
+	if progressDetail.Message != waitingForInstanceReadyMessage {
+		// 2. rebuild source pvcs and recreate the instance by deleting it.
+		return false, r.rebuildSourcePVCsAndRecreateInstance(reqCtx, cli, opsRes.OpsRequest, progressDetail, insHelper)
+	}
+
+	// 3. waiting for new instance is available.
+	return r.instanceIsAvailable(insHelper.synthesizedComp, insHelper.targetPod)
+}
+
+// streamDataFromPeer streams the data from the peer into the new instance pvs by a temp pod,
+// which pipes the output of the DataDump action into the DataLoad action.
+func (r rebuildInstanceOpsHandler) streamDataFromPeer(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	insHelper *instanceHelper,
+	rebuildFrom appsv1alpha1.RebuildInstance,
+	progressDetail *appsv1alpha1.ProgressStatusDetail) (bool, error) {
+	rebuildPodName := fmt.Sprintf("%s-%s-%s-%d", insHelper.rebuildPrefix, common.CutString(opsRes.OpsRequest.Name, 20), insHelper.comp.Name, insHelper.index)
+	rebuildPod := &corev1.Pod{}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, cli, client.ObjectKey{Name: rebuildPodName, Namespace: opsRes.Cluster.Namespace}, rebuildPod)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		peer, err := r.pickPeer(reqCtx, cli, opsRes, insHelper, rebuildFrom)
+		if err != nil {
+			return false, err
+		}
+		container, err := r.buildDataStreamContainer(insHelper, peer)
+		if err != nil {
+			return false, err
+		}
+		progressDetail.Message = fmt.Sprintf(`Start to stream the data from the peer "%s"`, peer.Name)
+		return false, r.createTmpPVCsAndPodWithContainer(reqCtx, cli, opsRes.OpsRequest, insHelper, rebuildPodName, container)
+	}
+	switch rebuildPod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`pod "%s" rebuild failed, due to streaming the data by pod "%s" is Failed`, insHelper.targetPod.Name, rebuildPodName))
+	default:
+		progressDetail.Message = fmt.Sprintf(`Waiting for streaming the data by pod "%s" to be completed`, rebuildPod.Name)
+		return false, nil
+	}
+}
+
+// pickPeer picks the peer to stream the data from, a healthy secondary with the least replication lag is preferred.
+func (r rebuildInstanceOpsHandler) pickPeer(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	insHelper *instanceHelper,
+	rebuildFrom appsv1alpha1.RebuildInstance) (*corev1.Pod, error) {
+	var rebuildingNames []string
+	for _, ins := range rebuildFrom.Instances {
+		insName, err := getActiveInstanceName(reqCtx.Ctx, cli, opsRes.Cluster, rebuildFrom.ComponentName, ins.Name)
+		if err != nil {
+			return nil, err
+		}
+		rebuildingNames = append(rebuildingNames, insName)
+	}
+	isRebuilding := func(pod *corev1.Pod) bool {
+		return slices.Contains(rebuildingNames, pod.Name)
+	}
+	isHealthyPeer := func(pod *corev1.Pod) bool {
+		available, err := r.instanceIsAvailable(insHelper.synthesizedComp, pod)
+		return err == nil && available && !isRebuilding(pod)
+	}
+	if rebuildFrom.PeerInstanceName != "" {
+		peer := &corev1.Pod{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: rebuildFrom.PeerInstanceName, Namespace: opsRes.Cluster.Namespace}, peer); err != nil {
+			return nil, err
+		}
+		if peer.Labels[constant.KBAppComponentLabelKey] != insHelper.synthesizedComp.Name || !isHealthyPeer(peer) {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the peer "%s" is not a healthy instance of component "%s"`, peer.Name, insHelper.synthesizedComp.Name))
+		}
+		return peer, nil
+	}
+	podList, err := component.GetComponentPodList(reqCtx.Ctx, cli, *opsRes.Cluster, insHelper.synthesizedComp.Name)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		peer     *corev1.Pod
+		peerLag  *int64
+		primary  *corev1.Pod
+		isLesser = func(lag *int64) bool {
+			return peer == nil || (lag != nil && (peerLag == nil || *lag < *peerLag))
+		}
+	)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isHealthyPeer(pod) {
+			continue
+		}
+		if r.isWritableInstance(insHelper.synthesizedComp, pod) {
+			primary = pod
+			continue
+		}
+		if lag := getReplicationLag(reqCtx, pod); isLesser(lag) {
+			peer, peerLag = pod, lag
+		}
+	}
+	if peer == nil {
+		peer = primary
+	}
+	if peer == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`no healthy peer found to rebuild the instance "%s"`, insHelper.targetPod.Name))
+	}
+	return peer, nil
+}
+
+// isWritableInstance checks if the instance is the primary or leader.
+func (r rebuildInstanceOpsHandler) isWritableInstance(synthesizedComp *component.SynthesizedComponent, pod *corev1.Pod) bool {
+	roleName := pod.Labels[constant.RoleLabelKey]
+	for _, role := range synthesizedComp.Roles {
+		if role.Name == roleName && role.Writable {
+			return true
+		}
+	}
+	return false
+}
+
+// buildDataStreamContainer builds the container which pipes the output of the DataDump action into the DataLoad action.
+func (r rebuildInstanceOpsHandler) buildDataStreamContainer(insHelper *instanceHelper, peer *corev1.Pod) (*corev1.Container, error) {
+	var dataDump, dataLoad *appsv1alpha1.Action
+	if actions := insHelper.synthesizedComp.LifecycleActions; actions != nil {
+		if actions.DataDump != nil {
+			dataDump = actions.DataDump.CustomHandler
+		}
+		if actions.DataLoad != nil {
+			dataLoad = actions.DataLoad.CustomHandler
+		}
+	}
+	if dataDump == nil || dataDump.Exec == nil || dataLoad == nil || dataLoad.Exec == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the dataDump and dataLoad actions with exec are required to rebuild the instance from a peer, `+
+			`but they are not defined in the component "%s"`, insHelper.synthesizedComp.Name))
+	}
+	// the container runs with the image of the DataLoad action, and mounts the new volumes at the same paths as the instance.
+	var templateContainer *corev1.Container
+	for i := range insHelper.targetPod.Spec.Containers {
+		c := &insHelper.targetPod.Spec.Containers[i]
+		if templateContainer == nil || c.Name == dataLoad.Container {
+			templateContainer = c
+		}
+	}
+	if templateContainer == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`no container found in the instance "%s"`, insHelper.targetPod.Name))
+	}
+	image := dataLoad.Image
+	if image == "" {
+		image = templateContainer.Image
+	}
+	var volumeMounts []corev1.VolumeMount
+	for _, volume := range insHelper.volumes {
+		for _, c := range insHelper.targetPod.Spec.Containers {
+			if i := slices.IndexFunc(c.VolumeMounts, func(m corev1.VolumeMount) bool { return m.Name == volume.Name }); i >= 0 {
+				volumeMounts = append(volumeMounts, c.VolumeMounts[i])
+				break
+			}
+		}
+	}
+	env := append([]corev1.EnvVar{}, templateContainer.Env...)
+	env = append(env, dataDump.Env...)
+	env = append(env, dataLoad.Env...)
+	env = append(env, []corev1.EnvVar{
+		{Name: "KB_DATA_SOURCE_POD_NAME", Value: peer.Name},
+		{Name: "KB_DATA_SOURCE_POD_IP", Value: peer.Status.PodIP},
+		{Name: "KB_DATA_SOURCE_POD_FQDN", Value: fmt.Sprintf("%s.%s.%s.svc", peer.Name,
+			constant.GeneratePodSubDomain(insHelper.synthesizedComp.ClusterName, insHelper.synthesizedComp.Name), peer.Namespace)},
+	}...)
+	// the failure of the DataDump action is recorded, since the exit code of the pipeline is the one of the DataLoad action.
+	script := fmt.Sprintf(`{ %s || touch /tmp/kb-data-dump-failed; } | %s && [ ! -e /tmp/kb-data-dump-failed ]`,
+		r.buildShellCommand(dataDump.Exec), r.buildShellCommand(dataLoad.Exec))
+	container := &corev1.Container{
+		Name:            "rebuild",
+		Command:         []string{"sh", "-c", script},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Image:           image,
+		Env:             env,
+		EnvFrom:         templateContainer.EnvFrom,
+		VolumeMounts:    volumeMounts,
+		SecurityContext: templateContainer.SecurityContext,
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(container)
+	return container, nil
+}
+
+// buildShellCommand builds the shell command line of the exec action.
+func (r rebuildInstanceOpsHandler) buildShellCommand(exec *appsv1alpha1.ExecAction) string {
+	args := append(append([]string{}, exec.Command...), exec.Args...)
+	for i := range args {
+		args[i] = "'" + strings.ReplaceAll(args[i], "'", `'\''`) + "'"
+	}
+	return strings.Join(args, " ")
+}
+
 // rebuildInstancePVByPod rebuilds the new instance pvs by a temp pod.
 func (r rebuildInstanceOpsHandler) rebuildInstancePVByPod(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
@@ -571,12 +794,6 @@ func (r rebuildInstanceOpsHandler) createTmpPVCsAndPod(reqCtx intctrlutil.Reques
 	opsRequest *appsv1alpha1.OpsRequest,
 	insHelper *instanceHelper,
 	tmpPodName string) error {
-	for _, v := range insHelper.pvcMap {
-		_ = intctrlutil.SetControllerReference(opsRequest, v)
-		if err := cli.Create(reqCtx.Ctx, v); client.IgnoreAlreadyExists(err) != nil {
-			return err
-		}
-	}
 	container := &corev1.Container{
 		Name:            "rebuild",
 		Command:         []string{"sh", "-c", "echo 'rebuild done.'"},
@@ -585,6 +802,22 @@ func (r rebuildInstanceOpsHandler) createTmpPVCsAndPod(reqCtx intctrlutil.Reques
 		VolumeMounts:    insHelper.volumeMounts,
 	}
 	intctrlutil.InjectZeroResourcesLimitsIfEmpty(container)
+	return r.createTmpPVCsAndPodWithContainer(reqCtx, cli, opsRequest, insHelper, tmpPodName, container)
+}
+
+// createTmpPVCsAndPodWithContainer creates the tmp pvcs and the pod which runs the container.
+func (r rebuildInstanceOpsHandler) createTmpPVCsAndPodWithContainer(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRequest *appsv1alpha1.OpsRequest,
+	insHelper *instanceHelper,
+	tmpPodName string,
+	container *corev1.Container) error {
+	for _, v := range insHelper.pvcMap {
+		_ = intctrlutil.SetControllerReference(opsRequest, v)
+		if err := cli.Create(reqCtx.Ctx, v); client.IgnoreAlreadyExists(err) != nil {
+			return err
+		}
+	}
 	rebuildPodBuilder := builder.NewPodBuilder(insHelper.targetPod.Namespace, tmpPodName).AddTolerations(insHelper.targetPod.Spec.Tolerations...).
 		AddContainer(*container).
 		AddVolumes(insHelper.volumes...).
@@ -275,6 +275,27 @@ var _ = Describe("OpsUtil functions", func() {
 			Eventually(testapps.List(&testCtx, generics.PodSignature, matchingLabels, client.InNamespace(opsRes.OpsRequest.Namespace))).Should(HaveLen(0))
 		})
 
+		It("test rebuild instance from peer without the dataDump and dataLoad actions", func() {
+			By("init operations resources ")
+			opsRes := prepareOpsRes("")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.RebuildFrom[0].FromPeer = true
+			})).Should(Succeed())
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("expect the opsRequest to fail and no tmp pods are created")
+			_, _ = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			}))
+			matchingLabels := client.MatchingLabels{
+				constant.OpsRequestNameLabelKey:      opsRes.OpsRequest.Name,
+				constant.OpsRequestNamespaceLabelKey: opsRes.OpsRequest.Namespace,
+			}
+			Eventually(testapps.List(&testCtx, generics.PodSignature, matchingLabels, client.InNamespace(opsRes.OpsRequest.Namespace))).Should(HaveLen(0))
+		})
+
 		It("test rebuild instance with backup", func() {
 			By("init operation resources and backup")
 			actionSet := testapps.CreateCustomizedObj(&testCtx, "backup/actionset.yaml",
@@ -47,6 +47,9 @@ func init() {
 		ToClusterPhase: appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster: true,
 		OpsHandler:     &reAction,
+
+		RespectMaintenanceWindow: true,
+		SupportDryRun:            true,
 	}
 	opsManager.RegisterOps(appsv1alpha1.ReconfiguringType, reconfigureBehaviour)
 }
@@ -56,7 +59,39 @@ func (r *reconfigureAction) ActionStartedCondition(reqCtx intctrlutil.RequestCtx
 	return appsv1alpha1.NewReconfigureCondition(opsRes.OpsRequest), nil
 }
 
+// SaveLastConfiguration records the current values of the parameters to be updated to the OpsRequest.status.lastConfiguration
 func (r *reconfigureAction) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	lastComponentInfo := map[string]appsv1alpha1.LastComponentConfiguration{}
+	// the reconfiguring status should not be initialized when recording the last configuration.
+	resource := &OpsResource{
+		Cluster:    opsRes.Cluster,
+		OpsRequest: opsRes.OpsRequest.DeepCopy(),
+		Recorder:   opsRes.Recorder,
+	}
+	for _, reconfigureParams := range fromReconfigureOperations(resource.OpsRequest.Spec, reqCtx, cli, resource) {
+		item := reconfigureParams.configurationItem
+		lastItem, err := newPipeline(reconfigureContext{
+			cli:           cli,
+			reqCtx:        reqCtx,
+			resource:      reconfigureParams.resource,
+			config:        item,
+			clusterName:   reconfigureParams.clusterName,
+			componentName: reconfigureParams.componentName,
+		}).Configuration().
+			Validate().
+			ConfigMap(item.Name).
+			ConfigConstraints().
+			LastConfiguration()
+		if err != nil {
+			// the invalid reconfiguring is rejected in the Action.
+			reqCtx.Log.Info(fmt.Sprintf("failed to record the last configuration of %s: %s", item.Name, err.Error()))
+			continue
+		}
+		lastConfiguration := lastComponentInfo[reconfigureParams.componentName]
+		lastConfiguration.Configurations = append(lastConfiguration.Configurations, *lastItem)
+		lastComponentInfo[reconfigureParams.componentName] = lastConfiguration
+	}
+	opsRes.OpsRequest.Status.LastConfiguration.Components = lastComponentInfo
 	return nil
 }
 
@@ -273,6 +308,34 @@ func (r *reconfigureAction) doReconfiguring(params reconfigureParams) error {
 	return nil
 }
 
+// DryRunAction merges the parameters as Action does, and renders the ConfigMap and the restart of the workload
+// which would be done by the configuration controller.
+func (r *reconfigureAction) DryRunAction(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) error {
+	for _, params := range fromReconfigureOperations(resource.OpsRequest.Spec, reqCtx, cli, resource) {
+		item := params.configurationItem
+		result := newPipeline(reconfigureContext{
+			cli:           params.cli,
+			reqCtx:        params.reqCtx,
+			resource:      params.resource,
+			config:        item,
+			clusterName:   params.clusterName,
+			componentName: params.componentName,
+		}).
+			Configuration().
+			Validate().
+			ConfigMap(item.Name).
+			ConfigConstraints().
+			Merge().
+			Sync().
+			Render().
+			Complete()
+		if result.err != nil {
+			return processMergedFailed(params.resource, result.failed, result.err)
+		}
+	}
+	return nil
+}
+
 func needReconfigure(request *appsv1alpha1.OpsRequest, status *appsv1alpha1.ReconfiguringStatus) bool {
 	// Update params to configmap
 	if request.Spec.Type != appsv1alpha1.ReconfiguringType {
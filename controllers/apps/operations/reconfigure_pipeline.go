@@ -20,12 +20,19 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"reflect"
+
+	"github.com/spf13/cast"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/configuration/util"
 	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -47,6 +54,7 @@ type pipeline struct {
 
 	updatedParameters []cfgcore.ParamPairs
 	mergedConfig      map[string]string
+	renderedData      map[string]string
 	configPatch       *cfgcore.ConfigPatchInfo
 	isFileUpdated     bool
 
@@ -168,6 +176,7 @@ func (p *pipeline) createUpdatePatch(item *appsv1alpha1.ConfigurationItemDetail,
 		p.isFailed = true
 		return err
 	}
+	p.renderedData = updatedData
 	p.configPatch, _, err = cfgcore.CreateConfigPatch(p.ConfigMapObj.Data,
 		updatedData,
 		p.configConstraint.Spec.FormatterConfig.Format,
@@ -213,6 +222,79 @@ func (p *pipeline) Sync() *pipeline {
 	})
 }
 
+// Render updates the ConfigMap with the merged data, and restarts the workload if the updated parameters
+// can not be reloaded dynamically, as what the configuration controller does after the configuration is synced.
+// It is only used in dry-run mode to render the impact of the reconfiguring.
+func (p *pipeline) Render() *pipeline {
+	renderFn := func() error {
+		configMap := p.ConfigMapObj.DeepCopy()
+		if p.renderedData != nil {
+			configMap.Data = p.renderedData
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		for _, key := range p.config.Keys {
+			if key.FileContent != "" {
+				configMap.Data[key.Key] = key.FileContent
+			}
+		}
+		if reflect.DeepEqual(configMap.Data, p.ConfigMapObj.Data) {
+			return nil
+		}
+		if err := p.cli.Update(p.reqCtx.Ctx, configMap); err != nil {
+			return err
+		}
+		if restart, err := p.needRestart(); err != nil || !restart {
+			return err
+		}
+		return p.restartWorkload(configMap)
+	}
+
+	return p.Wrap(renderFn)
+}
+
+func (p *pipeline) needRestart() (bool, error) {
+	if p.config.Policy != nil {
+		switch *p.config.Policy {
+		case appsv1alpha1.NormalPolicy, appsv1alpha1.RestartPolicy, appsv1alpha1.RollingPolicy,
+			appsv1alpha1.DynamicReloadAndRestartPolicy:
+			return true, nil
+		case appsv1alpha1.AsyncDynamicReloadPolicy, appsv1alpha1.SyncDynamicReloadPolicy:
+			return false, nil
+		}
+	}
+	if p.configConstraint == nil || p.configPatch == nil {
+		return p.isFileUpdated, nil
+	}
+	dynamicUpdate, err := cfgcore.IsUpdateDynamicParameters(&p.configConstraint.Spec, p.configPatch)
+	if err != nil {
+		return false, err
+	}
+	return !dynamicUpdate, nil
+}
+
+func (p *pipeline) restartWorkload(configMap *corev1.ConfigMap) error {
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{
+		Namespace: configMap.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(p.clusterName, p.componentName),
+	}
+	if err := p.cli.Get(p.reqCtx.Ctx, itsKey, its); err != nil {
+		return err
+	}
+	version, err := util.ComputeHash(configMap.Data)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(its.DeepCopy())
+	if its.Spec.Template.Annotations == nil {
+		its.Spec.Template.Annotations = map[string]string{}
+	}
+	its.Spec.Template.Annotations[cfgcore.GenerateUniqKeyWithConfig(constant.UpgradeRestartAnnotationKey, p.configSpec.Name)] = version
+	return p.cli.Patch(p.reqCtx.Ctx, its, patch)
+}
+
 func (p *pipeline) Complete() reconfiguringResult {
 	if p.Err != nil {
 		return makeReconfiguringResult(p.Err, withFailed(p.isFailed))
@@ -223,3 +305,40 @@ func (p *pipeline) Complete() reconfiguringResult {
 		withNoFormatFilesUpdated(p.isFileUpdated),
 	)
 }
+
+// LastConfiguration returns the current values of the configurations which would be updated by the reconfiguring,
+// the parameters are restored to these values if the reconfiguring is rolled back.
+func (p *pipeline) LastConfiguration() (*appsv1alpha1.ConfigurationItem, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	lastItem := &appsv1alpha1.ConfigurationItem{
+		Name:   p.config.Name,
+		Policy: p.config.Policy,
+	}
+	for _, key := range p.config.Keys {
+		data := p.ConfigMapObj.Data[key.Key]
+		lastKey := appsv1alpha1.ParameterConfig{Key: key.Key}
+		if len(key.Parameters) == 0 {
+			lastKey.FileContent = data
+			lastItem.Keys = append(lastItem.Keys, lastKey)
+			continue
+		}
+		if p.configConstraint == nil || p.configConstraint.Spec.FormatterConfig == nil {
+			return nil, cfgcore.MakeError("not support to patch parameters of the config file without formatter: %s", key.Key)
+		}
+		configObj, err := cfgcore.FromConfigObject(key.Key, data, p.configConstraint.Spec.FormatterConfig)
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range key.Parameters {
+			lastParam := appsv1alpha1.ParameterPair{Key: param.Key}
+			if value := configObj.Get(param.Key); value != nil {
+				lastParam.Value = util.ToPointer(cast.ToString(value))
+			}
+			lastKey.Parameters = append(lastKey.Parameters, lastParam)
+		}
+		lastItem.Keys = append(lastItem.Keys, lastKey)
+	}
+	return lastItem, nil
+}
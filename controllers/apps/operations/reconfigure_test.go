@@ -233,6 +233,14 @@ var _ = Describe("Reconfigure OpsRequest", func() {
 			_, err := opsManager.Do(reqCtx, k8sClient, opsRes)
 			Expect(err).ShouldNot(HaveOccurred())
 
+			By("expect the last values of the parameters are recorded")
+			lastConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[consensusComp]
+			Expect(lastConfiguration.Configurations).Should(HaveLen(1))
+			Expect(lastConfiguration.Configurations[0].Name).Should(Equal("mysql-test"))
+			Expect(lastConfiguration.Configurations[0].Keys).Should(HaveLen(1))
+			Expect(lastConfiguration.Configurations[0].Keys[0].Key).Should(Equal("my.cnf"))
+			Expect(lastConfiguration.Configurations[0].Keys[0].Parameters).Should(HaveLen(2))
+
 			By("Reconfigure configure")
 			_, err = opsManager.Do(reqCtx, k8sClient, opsRes)
 			Expect(err).ShouldNot(HaveOccurred())
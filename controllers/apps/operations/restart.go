@@ -46,6 +46,8 @@ func init() {
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
 		OpsHandler:        restartOpsHandler{},
+
+		RespectMaintenanceWindow: true,
 	}
 
 	opsMgr := GetOpsManager()
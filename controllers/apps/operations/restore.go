@@ -73,7 +73,14 @@ func (r RestoreOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	}
 
 	// create cluster
-	if err = cli.Create(reqCtx.Ctx, cluster); err != nil {
+	return createClusterOfOpsRequest(reqCtx, cli, opsRes, cluster)
+}
+
+// createClusterOfOpsRequest creates the cluster which is referenced by the OpsRequest,
+// and sets the cluster as the owner of the OpsRequest.
+func createClusterOfOpsRequest(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, cluster *appsv1alpha1.Cluster) error {
+	opsRequest := opsRes.OpsRequest
+	if err := cli.Create(reqCtx.Ctx, cluster); err != nil {
 		return err
 	}
 	opsRes.Cluster = cluster
@@ -87,13 +94,10 @@ func (r RestoreOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	opsRequest.Labels[constant.AppInstanceLabelKey] = opsRequest.Spec.ClusterRef
 	opsRequest.Labels[constant.OpsRequestTypeLabelKey] = string(opsRequest.Spec.Type)
 	scheme, _ := appsv1alpha1.SchemeBuilder.Build()
-	if err = controllerutil.SetOwnerReference(cluster, opsRequest, scheme); err != nil {
-		return err
-	}
-	if err = cli.Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+	if err := controllerutil.SetOwnerReference(cluster, opsRequest, scheme); err != nil {
 		return err
 	}
-	return nil
+	return cli.Patch(reqCtx.Ctx, opsRequest, patch)
 }
 
 // ReconcileAction implements the restore action.
@@ -193,7 +197,13 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 	}
 	cluster.Annotations[constant.RestoreFromBackupAnnotationKey] = restoreAnnotation
 	cluster.Name = opsRequest.Spec.ClusterRef
-	// Reset cluster services
+	resetClusterServices(cluster)
+	return cluster, nil
+}
+
+// resetClusterServices resets the services of the cluster restored from a snapshot,
+// the LoadBalancer services are removed and the node ports are reallocated.
+func resetClusterServices(cluster *appsv1alpha1.Cluster) {
 	var services []appsv1alpha1.ClusterService
 	for i := range cluster.Spec.Services {
 		svc := cluster.Spec.Services[i]
@@ -211,5 +221,4 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 		services = append(services, svc)
 	}
 	cluster.Spec.Services = services
-	return cluster, nil
 }
@@ -0,0 +1,103 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// NeedRollback checks if the failed OpsRequest should be rolled back and the rollback OpsRequest is not created yet.
+func NeedRollback(opsRequest *appsv1alpha1.OpsRequest) bool {
+	return opsRequest.Spec.RollbackOnFailure &&
+		opsRequest.Status.Phase == appsv1alpha1.OpsFailedPhase &&
+		opsRequest.Status.RollbackOpsRequest == ""
+}
+
+// BuildRollbackOpsRequest builds the OpsRequest which restores the configuration recorded in the status.lastConfiguration
+// of the failed OpsRequest. It returns nil if there is nothing to roll back.
+func BuildRollbackOpsRequest(opsRequest *appsv1alpha1.OpsRequest) *appsv1alpha1.OpsRequest {
+	lastConfiguration := opsRequest.Status.LastConfiguration
+	// sort the components to keep the rollback OpsRequest stable.
+	compNames := make([]string, 0, len(lastConfiguration.Components))
+	for compName := range lastConfiguration.Components {
+		compNames = append(compNames, compName)
+	}
+	sort.Strings(compNames)
+
+	rollbackSpec := appsv1alpha1.OpsRequestSpec{
+		ClusterRef: opsRequest.Spec.ClusterRef,
+		Type:       opsRequest.Spec.Type,
+		// the rollback runs immediately regardless of the cluster phase, the queue and the maintenance window.
+		Force: true,
+	}
+	switch opsRequest.Spec.Type {
+	case appsv1alpha1.VerticalScalingType:
+		for _, compName := range compNames {
+			rollbackSpec.VerticalScalingList = append(rollbackSpec.VerticalScalingList, appsv1alpha1.VerticalScaling{
+				ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: compName},
+				ResourceRequirements: lastConfiguration.Components[compName].ResourceRequirements,
+			})
+		}
+		if len(rollbackSpec.VerticalScalingList) == 0 {
+			return nil
+		}
+	case appsv1alpha1.UpgradeType:
+		if lastConfiguration.ClusterVersionRef == "" {
+			return nil
+		}
+		rollbackSpec.Upgrade = &appsv1alpha1.Upgrade{ClusterVersionRef: lastConfiguration.ClusterVersionRef}
+	case appsv1alpha1.ReconfiguringType:
+		for _, compName := range compNames {
+			configurations := lastConfiguration.Components[compName].Configurations
+			if len(configurations) == 0 {
+				continue
+			}
+			rollbackSpec.Reconfigures = append(rollbackSpec.Reconfigures, appsv1alpha1.Reconfigure{
+				ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: compName},
+				Configurations: configurations,
+			})
+		}
+		if len(rollbackSpec.Reconfigures) == 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opsRequest.GetRollbackOpsRequestName(),
+			Namespace: opsRequest.Namespace,
+			Labels: map[string]string{
+				constant.OpsRequestRollbackOfLabelKey: opsRequest.Name,
+			},
+			// the rollback is requested by the requester of the rolled back OpsRequest.
+			Annotations: map[string]string{
+				constant.OpsRequestRequestedByAnnotationKey: opsRequest.Annotations[constant.OpsRequestRequestedByAnnotationKey],
+			},
+		},
+		Spec: rollbackSpec,
+	}
+}
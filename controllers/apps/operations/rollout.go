@@ -0,0 +1,300 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// rolloutActionName is the action name of the progressDetail which records the current step of the rollout.
+const rolloutActionName = "Rollout"
+
+// getRolloutComponentNames gets the names of the components which are rolled out in steps.
+func getRolloutComponentNames(opsRequest *appsv1alpha1.OpsRequest) []string {
+	var compNames []string
+	switch opsRequest.Spec.Type {
+	case appsv1alpha1.VerticalScalingType:
+		for _, v := range opsRequest.Spec.VerticalScalingList {
+			compNames = append(compNames, v.ComponentName)
+		}
+	case appsv1alpha1.UpgradeType:
+		// the components changed by the upgrade are recorded in status.components.
+		for compName := range opsRequest.Status.Components {
+			compNames = append(compNames, compName)
+		}
+	}
+	sort.Strings(compNames)
+	return compNames
+}
+
+// buildUpdatePartition builds the update partition of the InstanceSet for the specified rollout step.
+// it returns nil if the step is out of range, which means all the replicas can be updated.
+func buildUpdatePartition(opsRequest *appsv1alpha1.OpsRequest, stepIndex int32, replicas int32) (*workloads.UpdatePartition, error) {
+	rollout := opsRequest.Spec.Rollout
+	if rollout == nil || int(stepIndex) >= len(rollout.Steps) {
+		return nil, nil
+	}
+	step := rollout.Steps[stepIndex]
+	partition := &workloads.UpdatePartition{Roles: step.Roles}
+	if step.Replicas != nil {
+		partitionReplicas, err := intstr.GetScaledValueFromIntOrPercent(step.Replicas, int(replicas), true)
+		if err != nil {
+			return nil, err
+		}
+		partition.Replicas = pointer.Int32(int32(partitionReplicas))
+	}
+	return partition, nil
+}
+
+// setRolloutPartitions sets the update partitions of the component InstanceSets to the specified rollout step.
+// the partitions are removed if the OpsRequest is not rolled out in steps, or all the steps are completed.
+func setRolloutPartitions(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, stepIndex int32) error {
+	for _, compName := range getRolloutComponentNames(opsRes.OpsRequest) {
+		comp := opsRes.Cluster.Spec.GetComponentByName(compName)
+		if comp == nil {
+			continue
+		}
+		partition, err := buildUpdatePartition(opsRes.OpsRequest, stepIndex, comp.Replicas)
+		if err != nil {
+			return intctrlutil.NewFatalError(err.Error())
+		}
+		its := &workloads.InstanceSet{}
+		itsKey := client.ObjectKey{Namespace: opsRes.Cluster.Namespace, Name: constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compName)}
+		if err = cli.Get(reqCtx.Ctx, itsKey, its); err != nil {
+			if client.IgnoreNotFound(err) == nil && partition == nil {
+				continue
+			}
+			return err
+		}
+		if reflect.DeepEqual(its.Spec.UpdatePartition, partition) {
+			continue
+		}
+		patch := client.MergeFrom(its.DeepCopy())
+		its.Spec.UpdatePartition = partition
+		if err = cli.Patch(reqCtx.Ctx, its, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeRolloutPartitions removes the update partitions of the component InstanceSets set by the rollout.
+func removeRolloutPartitions(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return setRolloutPartitions(reqCtx, cli, opsRes, math.MaxInt32)
+}
+
+// isRolloutStepReady checks if the replicas of the current rollout step are updated and healthy in all the components.
+func isRolloutStepReady(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error) {
+	// wait for the changes of the OpsRequest to be applied to the InstanceSets.
+	if opsRes.Cluster.Status.ObservedGeneration < opsRes.OpsRequest.Status.ClusterGeneration {
+		return false, nil
+	}
+	for _, compName := range getRolloutComponentNames(opsRes.OpsRequest) {
+		comp := &appsv1alpha1.Component{}
+		compKey := client.ObjectKey{Namespace: opsRes.Cluster.Namespace, Name: constant.GenerateClusterComponentName(opsRes.Cluster.Name, compName)}
+		if err := cli.Get(reqCtx.Ctx, compKey, comp); err != nil {
+			return false, err
+		}
+		if comp.Status.ObservedGeneration != comp.Generation {
+			return false, nil
+		}
+		its := &workloads.InstanceSet{}
+		itsKey := client.ObjectKey{Namespace: opsRes.Cluster.Namespace, Name: constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compName)}
+		if err := cli.Get(reqCtx.Ctx, itsKey, its); err != nil {
+			return false, err
+		}
+		podList, err := intctrlcomp.GetComponentPodList(reqCtx.Ctx, cli, *opsRes.Cluster, compName)
+		if err != nil {
+			return false, err
+		}
+		pods := make([]*corev1.Pod, 0, len(podList.Items))
+		for i := range podList.Items {
+			pods = append(pods, &podList.Items[i])
+		}
+		ready, err := instanceset.IsUpdatePartitionReady(its, pods)
+		if err != nil || !ready {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// reconcileActionWithRollout reconciles the opsRequest which updates the replicas of the components in steps
+// if spec.rollout is specified, and then reconciles the status of the components.
+func reconcileActionWithRollout(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsMessageKey string,
+	syncOverrideBy syncOverrideByOps) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	phase, rolloutRequeueAfter, err := reconcileRollout(reqCtx, cli, opsRes)
+	if err != nil || phase != "" {
+		return phase, rolloutRequeueAfter, err
+	}
+	phase, requeueAfter, err := reconcileActionWithComponentOps(reqCtx, cli, opsRes, opsMessageKey, syncOverrideBy, handleComponentStatusProgress)
+	if err != nil || phase != appsv1alpha1.OpsRunningPhase {
+		return phase, requeueAfter, err
+	}
+	if rolloutRequeueAfter != 0 && (requeueAfter == 0 || rolloutRequeueAfter < requeueAfter) {
+		requeueAfter = rolloutRequeueAfter
+	}
+	return phase, requeueAfter, nil
+}
+
+// reconcileRollout moves the rollout of the OpsRequest forward step by step.
+// a step is completed after the selected replicas are updated and stay healthy for the pause duration,
+// then the update partitions are moved to the next step. It returns an error with the Failed phase
+// if the rollout is aborted.
+func reconcileRollout(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.Rollout == nil || opsRequest.Status.Phase != appsv1alpha1.OpsRunningPhase {
+		return "", 0, nil
+	}
+	oldOpsRequest := opsRequest.DeepCopy()
+	if opsRequest.Status.Rollout == nil {
+		opsRequest.Status.Rollout = &appsv1alpha1.OpsRolloutStatus{StepStartTimestamp: opsRequest.Status.StartTimestamp}
+	}
+	requeueAfter, abortMessage, err := reconcileRolloutStep(reqCtx, cli, opsRes)
+	if err != nil {
+		return "", 0, err
+	}
+	setRolloutProgressDetails(opsRes, abortMessage)
+	if !reflect.DeepEqual(opsRequest.Status, oldOpsRequest.Status) {
+		if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(oldOpsRequest)); err != nil {
+			return "", 0, err
+		}
+	}
+	if abortMessage != "" {
+		return appsv1alpha1.OpsFailedPhase, 0, errors.New(abortMessage)
+	}
+	return "", requeueAfter, nil
+}
+
+// reconcileRolloutStep checks the current rollout step and moves on to the next step if it is completed.
+// it returns a non-empty message if the rollout should be aborted.
+func reconcileRolloutStep(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (time.Duration, string, error) {
+	var (
+		rollout       = opsRes.OpsRequest.Spec.Rollout
+		rolloutStatus = opsRes.OpsRequest.Status.Rollout
+	)
+	if int(rolloutStatus.CurrentStep) >= len(rollout.Steps) {
+		return 0, "", nil
+	}
+	ready, err := isRolloutStepReady(reqCtx, cli, opsRes)
+	if err != nil {
+		return 0, "", err
+	}
+	if rolloutStatus.PauseStartTimestamp == nil {
+		if !ready {
+			if rollout.ProgressDeadlineSeconds == 0 {
+				return 0, "", nil
+			}
+			deadline := rolloutStatus.StepStartTimestamp.Add(time.Duration(rollout.ProgressDeadlineSeconds) * time.Second)
+			if time.Now().After(deadline) {
+				return 0, fmt.Sprintf("rollout step %d is not completed in %d seconds",
+					rolloutStatus.CurrentStep+1, rollout.ProgressDeadlineSeconds), nil
+			}
+			return time.Until(deadline), "", nil
+		}
+		now := metav1.Now()
+		rolloutStatus.PauseStartTimestamp = &now
+	} else if !ready {
+		return 0, fmt.Sprintf("the updated replicas turn unhealthy during the pause of rollout step %d",
+			rolloutStatus.CurrentStep+1), nil
+	}
+	pauseEnd := rolloutStatus.PauseStartTimestamp.Add(time.Duration(rollout.Steps[rolloutStatus.CurrentStep].PauseSeconds) * time.Second)
+	if time.Now().Before(pauseEnd) {
+		return time.Until(pauseEnd), "", nil
+	}
+	// move on to the next step, the partitions are removed after the last step.
+	if err = setRolloutPartitions(reqCtx, cli, opsRes, rolloutStatus.CurrentStep+1); err != nil {
+		return 0, "", err
+	}
+	rolloutStatus.CurrentStep += 1
+	rolloutStatus.StepStartTimestamp = metav1.Now()
+	rolloutStatus.PauseStartTimestamp = nil
+	return 0, "", nil
+}
+
+// setRolloutProgressDetails records the current step of the rollout in the progressDetails of the components.
+func setRolloutProgressDetails(opsRes *OpsResource, failedMessage string) {
+	opsRequest := opsRes.OpsRequest
+	rolloutStatus := opsRequest.Status.Rollout
+	stepsCount := len(opsRequest.Spec.Rollout.Steps)
+	progressDetail := appsv1alpha1.ProgressStatusDetail{ActionName: rolloutActionName}
+	switch {
+	case failedMessage != "":
+		progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, failedMessage)
+	case int(rolloutStatus.CurrentStep) >= stepsCount:
+		progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+			fmt.Sprintf("all %d rollout steps are completed, rolling out the remaining replicas", stepsCount))
+	case rolloutStatus.PauseStartTimestamp != nil:
+		progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+			fmt.Sprintf("rollout step %d/%d: pausing for %d seconds", rolloutStatus.CurrentStep+1, stepsCount,
+				opsRequest.Spec.Rollout.Steps[rolloutStatus.CurrentStep].PauseSeconds))
+	default:
+		progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+			fmt.Sprintf("rollout step %d/%d: updating %s", rolloutStatus.CurrentStep+1, stepsCount,
+				describeRolloutStep(opsRequest.Spec.Rollout.Steps[rolloutStatus.CurrentStep])))
+	}
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, compName := range getRolloutComponentNames(opsRequest) {
+		compStatus := opsRequest.Status.Components[compName]
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+		opsRequest.Status.Components[compName] = compStatus
+	}
+}
+
+// describeRolloutStep describes the replicas selected by the rollout step.
+func describeRolloutStep(step appsv1alpha1.OpsRolloutStep) string {
+	replicas := "all replicas"
+	if step.Replicas != nil {
+		replicas = fmt.Sprintf("%s replicas", step.Replicas.String())
+	}
+	if len(step.Roles) == 0 {
+		return replicas
+	}
+	return fmt.Sprintf("%s with roles %s", replicas, strings.Join(step.Roles, ","))
+}
+
+// isRolloutProgressDetail checks if the progressDetail records the rollout step instead of a pod.
+func isRolloutProgressDetail(progressDetail appsv1alpha1.ProgressStatusDetail) bool {
+	return progressDetail.ObjectKey == "" && progressDetail.ActionName == rolloutActionName
+}
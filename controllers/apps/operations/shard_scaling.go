@@ -0,0 +1,378 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const shardScalingRequeueAfter = 5 * time.Second
+
+type shardScalingOpsHandler struct{}
+
+var _ OpsHandler = shardScalingOpsHandler{}
+
+func init() {
+	shardScalingBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        shardScalingOpsHandler{},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.ShardScalingType, shardScalingBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the shard scaling request.
+func (r shardScalingOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewShardScalingCondition(opsRes.OpsRequest), nil
+}
+
+// Action modifies Cluster.spec.shardingSpecs[*].shards if the shards are scaled out.
+// if the shards are scaled in, the shards to remove are marked here and the shards will be updated after they are drained.
+func (r shardScalingOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, shardScaling := range opsRequest.Spec.ShardScalingList {
+		shardingSpec := r.getShardingSpec(opsRes.Cluster, shardScaling.ShardingName)
+		if shardingSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`sharding "%s" not found in cluster "%s"`, shardScaling.ShardingName, opsRes.Cluster.Name))
+		}
+		shards, err := r.listUndeletedShards(reqCtx, cli, opsRes.Cluster, shardingSpec)
+		if err != nil {
+			return err
+		}
+		if removeCount := len(shards) - int(shardScaling.Shards); removeCount > 0 {
+			if err = r.markRemovingShards(reqCtx, cli, opsRequest, shards, removeCount); err != nil {
+				return err
+			}
+		} else {
+			shardingSpec.Shards = shardScaling.Shards
+		}
+		opsRequest.Status.Components[shardScaling.ShardingName] = appsv1alpha1.OpsRequestComponentStatus{
+			Phase: appsv1alpha1.UpdatingClusterCompPhase,
+		}
+	}
+	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the steps of each sharding are:
+// 1. drain the shards to remove by the shardRemove action, and update the shards after all of them are drained.
+// 2. wait for the shards to be created or deleted.
+// 3. add the new shards by the shardAdd action.
+// 4. rebalance the data among the shards by the rebalance action.
+func (r shardScalingOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+		expectCount     = len(opsRes.OpsRequest.Spec.ShardScalingList)
+		completedCount  int
+		failedCount     int
+	)
+	for _, shardScaling := range opsRes.OpsRequest.Spec.ShardScalingList {
+		compStatus := opsRes.OpsRequest.Status.Components[shardScaling.ShardingName]
+		if compStatus.Phase == appsv1alpha1.RunningClusterCompPhase || compStatus.Phase == appsv1alpha1.FailedClusterCompPhase {
+			completedCount += 1
+			if compStatus.Phase == appsv1alpha1.FailedClusterCompPhase {
+				failedCount += 1
+			}
+			continue
+		}
+		completed, err := r.scaleShards(reqCtx, cli, opsRes, shardScaling, &compStatus)
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			compStatus.Phase = appsv1alpha1.FailedClusterCompPhase
+			compStatus.Message = err.Error()
+			completedCount += 1
+			failedCount += 1
+		} else if err != nil {
+			return opsRequestPhase, 0, err
+		} else if completed {
+			compStatus.Phase = appsv1alpha1.RunningClusterCompPhase
+			completedCount += 1
+		}
+		opsRes.OpsRequest.Status.Components[shardScaling.ShardingName] = compStatus
+	}
+	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if completedCount != expectCount {
+		return opsRequestPhase, shardScalingRequeueAfter, nil
+	}
+	if failedCount > 0 {
+		return appsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	return appsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration records the last shards of the shardings.
+func (r shardScalingOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	lastComponentInfo := map[string]appsv1alpha1.LastComponentConfiguration{}
+	for _, shardScaling := range opsRes.OpsRequest.Spec.ShardScalingList {
+		shardingSpec := r.getShardingSpec(opsRes.Cluster, shardScaling.ShardingName)
+		if shardingSpec == nil {
+			continue
+		}
+		shards := shardingSpec.Shards
+		lastComponentInfo[shardScaling.ShardingName] = appsv1alpha1.LastComponentConfiguration{
+			Replicas: &shards,
+		}
+	}
+	opsRes.OpsRequest.Status.LastConfiguration.Components = lastComponentInfo
+	return nil
+}
+
+// scaleShards scales the shards of the sharding, it returns true if the shards have been scaled and rebalanced.
+func (r shardScalingOpsHandler) scaleShards(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	shardScaling appsv1alpha1.ShardScaling,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) (bool, error) {
+	shardingSpec := r.getShardingSpec(opsRes.Cluster, shardScaling.ShardingName)
+	if shardingSpec == nil {
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`sharding "%s" not found in cluster "%s"`, shardScaling.ShardingName, opsRes.Cluster.Name))
+	}
+	comps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, shardingSpec)
+	if err != nil {
+		return false, err
+	}
+	var (
+		removingShards  []appsv1alpha1.Component
+		addedShards     []string
+		remainingShards []string
+	)
+	for _, comp := range comps {
+		shardName, err := component.ShortName(opsRes.Cluster.Name, comp.Name)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case comp.Annotations[constant.ShardRemovingAnnotationKey] == opsRes.OpsRequest.Name:
+			removingShards = append(removingShards, comp)
+		case !comp.DeletionTimestamp.IsZero():
+			continue
+		default:
+			remainingShards = append(remainingShards, shardName)
+			if !comp.CreationTimestamp.Before(&opsRes.OpsRequest.CreationTimestamp) {
+				addedShards = append(addedShards, shardName)
+			}
+		}
+	}
+	slices.Sort(remainingShards)
+
+	// 1. drain the shards to remove, and update the shards after all of them are drained.
+	drained := true
+	for _, comp := range removingShards {
+		if !comp.DeletionTimestamp.IsZero() {
+			continue
+		}
+		shardName, _ := component.ShortName(opsRes.Cluster.Name, comp.Name)
+		completed, err := r.doShardAction(reqCtx, cli, opsRes, shardingSpec, compStatus, shardName, component.ShardRemoveAction, remainingShards)
+		if err != nil {
+			return false, err
+		}
+		drained = drained && completed
+	}
+	if !drained {
+		return false, nil
+	}
+	if shardingSpec.Shards != shardScaling.Shards {
+		shardingSpec.Shards = shardScaling.Shards
+		return false, cli.Update(reqCtx.Ctx, opsRes.Cluster)
+	}
+
+	// 2. wait for the shards to be created or deleted.
+	if len(removingShards) > 0 || len(remainingShards) != int(shardScaling.Shards) {
+		return false, nil
+	}
+	for _, shardName := range addedShards {
+		if opsRes.Cluster.Status.Components[shardName].Phase != appsv1alpha1.RunningClusterCompPhase {
+			return false, nil
+		}
+	}
+
+	// 3. add the new shards.
+	added := true
+	for _, shardName := range addedShards {
+		completed, err := r.doShardAction(reqCtx, cli, opsRes, shardingSpec, compStatus, shardName, component.ShardAddAction, remainingShards)
+		if err != nil {
+			return false, err
+		}
+		added = added && completed
+	}
+	if !added || len(remainingShards) == 0 {
+		return added, nil
+	}
+
+	// 4. rebalance the data among the shards.
+	return r.doShardAction(reqCtx, cli, opsRes, shardingSpec, compStatus, remainingShards[0], component.RebalanceAction, remainingShards)
+}
+
+// doShardAction executes the shard action by a job against the shard, it returns true if the action is completed or not defined.
+func (r shardScalingOpsHandler) doShardAction(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	shardingSpec *appsv1alpha1.ShardingSpec,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	shardName string,
+	actionType component.LifeCycleActionType,
+	shards []string) (bool, error) {
+	jobName := r.genShardActionJobName(opsRes.OpsRequest, actionType, shardName)
+	progressDetail := appsv1alpha1.ProgressStatusDetail{
+		Group:     string(actionType),
+		ObjectKey: getProgressObjectKey(constant.JobKind, jobName),
+	}
+	if existing := findStatusProgressDetail(compStatus.ProgressDetails, progressDetail.ObjectKey); existing != nil &&
+		existing.Status == appsv1alpha1.SucceedProgressStatus {
+		return true, nil
+	}
+	compSpec := shardingSpec.Template.DeepCopy()
+	compSpec.Name = shardName
+	synthesizedComp, err := component.BuildSynthesizedComponentWrapper(reqCtx, cli, opsRes.Cluster, compSpec)
+	if err != nil {
+		return false, err
+	}
+	if !component.HasShardAction(synthesizedComp, actionType) {
+		return true, nil
+	}
+	job := &batchv1.Job{}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, cli, types.NamespacedName{Name: jobName, Namespace: opsRes.Cluster.Namespace}, job)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		if job, err = component.RenderShardActionJob(reqCtx.Ctx, cli, opsRes.Cluster, synthesizedComp, actionType, shardingSpec.Name, shards); err != nil {
+			return false, err
+		}
+		job.Name = jobName
+		job.Spec.Template.Name = jobName
+		_ = intctrlutil.SetControllerReference(opsRes.OpsRequest, job)
+		if err = cli.Create(reqCtx.Ctx, job); err != nil {
+			return false, err
+		}
+		progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+			fmt.Sprintf("Start to execute the %s action against shard %s", actionType, shardName))
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+		return false, nil
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+				fmt.Sprintf("Execute the %s action against shard %s successfully", actionType, shardName))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+			return true, component.CleanJobByName(reqCtx.Ctx, cli, opsRes.Cluster, jobName)
+		case batchv1.JobFailed:
+			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus,
+				fmt.Sprintf(`Failed to execute the %s action against shard %s, please check the job "%s"`, actionType, shardName, jobName))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+			return false, intctrlutil.NewFatalError(progressDetail.Message)
+		}
+	}
+	return false, nil
+}
+
+// markRemovingShards marks the shards to remove, the shards marked previously and the newest shards are removed first.
+func (r shardScalingOpsHandler) markRemovingShards(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRequest *appsv1alpha1.OpsRequest,
+	shards []appsv1alpha1.Component,
+	removeCount int) error {
+	slices.SortFunc(shards, func(a, b appsv1alpha1.Component) int {
+		if aRemoving, bRemoving := intctrlutil.IsShardRemoving(&a), intctrlutil.IsShardRemoving(&b); aRemoving != bRemoving {
+			if aRemoving {
+				return -1
+			}
+			return 1
+		}
+		if c := b.CreationTimestamp.Time.Compare(a.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range shards {
+		comp := &shards[i]
+		patch := client.MergeFrom(comp.DeepCopy())
+		if i < removeCount {
+			if comp.Annotations[constant.ShardRemovingAnnotationKey] == opsRequest.Name {
+				continue
+			}
+			if comp.Annotations == nil {
+				comp.Annotations = map[string]string{}
+			}
+			comp.Annotations[constant.ShardRemovingAnnotationKey] = opsRequest.Name
+		} else {
+			if !intctrlutil.IsShardRemoving(comp) {
+				continue
+			}
+			delete(comp.Annotations, constant.ShardRemovingAnnotationKey)
+		}
+		if err := cli.Patch(reqCtx.Ctx, comp, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r shardScalingOpsHandler) listUndeletedShards(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	shardingSpec *appsv1alpha1.ShardingSpec) ([]appsv1alpha1.Component, error) {
+	comps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, cluster, shardingSpec)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(comps, func(comp appsv1alpha1.Component) bool {
+		return !comp.DeletionTimestamp.IsZero()
+	}), nil
+}
+
+func (r shardScalingOpsHandler) getShardingSpec(cluster *appsv1alpha1.Cluster, shardingName string) *appsv1alpha1.ShardingSpec {
+	for i := range cluster.Spec.ShardingSpecs {
+		if cluster.Spec.ShardingSpecs[i].Name == shardingName {
+			return &cluster.Spec.ShardingSpecs[i]
+		}
+	}
+	return nil
+}
+
+func (r shardScalingOpsHandler) genShardActionJobName(opsRequest *appsv1alpha1.OpsRequest, actionType component.LifeCycleActionType, shardName string) string {
+	return common.CutString(strings.ToLower(fmt.Sprintf("%s-%s-%s", actionType, opsRequest.UID[:8], shardName)), 63)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("ShardScaling OpsRequest", func() {
+
+	var (
+		randomStr    = testCtx.GetRandomStr()
+		clusterName  = "cluster-for-ops-" + randomStr
+		shardingName = "shard"
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	initShardingResources := func(shards int, removingShard string) *OpsResource {
+		cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+			AddShardingSpecV2(shardingName, testapps.DefaultRedisCompDefName).
+			SetShards(int32(shards)).
+			Create(&testCtx).GetObject()
+		for _, name := range []string{"shard-a", "shard-b", "shard-c"}[:shards] {
+			factory := testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, name), "").
+				AddLabels(constant.AppInstanceLabelKey, clusterName).
+				AddLabels(constant.KBAppShardingNameLabelKey, shardingName).
+				SetReplicas(1)
+			if name == removingShard {
+				factory.AddAnnotations(constant.ShardRemovingAnnotationKey, "previous-ops")
+			}
+			factory.Create(&testCtx)
+		}
+		return &OpsResource{
+			Cluster:  cluster,
+			Recorder: k8sManager.GetEventRecorderFor("opsrequest-controller"),
+		}
+	}
+
+	createShardScalingOps := func(opsRes *OpsResource, shards int32) {
+		ops := testapps.NewOpsRequestObj("shard-scaling-"+randomStr, testCtx.DefaultNamespace,
+			clusterName, appsv1alpha1.ShardScalingType)
+		ops.Spec.ShardScalingList = []appsv1alpha1.ShardScaling{
+			{ShardingName: shardingName, Shards: shards},
+		}
+		opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+	}
+
+	Context("Test ShardScaling opsRequest", func() {
+		It("updates the shards when scaling out", func() {
+			opsRes := initShardingResources(2, "")
+			createShardScalingOps(opsRes, 3)
+
+			By("expect the shards of the cluster to be updated")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			Expect(shardScalingOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.ShardingSpecs[0].Shards).Should(BeEquivalentTo(3))
+			})).Should(Succeed())
+		})
+
+		It("marks the shards to remove and keeps the shards when scaling in", func() {
+			opsRes := initShardingResources(3, "shard-c")
+			createShardScalingOps(opsRes, 2)
+
+			By("expect the shard marked previously to be removed and the shards not to be updated until it is drained")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			Expect(shardScalingOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Name: constant.GenerateClusterComponentName(clusterName, "shard-c"),
+				Namespace: testCtx.DefaultNamespace}, func(g Gomega, comp *appsv1alpha1.Component) {
+				g.Expect(comp.Annotations[constant.ShardRemovingAnnotationKey]).Should(Equal(opsRes.OpsRequest.Name))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.ShardingSpecs[0].Shards).Should(BeEquivalentTo(3))
+			})).Should(Succeed())
+		})
+	})
+})
@@ -26,6 +26,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +36,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -90,11 +92,19 @@ func needDoSwitchover(ctx context.Context,
 		if err != nil {
 			return false, err
 		}
-		podParent, _ := intctrlutil.ParseParentNameAndOrdinal(pod.Name)
-		siParent, o := intctrlutil.ParseParentNameAndOrdinal(switchover.InstanceName)
-		if podParent != siParent || o < 0 || o >= int32(len(podList.Items)) {
+		// the instances may not share the same parent name, e.g. the ones of the instance templates
+		// and the blue/green update, so the candidate is checked against the pods of the component.
+		index := slices.IndexFunc(podList.Items, func(item corev1.Pod) bool { return item.Name == switchover.InstanceName })
+		if index < 0 {
 			return false, errors.New("switchover.InstanceName is invalid")
 		}
+		its, err := getComponentInstanceSet(ctx, cli, cluster, synthesizedComp.Name)
+		if err != nil {
+			return false, err
+		}
+		if its != nil && !instanceset.IsLeaderCandidate(its, &podList.Items[index]) {
+			return false, intctrlutil.NewFatalError(fmt.Sprintf(`instance "%s" is held and excluded from the leader candidates`, switchover.InstanceName))
+		}
 		// If the current instance is already the primary, then no switchover will be performed.
 		if pod.Name == switchover.InstanceName {
 			return false, nil
@@ -48,6 +48,13 @@ type OpsHandler interface {
 	SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
 }
 
+// OpsDryRunHandler is implemented by the OpsHandler which needs to render the changes made by other controllers
+// as a result of its Action in dry-run mode.
+type OpsDryRunHandler interface {
+	// DryRunAction is called instead of Action in dry-run mode, the client records the changes instead of applying them.
+	DryRunAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
+}
+
 type OpsBehaviour struct {
 	FromClusterPhases []appsv1alpha1.ClusterPhase
 
@@ -68,6 +75,14 @@ type OpsBehaviour struct {
 	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
 	QueueBySelf bool
 
+	// RespectMaintenanceWindow indicates that the operation is disruptive and only starts within the maintenance window
+	// declared by the OpsRequest or the Cluster.
+	RespectMaintenanceWindow bool
+
+	// SupportDryRun indicates that the Action of the operation only changes the objects through the given client,
+	// so that it can run in dry-run mode with a client which records the changes instead of applying them.
+	SupportDryRun bool
+
 	OpsHandler OpsHandler
 }
 
@@ -42,6 +42,8 @@ func init() {
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
 		OpsHandler:        upgradeOpsHandler{},
+
+		RespectMaintenanceWindow: true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -56,13 +58,17 @@ func (u upgradeOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx,
 // Action modifies Cluster.spec.clusterVersionRef with opsRequest.spec.upgrade.clusterVersionRef
 func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	opsRes.Cluster.Spec.ClusterVersionRef = opsRes.OpsRequest.Spec.Upgrade.ClusterVersionRef
+	// restrict the replicas to update before changing the cluster if the opsRequest is rolled out in steps.
+	if err := setRolloutPartitions(reqCtx, cli, opsRes, 0); err != nil {
+		return err
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
 // ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
 // the Reconcile function for upgrade opsRequest.
 func (u upgradeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
-	return reconcileActionWithComponentOps(reqCtx, cli, opsRes, "upgrade", nil, handleComponentStatusProgress)
+	return reconcileActionWithRollout(reqCtx, cli, opsRes, "upgrade", nil)
 }
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
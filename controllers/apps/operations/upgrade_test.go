@@ -20,12 +20,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
@@ -96,5 +101,60 @@ var _ = Describe("Upgrade OpsRequest", func() {
 			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("Test upgrade OpsRequest in steps", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, clusterObject := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+
+			By("create Upgrade Ops with rollout steps")
+			newClusterVersionName := "clusterversion-upgrade-" + randomStr
+			_ = testapps.NewClusterVersionFactory(newClusterVersionName, clusterDefinitionName).
+				AddComponentVersion(statelessComp).AddContainerShort(testapps.DefaultNginxContainerName, "nginx:1.14.2").
+				AddComponentVersion(consensusComp).AddContainerShort(testapps.DefaultMySQLContainerName, mysqlImageForUpdate).
+				AddComponentVersion(statefulComp).AddContainerShort(testapps.DefaultMySQLContainerName, mysqlImageForUpdate).
+				Create(&testCtx).GetObject()
+			ops := testapps.NewOpsRequestObj("upgrade-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterObject.Name, appsv1alpha1.UpgradeType)
+			ops.Spec.Upgrade = &appsv1alpha1.Upgrade{ClusterVersionRef: newClusterVersionName}
+			canaryReplicas := intstr.FromInt32(1)
+			ops.Spec.Rollout = &appsv1alpha1.OpsRollout{
+				Steps: []appsv1alpha1.OpsRolloutStep{
+					{Replicas: &canaryReplicas, PauseSeconds: 60},
+				},
+				ProgressDeadlineSeconds: 600,
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			mockComponentIsOperating(opsRes.Cluster, appsv1alpha1.UpdatingClusterCompPhase,
+				consensusComp, statelessComp, statefulComp)
+
+			By("expect the update partition of the InstanceSet is set to the first step")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+				g.Expect(fetched.Spec.UpdatePartition).ShouldNot(BeNil())
+				g.Expect(*fetched.Spec.UpdatePartition.Replicas).Should(BeEquivalentTo(1))
+			})).Should(Succeed())
+
+			By("mock the rollout step exceeds the progress deadline")
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+				opsRes.OpsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+				opsRes.OpsRequest.Status.ClusterGeneration = opsRes.Cluster.Generation + 1
+			})).ShouldNot(HaveOccurred())
+
+			By("expect the update partition is removed after the opsRequest fails")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+				g.Expect(fetched.Spec.UpdatePartition).Should(BeNil())
+			})).Should(Succeed())
+		})
 	})
 })
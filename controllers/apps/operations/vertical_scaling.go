@@ -43,6 +43,8 @@ func init() {
 		OpsHandler:        vsHandler,
 		QueueByCluster:    true,
 		CancelFunc:        vsHandler.Cancel,
+
+		RespectMaintenanceWindow: true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -66,13 +68,17 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 		component.Resources = verticalScaling.ResourceRequirements
 		opsRes.Cluster.Spec.ComponentSpecs[index] = component
 	}
+	// restrict the replicas to update before changing the cluster if the opsRequest is rolled out in steps.
+	if err := setRolloutPartitions(reqCtx, cli, opsRes, 0); err != nil {
+		return err
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
 // ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
 // the Reconcile function for vertical scaling opsRequest.
 func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
-	return reconcileActionWithComponentOps(reqCtx, cli, opsRes, "vertical scale", vs.syncOverrideByOps, handleComponentStatusProgress)
+	return reconcileActionWithRollout(reqCtx, cli, opsRes, "vertical scale", vs.syncOverrideByOps)
 }
 
 func (vs verticalScalingHandler) syncOverrideByOps(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
@@ -139,6 +145,10 @@ func (vs verticalScalingHandler) Cancel(reqCxt intctrlutil.RequestCtx, cli clien
 			return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel, `can not cancel the opsRequest due to another VerticalScaling opsRequest "%s" is running`, v.OverrideBy.OpsName)
 		}
 	}
+	// roll back all the replicas at once.
+	if err := removeRolloutPartitions(reqCxt, cli, opsRes); err != nil {
+		return err
+	}
 	return cancelComponentOps(reqCxt.Ctx, cli, opsRes, func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec) error {
 		comp.Resources = lastConfig.ResourceRequirements
 		return nil
@@ -28,9 +28,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -116,6 +118,126 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			testVerticalScaling(verticalScaling)
 		})
 
+		It("build the rollback opsRequest of the failed vertical scaling", func() {
+			verticalScaling := []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("400m"),
+							corev1.ResourceMemory: resource.MustParse("300Mi"),
+						},
+					},
+				},
+			}
+			opsRes := testVerticalScaling(verticalScaling)
+			opsRequest := opsRes.OpsRequest
+			lastConfiguration, ok := opsRequest.Status.LastConfiguration.Components[consensusComp]
+			Expect(ok).Should(BeTrue())
+
+			By("expect no rollback if spec.rollbackOnFailure is disabled")
+			opsRequest.Status.Phase = appsv1alpha1.OpsFailedPhase
+			Expect(NeedRollback(opsRequest)).Should(BeFalse())
+
+			By("expect the rollback opsRequest restores the last resources")
+			opsRequest.Spec.RollbackOnFailure = true
+			Expect(NeedRollback(opsRequest)).Should(BeTrue())
+			rollbackOps := BuildRollbackOpsRequest(opsRequest)
+			Expect(rollbackOps).ShouldNot(BeNil())
+			Expect(rollbackOps.Name).Should(Equal(opsRequest.GetRollbackOpsRequestName()))
+			Expect(rollbackOps.Labels[constant.OpsRequestRollbackOfLabelKey]).Should(Equal(opsRequest.Name))
+			Expect(rollbackOps.Spec.Type).Should(Equal(appsv1alpha1.VerticalScalingType))
+			Expect(rollbackOps.Spec.Force).Should(BeTrue())
+			Expect(rollbackOps.Spec.RollbackOnFailure).Should(BeFalse())
+			Expect(rollbackOps.Spec.VerticalScalingList).Should(HaveLen(1))
+			Expect(rollbackOps.Spec.VerticalScalingList[0].ComponentName).Should(Equal(consensusComp))
+			Expect(rollbackOps.Spec.VerticalScalingList[0].ResourceRequirements).Should(Equal(lastConfiguration.ResourceRequirements))
+
+			By("expect no rollback if the rollback opsRequest is created")
+			opsRequest.Status.RollbackOpsRequest = rollbackOps.Name
+			Expect(NeedRollback(opsRequest)).Should(BeFalse())
+		})
+
+		It("vertical scaling in steps", func() {
+			By("init operations resources")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+
+			By("create VerticalScaling ops with rollout steps")
+			ops := testapps.NewOpsRequestObj("vertical-scaling-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VerticalScalingType)
+			ops.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("400m"),
+						},
+					},
+				},
+			}
+			canaryReplicas := intstr.FromInt32(1)
+			ops.Spec.Rollout = &appsv1alpha1.OpsRollout{
+				Steps: []appsv1alpha1.OpsRolloutStep{
+					{Replicas: &canaryReplicas, Roles: []string{"follower"}, PauseSeconds: 60},
+				},
+				ProgressDeadlineSeconds: 600,
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("expect the update partition of the InstanceSet is set to the first step")
+			vsHandler := verticalScalingHandler{}
+			Expect(vsHandler.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+				g.Expect(fetched.Spec.UpdatePartition).ShouldNot(BeNil())
+				g.Expect(*fetched.Spec.UpdatePartition.Replicas).Should(BeEquivalentTo(1))
+				g.Expect(fetched.Spec.UpdatePartition.Roles).Should(Equal([]string{"follower"}))
+			})).Should(Succeed())
+
+			By("expect the current step is recorded in the progressDetails")
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+				opsRes.OpsRequest.Status.StartTimestamp = metav1.Now()
+				opsRes.OpsRequest.Status.ClusterGeneration = opsRes.Cluster.Generation + 1
+			})).ShouldNot(HaveOccurred())
+			phase, _, err := reconcileRollout(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(BeEmpty())
+			Expect(opsRes.OpsRequest.Status.Rollout).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.Rollout.CurrentStep).Should(BeEquivalentTo(0))
+			progressDetail := findActionProgress(opsRes.OpsRequest.Status.Components[consensusComp].ProgressDetails, rolloutActionName)
+			Expect(progressDetail).ShouldNot(BeNil())
+			Expect(progressDetail.Status).Should(Equal(appsv1alpha1.ProcessingProgressStatus))
+			Expect(progressDetail.Message).Should(ContainSubstring("rollout step 1/1"))
+
+			By("expect the rollout is aborted if the step exceeds the progress deadline")
+			opsRes.OpsRequest.Status.Rollout.StepStartTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			phase, _, err = reconcileRollout(reqCtx, k8sClient, opsRes)
+			Expect(err).Should(HaveOccurred())
+			Expect(phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+
+			By("expect the update partition is removed after the opsRequest fails")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+				g.Expect(fetched.Spec.UpdatePartition).Should(BeNil())
+			})).Should(Succeed())
+
+			By("expect the update partition is removed after cancelling")
+			Expect(setRolloutPartitions(reqCtx, k8sClient, opsRes, 0)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+				g.Expect(fetched.Spec.UpdatePartition).ShouldNot(BeNil())
+			})).Should(Succeed())
+			Expect(vsHandler.Cancel(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+				g.Expect(fetched.Spec.UpdatePartition).Should(BeNil())
+			})).Should(Succeed())
+		})
+
 		It("cancel vertical scaling opsRequest", func() {
 			By("init operations resources with CLusterDefinition/ClusterVersion/Hybrid components Cluster/consensus Pods")
 			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
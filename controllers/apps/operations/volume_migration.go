@@ -0,0 +1,391 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type volumeMigrationOpsHandler struct {
+	// the instances are migrated by rebuilding them onto the new volumes.
+	rebuildHandler rebuildInstanceOpsHandler
+}
+
+var _ OpsHandler = volumeMigrationOpsHandler{}
+
+const volumeMigrationRequeueAfter = 5 * time.Second
+
+func init() {
+	volumeMigrationBehaviour := OpsBehaviour{
+		FromClusterPhases:        appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:           appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:           true,
+		RespectMaintenanceWindow: true,
+		OpsHandler:               volumeMigrationOpsHandler{},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.VolumeMigrationType, volumeMigrationBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the volume migration request.
+func (r volumeMigrationOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewVolumeMigratingCondition(opsRes.OpsRequest), nil
+}
+
+// Action modifies the storageClassName and storage of Cluster.spec.components[*].volumeClaimTemplates[*].
+// the new volumes of the instances will be built with the updated volumeClaimTemplates.
+func (r volumeMigrationOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	volumeMigrationMap := r.getVolumeMigrationMap(opsRes.OpsRequest)
+	for index := range opsRes.Cluster.Spec.ComponentSpecs {
+		compSpec := &opsRes.Cluster.Spec.ComponentSpecs[index]
+		volumeMigration, ok := volumeMigrationMap[compSpec.Name]
+		if !ok {
+			continue
+		}
+		for _, v := range volumeMigration.VolumeClaimTemplates {
+			for i := range compSpec.VolumeClaimTemplates {
+				vct := &compSpec.VolumeClaimTemplates[i]
+				if vct.Name != v.Name {
+					continue
+				}
+				if v.StorageClassName != nil {
+					vct.Spec.StorageClassName = v.StorageClassName
+				}
+				if v.Storage != nil {
+					if vct.Spec.Resources.Requests == nil {
+						vct.Spec.Resources.Requests = corev1.ResourceList{}
+					}
+					vct.Spec.Resources.Requests[corev1.ResourceStorage] = *v.Storage
+				}
+			}
+		}
+	}
+	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+}
+
+// SaveLastConfiguration records the storage size of the volumeClaimTemplates to migrate.
+func (r volumeMigrationOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	volumeMigrationMap := r.getVolumeMigrationMap(opsRes.OpsRequest)
+	lastComponentInfo := map[string]appsv1alpha1.LastComponentConfiguration{}
+	for _, v := range opsRes.Cluster.Spec.ComponentSpecs {
+		volumeMigration, ok := volumeMigrationMap[v.Name]
+		if !ok {
+			continue
+		}
+		lastVCTs := make([]appsv1alpha1.OpsRequestVolumeClaimTemplate, 0)
+		for _, vct := range v.VolumeClaimTemplates {
+			if !slices.ContainsFunc(volumeMigration.VolumeClaimTemplates, func(t appsv1alpha1.VolumeMigrationTemplate) bool {
+				return t.Name == vct.Name
+			}) {
+				continue
+			}
+			lastVCTs = append(lastVCTs, appsv1alpha1.OpsRequestVolumeClaimTemplate{
+				Name:    vct.Name,
+				Storage: vct.Spec.Resources.Requests[corev1.ResourceStorage],
+			})
+		}
+		lastComponentInfo[v.Name] = appsv1alpha1.LastComponentConfiguration{
+			VolumeClaimTemplates: lastVCTs,
+		}
+	}
+	opsRes.OpsRequest.Status.LastConfiguration.Components = lastComponentInfo
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the instances are migrated one at a time, and the leader of each component is switched over and migrated last.
+func (r volumeMigrationOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+		expectCount     int
+		completedCount  int
+		failedCount     int
+		processing      bool
+	)
+	if opsRes.OpsRequest.Status.Components == nil {
+		opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, v := range opsRes.OpsRequest.Spec.VolumeMigrationList {
+		compStatus := opsRes.OpsRequest.Status.Components[v.ComponentName]
+		comp := opsRes.Cluster.Spec.GetComponentByName(v.ComponentName)
+		synthesizedComp, err := component.BuildSynthesizedComponentWrapper(reqCtx, cli, opsRes.Cluster, comp)
+		if err != nil {
+			return opsRequestPhase, 0, err
+		}
+		if len(compStatus.ProgressDetails) == 0 {
+			if compStatus.ProgressDetails, err = r.initProgressDetails(reqCtx, cli, opsRes, synthesizedComp); err != nil {
+				return opsRequestPhase, 0, err
+			}
+		}
+		for i := range compStatus.ProgressDetails {
+			expectCount += 1
+			progressDetail := compStatus.ProgressDetails[i]
+			if isCompletedProgressStatus(progressDetail.Status) {
+				completedCount += 1
+				if progressDetail.Status == appsv1alpha1.FailedProgressStatus {
+					failedCount += 1
+				}
+				continue
+			}
+			// only one instance is migrated at a time, and stops migrating the rest if any instance fails.
+			if processing || failedCount > 0 {
+				continue
+			}
+			processing = true
+			completed, err := r.migrateInstance(reqCtx, cli, opsRes, comp, synthesizedComp, v, compStatus, &progressDetail, i)
+			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, err.Error())
+				setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+				completedCount += 1
+				failedCount += 1
+				processing = false
+				continue
+			}
+			if err != nil {
+				return opsRequestPhase, 0, err
+			}
+			if completed {
+				progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+					fmt.Sprintf("Migrate the volumes of pod %s successfully", r.getInstanceName(progressDetail)))
+				completedCount += 1
+				// migrate the next instance in the next reconciliation.
+			}
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+		}
+		opsRes.OpsRequest.Status.Components[v.ComponentName] = compStatus
+	}
+	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if failedCount > 0 && !processing {
+		return appsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	if completedCount != expectCount {
+		// the switchover job is not owned by the opsRequest, requeue to check it.
+		return opsRequestPhase, volumeMigrationRequeueAfter, nil
+	}
+	return appsv1alpha1.OpsSucceedPhase, 0, r.rebuildHandler.cleanupTmpResources(reqCtx, cli, opsRes)
+}
+
+// initProgressDetails plans the migration order of the instances, the serviceable and writable instance is migrated last.
+func (r volumeMigrationOpsHandler) initProgressDetails(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	synthesizedComp *component.SynthesizedComponent) ([]appsv1alpha1.ProgressStatusDetail, error) {
+	podList, err := component.GetComponentPodList(reqCtx.Ctx, cli, *opsRes.Cluster, synthesizedComp.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(podList.Items) == 0 {
+		return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeExpectedInProcess, "waiting for the pods of component %s to be created", synthesizedComp.Name)
+	}
+	pods := podList.Items
+	slices.SortStableFunc(pods, func(a, b corev1.Pod) int {
+		aIsLeader, bIsLeader := r.isServiceableNWritablePod(synthesizedComp, &a), r.isServiceableNWritablePod(synthesizedComp, &b)
+		if aIsLeader != bIsLeader {
+			if aIsLeader {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	progressDetails := make([]appsv1alpha1.ProgressStatusDetail, 0, len(pods))
+	for _, pod := range pods {
+		progressDetails = append(progressDetails, appsv1alpha1.ProgressStatusDetail{
+			ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name),
+			Status:    appsv1alpha1.PendingProgressStatus,
+			Message:   fmt.Sprintf("Waiting to migrate the volumes of pod %s", pod.Name),
+		})
+	}
+	return progressDetails, nil
+}
+
+// migrateInstance switches over the instance if it is the leader, and then rebuilds it onto the new volumes.
+func (r volumeMigrationOpsHandler) migrateInstance(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	comp *appsv1alpha1.ClusterComponentSpec,
+	synthesizedComp *component.SynthesizedComponent,
+	volumeMigration appsv1alpha1.VolumeMigration,
+	compStatus appsv1alpha1.OpsRequestComponentStatus,
+	progressDetail *appsv1alpha1.ProgressStatusDetail,
+	index int) (bool, error) {
+	instance := appsv1alpha1.Instance{Name: r.getInstanceName(*progressDetail)}
+	if progressDetail.Status == appsv1alpha1.PendingProgressStatus {
+		progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+			fmt.Sprintf("Start to migrate the volumes of pod %s", instance.Name))
+	}
+	if progressDetail.Message != waitingForInstanceReadyMessage {
+		// the instance has not been recreated yet, make sure it is not the leader.
+		switchedOver, err := r.switchoverLeader(reqCtx, cli, opsRes, synthesizedComp, compStatus, progressDetail, instance.Name)
+		if err != nil || !switchedOver {
+			return false, err
+		}
+	}
+	insHelper, err := r.rebuildHandler.prepareInstanceHelper(reqCtx, cli, opsRes, comp,
+		volumeMigration.EnvForRestore, instance, volumeMigration.BackupName, index)
+	if err != nil {
+		return false, err
+	}
+	r.filterMigratedVolumes(insHelper, volumeMigration)
+	// the new volumes must be seeded with the data, the instance can not be rebuilt with empty volumes.
+	switch {
+	case volumeMigration.FromPeer:
+		rebuildFrom := appsv1alpha1.RebuildInstance{
+			ComponentOps:  volumeMigration.ComponentOps,
+			Instances:     []appsv1alpha1.Instance{instance},
+			FromPeer:      true,
+			EnvForRestore: volumeMigration.EnvForRestore,
+		}
+		return r.rebuildHandler.rebuildInstanceFromPeer(reqCtx, cli, opsRes, insHelper, rebuildFrom, progressDetail)
+	case volumeMigration.BackupName != "":
+		return r.rebuildHandler.rebuildInstanceWithBackup(reqCtx, cli, opsRes, insHelper, progressDetail)
+	default:
+		return false, intctrlutil.NewFatalError(fmt.Sprintf("either backupName or fromPeer must be specified to migrate the volumes of component: %s",
+			volumeMigration.ComponentName))
+	}
+}
+
+// switchoverLeader switches over the leader to another instance before migrating it.
+// it returns true if the instance is not the leader or the leader can not be switched over.
+func (r volumeMigrationOpsHandler) switchoverLeader(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	synthesizedComp *component.SynthesizedComponent,
+	compStatus appsv1alpha1.OpsRequestComponentStatus,
+	progressDetail *appsv1alpha1.ProgressStatusDetail,
+	podName string) (bool, error) {
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: podName, Namespace: opsRes.Cluster.Namespace}, pod); err != nil {
+		return false, err
+	}
+	jobName := genSwitchoverJobName(opsRes.Cluster.Name, synthesizedComp.Name, opsRes.Cluster.Generation)
+	job := &batchv1.Job{}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, cli, types.NamespacedName{Name: jobName, Namespace: opsRes.Cluster.Namespace}, job)
+	if err != nil {
+		return false, err
+	}
+	switchover := r.buildSwitchover(synthesizedComp, compStatus)
+	if !r.isServiceableNWritablePod(synthesizedComp, pod) || synthesizedComp.Replicas <= 1 || switchover == nil {
+		if exists && job.Spec.TTLSecondsAfterFinished == nil {
+			return true, component.CleanJobByName(reqCtx.Ctx, cli, opsRes.Cluster, jobName)
+		}
+		return true, nil
+	}
+	if !exists {
+		progressDetail.Message = fmt.Sprintf("Switching over the leader %s before migrating its volumes", podName)
+		return false, createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, switchover)
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			return false, intctrlutil.NewFatalError(fmt.Sprintf(`the switchover job "%s" of the leader "%s" is failed`, jobName, podName))
+		}
+	}
+	progressDetail.Message = fmt.Sprintf(`Waiting for the leader "%s" to be switched over`, podName)
+	return false, nil
+}
+
+// buildSwitchover builds the switchover of the component, the candidate is an instance which has been migrated if possible.
+// it returns nil if the component does not support switchover.
+func (r volumeMigrationOpsHandler) buildSwitchover(synthesizedComp *component.SynthesizedComponent,
+	compStatus appsv1alpha1.OpsRequestComponentStatus) *appsv1alpha1.Switchover {
+	if synthesizedComp.LifecycleActions == nil || synthesizedComp.LifecycleActions.Switchover == nil {
+		return nil
+	}
+	switchoverSpec := synthesizedComp.LifecycleActions.Switchover
+	switchover := &appsv1alpha1.Switchover{
+		ComponentOps: appsv1alpha1.ComponentOps{ComponentName: synthesizedComp.Name},
+	}
+	if switchoverSpec.WithCandidate != nil {
+		for _, progressDetail := range compStatus.ProgressDetails {
+			if progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+				switchover.InstanceName = r.getInstanceName(progressDetail)
+				return switchover
+			}
+		}
+	}
+	if switchoverSpec.WithoutCandidate != nil {
+		switchover.InstanceName = KBSwitchoverCandidateInstanceForAnyPod
+		return switchover
+	}
+	return nil
+}
+
+// filterMigratedVolumes only rebuilds the volumes of the volumeClaimTemplates to migrate.
+func (r volumeMigrationOpsHandler) filterMigratedVolumes(insHelper *instanceHelper, volumeMigration appsv1alpha1.VolumeMigration) {
+	isMigrated := func(vctName string) bool {
+		return slices.ContainsFunc(volumeMigration.VolumeClaimTemplates, func(t appsv1alpha1.VolumeMigrationTemplate) bool {
+			return t.Name == vctName
+		})
+	}
+	for sourcePVCName, tmpPVC := range insHelper.pvcMap {
+		if !isMigrated(tmpPVC.Labels[constant.VolumeClaimTemplateNameLabelKey]) {
+			delete(insHelper.pvcMap, sourcePVCName)
+		}
+	}
+	insHelper.volumes = slices.DeleteFunc(insHelper.volumes, func(v corev1.Volume) bool {
+		return !isMigrated(v.Name)
+	})
+	insHelper.volumeMounts = slices.DeleteFunc(insHelper.volumeMounts, func(v corev1.VolumeMount) bool {
+		return !isMigrated(v.Name)
+	})
+}
+
+// isServiceableNWritablePod checks if the pod is the serviceable and writable instance, such as the leader or primary.
+func (r volumeMigrationOpsHandler) isServiceableNWritablePod(synthesizedComp *component.SynthesizedComponent, pod *corev1.Pod) bool {
+	roleName, ok := pod.Labels[constant.RoleLabelKey]
+	if !ok {
+		return false
+	}
+	for _, role := range synthesizedComp.Roles {
+		if role.Name == roleName && role.Serviceable && role.Writable {
+			return true
+		}
+	}
+	return false
+}
+
+func (r volumeMigrationOpsHandler) getInstanceName(progressDetail appsv1alpha1.ProgressStatusDetail) string {
+	return strings.TrimPrefix(progressDetail.ObjectKey, constant.PodKind+"/")
+}
+
+func (r volumeMigrationOpsHandler) getVolumeMigrationMap(opsRequest *appsv1alpha1.OpsRequest) map[string]appsv1alpha1.VolumeMigration {
+	volumeMigrationMap := make(map[string]appsv1alpha1.VolumeMigration)
+	for _, v := range opsRequest.Spec.VolumeMigrationList {
+		volumeMigrationMap[v.ComponentName] = v
+	}
+	return volumeMigrationMap
+}
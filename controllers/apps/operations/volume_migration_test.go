@@ -0,0 +1,132 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("VolumeMigration OpsRequest", func() {
+
+	var (
+		randomStr             = testCtx.GetRandomStr()
+		clusterDefinitionName = "cluster-definition-for-ops-" + randomStr
+		clusterVersionName    = "clusterversion-for-ops-" + randomStr
+		clusterName           = "cluster-for-ops-" + randomStr
+		storageClassName      = "new-sc-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		// default GracePeriod is 30s
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("Test VolumeMigration opsRequest", func() {
+		It("migrates the followers one at a time and the leader last", func() {
+			By("init operations resources")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			podList := initConsensusPods(ctx, k8sClient, opsRes, clusterName)
+			for i := range podList {
+				pvcName := fmt.Sprintf("%s-%s", testapps.DataVolumeName, podList[i].Name)
+				testapps.NewPersistentVolumeClaimFactory(podList[i].Namespace, pvcName, clusterName, consensusComp, testapps.DataVolumeName).
+					SetStorage("20Gi").Create(&testCtx)
+			}
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("create VolumeMigration opsRequest")
+			storage := resource.MustParse("10Gi")
+			ops := testapps.NewOpsRequestObj("volume-migration-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VolumeMigrationType)
+			ops.Spec.VolumeMigrationList = []appsv1alpha1.VolumeMigration{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					VolumeClaimTemplates: []appsv1alpha1.VolumeMigrationTemplate{
+						{Name: testapps.DataVolumeName, StorageClassName: &storageClassName, Storage: &storage},
+					},
+					FromPeer: true,
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsCreatingPhase
+
+			By("expect the volumeClaimTemplate of the cluster to be updated")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				vct := cluster.Spec.GetComponentByName(consensusComp).VolumeClaimTemplates[0]
+				g.Expect(*vct.Spec.StorageClassName).Should(Equal(storageClassName))
+				g.Expect(vct.Spec.Resources.Requests.Storage().Cmp(storage)).Should(Equal(0))
+			})).Should(Succeed())
+
+			By("expect the leader to be migrated last and only the first follower is migrated")
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			progressDetails := opsRes.OpsRequest.Status.Components[consensusComp].ProgressDetails
+			Expect(progressDetails).Should(HaveLen(len(podList)))
+			lastPod := &corev1.Pod{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: volumeMigrationOpsHandler{}.getInstanceName(progressDetails[len(podList)-1]),
+				Namespace: testCtx.DefaultNamespace}, lastPod)).Should(Succeed())
+			Expect(lastPod.Labels[constant.RoleLabelKey]).Should(Equal("leader"))
+			Expect(progressDetails[0].Status).Should(Equal(appsv1alpha1.FailedProgressStatus))
+			for _, progressDetail := range progressDetails[1:] {
+				Expect(progressDetail.Status).Should(Equal(appsv1alpha1.PendingProgressStatus))
+			}
+
+			By("expect the follower is not recreated with empty volumes as the component can not stream the data from a peer")
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			tmpPVCName := fmt.Sprintf("rebuild-%s-%s-%d", opsRes.OpsRequest.UID[:8], common.CutString(consensusComp+"-"+testapps.DataVolumeName, 30), 0)
+			Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKey{Name: tmpPVCName, Namespace: testCtx.DefaultNamespace},
+				&corev1.PersistentVolumeClaim{}, false)).Should(Succeed())
+		})
+	})
+})
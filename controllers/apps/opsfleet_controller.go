@@ -0,0 +1,296 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// OpsFleetReconciler reconciles a OpsFleet object
+type OpsFleetReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsfleets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsfleets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsfleets/finalizers,verbs=update
+
+// Reconcile fans out the OpsRequest template to the selected clusters and aggregates the progress of the OpsRequests.
+// At most spec.maxConcurrency OpsRequests run at the same time, and each of them is processed by the OpsRequest
+// controller like any other OpsRequest.
+func (r *OpsFleetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("opsFleet", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	fleet := &appsv1alpha1.OpsFleet{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, fleet); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// the OpsRequests of the clusters are garbage collected by the owner reference.
+	if !fleet.DeletionTimestamp.IsZero() || fleet.IsComplete() {
+		return intctrlutil.Reconciled()
+	}
+
+	statusPatch := client.MergeFrom(fleet.DeepCopy())
+	if err := r.runFleet(reqCtx, fleet); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err := r.Client.Status().Patch(reqCtx.Ctx, fleet, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// runFleet syncs the status of the created OpsRequests, then creates the OpsRequests for the rest clusters
+// as long as the concurrency and the failure budget allow.
+func (r *OpsFleetReconciler) runFleet(reqCtx intctrlutil.RequestCtx, fleet *appsv1alpha1.OpsFleet) error {
+	if fleet.Status.Phase == "" {
+		fleet.Status.Phase = appsv1alpha1.OpsPendingPhase
+	}
+	if len(fleet.Status.Clusters) == 0 {
+		clusterNames, err := r.selectClusters(reqCtx, fleet)
+		if err != nil {
+			return err
+		}
+		if len(clusterNames) == 0 {
+			r.completeFleet(fleet, appsv1alpha1.OpsFailedPhase, "no cluster matches the spec.clusterSelector")
+			return nil
+		}
+		for _, clusterName := range clusterNames {
+			fleet.Status.Clusters = append(fleet.Status.Clusters, appsv1alpha1.OpsFleetClusterStatus{
+				ClusterName:    clusterName,
+				OpsRequestName: fleet.GetClusterOpsRequestName(clusterName),
+			})
+		}
+	}
+
+	var (
+		total          = len(fleet.Status.Clusters)
+		completedCount int
+		failedCount    int
+		runningCount   int
+	)
+	defer func() {
+		fleet.Status.Progress = fmt.Sprintf("%d/%d", completedCount, total)
+		fleet.Status.FailedCount = int32(failedCount)
+	}()
+	for i := range fleet.Status.Clusters {
+		clusterStatus := &fleet.Status.Clusters[i]
+		if clusterStatus.Phase == "" {
+			continue
+		}
+		if err := r.syncClusterStatus(reqCtx, fleet, clusterStatus); err != nil {
+			return err
+		}
+		switch clusterStatus.Phase {
+		case appsv1alpha1.OpsSucceedPhase, appsv1alpha1.OpsDryRunCompletedPhase:
+			completedCount++
+		case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+			completedCount++
+			failedCount++
+		default:
+			runningCount++
+		}
+	}
+
+	if failedCount > int(fleet.Spec.FailureBudget) {
+		// stop creating new OpsRequests and wait for the running ones to complete.
+		if runningCount == 0 {
+			r.completeFleet(fleet, appsv1alpha1.OpsFailedPhase, fmt.Sprintf("%d OpsRequests failed, which exceeds the failure budget %d",
+				failedCount, fleet.Spec.FailureBudget))
+		}
+		return nil
+	}
+	maxConcurrency := int(fleet.Spec.MaxConcurrency)
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	for i := range fleet.Status.Clusters {
+		clusterStatus := &fleet.Status.Clusters[i]
+		if runningCount >= maxConcurrency {
+			break
+		}
+		if clusterStatus.Phase != "" {
+			continue
+		}
+		if err := r.createClusterOpsRequest(reqCtx, fleet, clusterStatus); err != nil {
+			return err
+		}
+		runningCount++
+	}
+	if completedCount == total {
+		message := fmt.Sprintf("all OpsRequests of %d clusters are completed", total)
+		if failedCount > 0 {
+			message = fmt.Sprintf("%s, %d of them failed within the failure budget", message, failedCount)
+		}
+		r.completeFleet(fleet, appsv1alpha1.OpsSucceedPhase, message)
+		return nil
+	}
+	if fleet.Status.Phase == appsv1alpha1.OpsPendingPhase {
+		fleet.Status.Phase = appsv1alpha1.OpsRunningPhase
+		fleet.Status.StartTimestamp = metav1.Now()
+	}
+	return nil
+}
+
+// selectClusters lists the names of the clusters matched by the spec.clusterSelector in order.
+func (r *OpsFleetReconciler) selectClusters(reqCtx intctrlutil.RequestCtx, fleet *appsv1alpha1.OpsFleet) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&fleet.Spec.ClusterSelector)
+	if err != nil {
+		return nil, err
+	}
+	clusterList := &appsv1alpha1.ClusterList{}
+	if err = r.Client.List(reqCtx.Ctx, clusterList, client.InNamespace(fleet.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	var clusterNames []string
+	for _, cluster := range clusterList.Items {
+		if !cluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+		clusterNames = append(clusterNames, cluster.Name)
+	}
+	sort.Strings(clusterNames)
+	return clusterNames, nil
+}
+
+// syncClusterStatus syncs the phase and the progress of the OpsRequest to the cluster status.
+func (r *OpsFleetReconciler) syncClusterStatus(reqCtx intctrlutil.RequestCtx,
+	fleet *appsv1alpha1.OpsFleet,
+	clusterStatus *appsv1alpha1.OpsFleetClusterStatus) error {
+	if isOpsPhaseCompleted(clusterStatus.Phase) {
+		return nil
+	}
+	opsRequest := &appsv1alpha1.OpsRequest{}
+	err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: clusterStatus.OpsRequestName, Namespace: fleet.Namespace}, opsRequest)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if apierrors.IsNotFound(err) {
+		// the OpsRequest has been deleted before it completed.
+		clusterStatus.Phase = appsv1alpha1.OpsFailedPhase
+		clusterStatus.CompletionTimestamp = metav1.Now()
+		clusterStatus.Message = fmt.Sprintf(`OpsRequest "%s" is deleted before it completed`, clusterStatus.OpsRequestName)
+		return nil
+	}
+	if opsRequest.Status.Phase != "" {
+		clusterStatus.Phase = opsRequest.Status.Phase
+	}
+	clusterStatus.Progress = opsRequest.Status.Progress
+	if opsRequest.IsComplete() {
+		clusterStatus.CompletionTimestamp = opsRequest.Status.CompletionTimestamp
+	}
+	if opsRequest.IsComplete() && !isOpsSucceed(opsRequest.Status.Phase) {
+		clusterStatus.Message = getOpsRequestFailedMessage(opsRequest)
+	}
+	return nil
+}
+
+// createClusterOpsRequest creates the OpsRequest of the cluster from the template, which is owned by the OpsFleet.
+func (r *OpsFleetReconciler) createClusterOpsRequest(reqCtx intctrlutil.RequestCtx,
+	fleet *appsv1alpha1.OpsFleet,
+	clusterStatus *appsv1alpha1.OpsFleetClusterStatus) error {
+	// the OpsRequest inherits the labels of the OpsFleet.
+	labels := map[string]string{}
+	for k, v := range fleet.Labels {
+		labels[k] = v
+	}
+	labels[constant.OpsFleetNameLabelKey] = fleet.Name
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterStatus.OpsRequestName,
+			Namespace: fleet.Namespace,
+			Labels:    labels,
+			// the OpsRequest is requested by the user who creates the OpsFleet.
+			Annotations: map[string]string{
+				constant.OpsRequestRequestedByAnnotationKey: fleet.Annotations[constant.OpsRequestRequestedByAnnotationKey],
+			},
+		},
+		Spec: *fleet.Spec.Template.DeepCopy(),
+	}
+	opsRequest.Spec.ClusterRef = clusterStatus.ClusterName
+	if err := controllerutil.SetControllerReference(fleet, opsRequest, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Client.Create(reqCtx.Ctx, opsRequest); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	clusterStatus.Phase = appsv1alpha1.OpsPendingPhase
+	clusterStatus.StartTimestamp = metav1.Now()
+	r.Recorder.Eventf(fleet, corev1.EventTypeNormal, reasonOpsFleetClusterStarted,
+		`OpsRequest "%s" is created for cluster "%s"`, clusterStatus.OpsRequestName, clusterStatus.ClusterName)
+	return nil
+}
+
+// completeFleet sets the OpsFleet to the completed phase.
+func (r *OpsFleetReconciler) completeFleet(fleet *appsv1alpha1.OpsFleet, phase appsv1alpha1.OpsPhase, message string) {
+	condition := metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeSucceed,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonOpsFleetSucceed,
+		Message: message,
+	}
+	eventType := corev1.EventTypeNormal
+	if phase == appsv1alpha1.OpsFailedPhase {
+		condition.Type = appsv1alpha1.ConditionTypeFailed
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonOpsFleetFailed
+		eventType = corev1.EventTypeWarning
+	}
+	if fleet.Status.StartTimestamp.IsZero() {
+		fleet.Status.StartTimestamp = metav1.Now()
+	}
+	fleet.Status.Phase = phase
+	fleet.Status.CompletionTimestamp = metav1.Now()
+	meta.SetStatusCondition(&fleet.Status.Conditions, condition)
+	r.Recorder.Event(fleet, eventType, condition.Reason, message)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpsFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.OpsFleet{}).
+		Owns(&appsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
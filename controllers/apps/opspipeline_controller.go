@@ -0,0 +1,288 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// OpsPipelineReconciler reconciles a OpsPipeline object
+type OpsPipelineReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opspipelines,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opspipelines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opspipelines/finalizers,verbs=update
+
+// Reconcile runs the steps of the OpsPipeline one by one.
+// The OpsRequest of a step is created only after the OpsRequest of the previous step completes,
+// then it is queued and processed by the OpsRequest controller like any other OpsRequest.
+func (r *OpsPipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("opsPipeline", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	pipeline := &appsv1alpha1.OpsPipeline{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, pipeline); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// the OpsRequests of the steps are garbage collected by the owner reference.
+	if !pipeline.DeletionTimestamp.IsZero() || pipeline.IsComplete() {
+		return intctrlutil.Reconciled()
+	}
+
+	statusPatch := client.MergeFrom(pipeline.DeepCopy())
+	if err := r.runSteps(reqCtx, pipeline); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err := r.Client.Status().Patch(reqCtx.Ctx, pipeline, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// runSteps walks through the steps in order and updates the status of the OpsPipeline.
+// it stops at the first step which is not completed.
+func (r *OpsPipelineReconciler) runSteps(reqCtx intctrlutil.RequestCtx, pipeline *appsv1alpha1.OpsPipeline) error {
+	if pipeline.Status.Phase == "" {
+		pipeline.Status.Phase = appsv1alpha1.OpsPendingPhase
+	}
+	var (
+		completedSteps int
+		failedSteps    []string
+	)
+	defer func() {
+		pipeline.Status.Progress = fmt.Sprintf("%d/%d", completedSteps, len(pipeline.Spec.Steps))
+	}()
+	for _, step := range pipeline.Spec.Steps {
+		stepStatus, err := r.syncStepStatus(reqCtx, pipeline, step)
+		if err != nil {
+			return err
+		}
+		switch stepStatus.Phase {
+		case appsv1alpha1.OpsSucceedPhase, appsv1alpha1.OpsDryRunCompletedPhase:
+			completedSteps++
+			continue
+		case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+			completedSteps++
+			if step.FailurePolicy == appsv1alpha1.OpsPipelineContinueOnFailure {
+				failedSteps = append(failedSteps, step.Name)
+				continue
+			}
+			r.completePipeline(pipeline, appsv1alpha1.OpsFailedPhase,
+				fmt.Sprintf(`step "%s" is %s: %s`, step.Name, stepStatus.Phase, stepStatus.Message))
+			return nil
+		}
+		// the step is in progress, wait for the OpsRequest to complete.
+		if pipeline.Status.Phase == appsv1alpha1.OpsPendingPhase {
+			pipeline.Status.Phase = appsv1alpha1.OpsRunningPhase
+			pipeline.Status.StartTimestamp = metav1.Now()
+		}
+		return nil
+	}
+	message := fmt.Sprintf("all %d steps are completed", len(pipeline.Spec.Steps))
+	if len(failedSteps) > 0 {
+		message = fmt.Sprintf("%s, the failed steps %s are skipped", message, strings.Join(failedSteps, ","))
+	}
+	r.completePipeline(pipeline, appsv1alpha1.OpsSucceedPhase, message)
+	return nil
+}
+
+// syncStepStatus creates the OpsRequest of the step if it does not exist, and syncs its phase to the step status.
+func (r *OpsPipelineReconciler) syncStepStatus(reqCtx intctrlutil.RequestCtx,
+	pipeline *appsv1alpha1.OpsPipeline,
+	step appsv1alpha1.OpsPipelineStep) (*appsv1alpha1.OpsPipelineStepStatus, error) {
+	stepStatus := pipeline.GetStepStatus(step.Name)
+	if stepStatus == nil {
+		pipeline.Status.Steps = append(pipeline.Status.Steps, appsv1alpha1.OpsPipelineStepStatus{
+			Name:           step.Name,
+			OpsRequestName: pipeline.GetStepOpsRequestName(step.Name),
+		})
+		stepStatus = &pipeline.Status.Steps[len(pipeline.Status.Steps)-1]
+	}
+	if isOpsPhaseCompleted(stepStatus.Phase) {
+		return stepStatus, nil
+	}
+	opsRequest := &appsv1alpha1.OpsRequest{}
+	err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: stepStatus.OpsRequestName, Namespace: pipeline.Namespace}, opsRequest)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if apierrors.IsNotFound(err) {
+		if stepStatus.Phase != "" {
+			// the OpsRequest has been deleted before it completed.
+			stepStatus.Phase = appsv1alpha1.OpsFailedPhase
+			stepStatus.CompletionTimestamp = metav1.Now()
+			stepStatus.Message = fmt.Sprintf(`OpsRequest "%s" is deleted before it completed`, stepStatus.OpsRequestName)
+			return stepStatus, nil
+		}
+		if err = r.createStepOpsRequest(reqCtx, pipeline, step, stepStatus.OpsRequestName); err != nil {
+			return nil, err
+		}
+		stepStatus.Phase = appsv1alpha1.OpsPendingPhase
+		stepStatus.StartTimestamp = metav1.Now()
+		return stepStatus, nil
+	}
+	if !isStepOpsRequest(pipeline, step, opsRequest) {
+		// an unrelated OpsRequest has the name of the step, it is never adopted.
+		stepStatus.Phase = appsv1alpha1.OpsFailedPhase
+		stepStatus.CompletionTimestamp = metav1.Now()
+		stepStatus.Message = fmt.Sprintf(`OpsRequest "%s" already exists and is not created for the step`, stepStatus.OpsRequestName)
+		return stepStatus, nil
+	}
+	if opsRequest.Status.Phase != "" {
+		stepStatus.Phase = opsRequest.Status.Phase
+	}
+	if opsRequest.IsComplete() {
+		stepStatus.CompletionTimestamp = opsRequest.Status.CompletionTimestamp
+	}
+	if opsRequest.IsComplete() && !isOpsSucceed(opsRequest.Status.Phase) {
+		stepStatus.Message = getOpsRequestFailedMessage(opsRequest)
+	}
+	return stepStatus, nil
+}
+
+// isOpsPhaseCompleted checks if the phase is one of the terminal phases of the OpsRequest.
+func isOpsPhaseCompleted(phase appsv1alpha1.OpsPhase) bool {
+	return (&appsv1alpha1.OpsRequest{}).IsComplete(phase)
+}
+
+// isOpsSucceed checks if the OpsRequest is completed without failure, the dry-run completion is counted as success.
+func isOpsSucceed(phase appsv1alpha1.OpsPhase) bool {
+	return phase == appsv1alpha1.OpsSucceedPhase || phase == appsv1alpha1.OpsDryRunCompletedPhase
+}
+
+// getOpsRequestFailedMessage gets the message of the condition which makes the OpsRequest not succeed.
+func getOpsRequestFailedMessage(opsRequest *appsv1alpha1.OpsRequest) string {
+	for _, condType := range []string{appsv1alpha1.ConditionTypeFailed, appsv1alpha1.ConditionTypeValidated,
+		appsv1alpha1.ConditionTypeDependencies, appsv1alpha1.ConditionTypeCancelled} {
+		condition := meta.FindStatusCondition(opsRequest.Status.Conditions, condType)
+		if condition != nil && (condition.Status == metav1.ConditionFalse || condType == appsv1alpha1.ConditionTypeCancelled) {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+// createStepOpsRequest creates the OpsRequest of the step, which is owned by the OpsPipeline.
+func (r *OpsPipelineReconciler) createStepOpsRequest(reqCtx intctrlutil.RequestCtx,
+	pipeline *appsv1alpha1.OpsPipeline,
+	step appsv1alpha1.OpsPipelineStep,
+	opsRequestName string) error {
+	// the OpsRequest inherits the labels of the OpsPipeline.
+	labels := map[string]string{}
+	for k, v := range pipeline.Labels {
+		labels[k] = v
+	}
+	labels[constant.OpsPipelineNameLabelKey] = pipeline.Name
+	labels[constant.OpsPipelineStepLabelKey] = step.Name
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opsRequestName,
+			Namespace: pipeline.Namespace,
+			Labels:    labels,
+			// the OpsRequest is requested by the user who creates the OpsPipeline.
+			Annotations: map[string]string{
+				constant.OpsRequestRequestedByAnnotationKey: pipeline.Annotations[constant.OpsRequestRequestedByAnnotationKey],
+			},
+		},
+		Spec: *step.Spec.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(pipeline, opsRequest, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Client.Create(reqCtx.Ctx, opsRequest); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		existing := &appsv1alpha1.OpsRequest{}
+		if err = r.Client.Get(reqCtx.Ctx, client.ObjectKeyFromObject(opsRequest), existing); err != nil {
+			return err
+		}
+		if !isStepOpsRequest(pipeline, step, existing) {
+			return fmt.Errorf(`OpsRequest "%s" already exists and is not created for the step "%s"`, opsRequestName, step.Name)
+		}
+	}
+	r.Recorder.Eventf(pipeline, corev1.EventTypeNormal, reasonOpsPipelineStepStarted,
+		`Step "%s" started with OpsRequest "%s"`, step.Name, opsRequestName)
+	return nil
+}
+
+// isStepOpsRequest checks if the OpsRequest is created for the step, by the controller reference and the labels.
+func isStepOpsRequest(pipeline *appsv1alpha1.OpsPipeline, step appsv1alpha1.OpsPipelineStep, opsRequest *appsv1alpha1.OpsRequest) bool {
+	return metav1.IsControlledBy(opsRequest, pipeline) &&
+		opsRequest.Labels[constant.OpsPipelineNameLabelKey] == pipeline.Name &&
+		opsRequest.Labels[constant.OpsPipelineStepLabelKey] == step.Name
+}
+
+// completePipeline sets the OpsPipeline to the completed phase.
+func (r *OpsPipelineReconciler) completePipeline(pipeline *appsv1alpha1.OpsPipeline, phase appsv1alpha1.OpsPhase, message string) {
+	condition := metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeSucceed,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonOpsPipelineSucceed,
+		Message: message,
+	}
+	eventType := corev1.EventTypeNormal
+	if phase == appsv1alpha1.OpsFailedPhase {
+		condition.Type = appsv1alpha1.ConditionTypeFailed
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonOpsPipelineFailed
+		eventType = corev1.EventTypeWarning
+	}
+	if pipeline.Status.StartTimestamp.IsZero() {
+		pipeline.Status.StartTimestamp = metav1.Now()
+	}
+	pipeline.Status.Phase = phase
+	pipeline.Status.CompletionTimestamp = metav1.Now()
+	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
+	r.Recorder.Event(pipeline, eventType, condition.Reason, message)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpsPipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.OpsPipeline{}).
+		Owns(&appsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
@@ -61,6 +61,9 @@ type OpsRequestReconciler struct {
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -96,6 +99,7 @@ func (r *OpsRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parseBackupOpsRequest)).
 		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.parseVolumeExpansionOpsRequest)).
 		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.parsePod)).
+		Watches(&appsv1alpha1.OpsRequest{}, handler.EnqueueRequestsFromMapFunc(r.parseDependentOpsRequests)).
 		Owns(&batchv1.Job{}).
 		Owns(&dpv1alpha1.Restore{}).
 		Complete(r)
@@ -169,13 +173,15 @@ func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.Reques
 			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
-	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsCreatingPhase:
+	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsScheduledPhase, appsv1alpha1.OpsCreatingPhase:
 		return r.doOpsRequestAction(reqCtx, opsRes)
 	case appsv1alpha1.OpsRunningPhase, appsv1alpha1.OpsCancellingPhase:
 		return r.reconcileStatusDuringRunningOrCanceling(reqCtx, opsRes)
 	case appsv1alpha1.OpsSucceedPhase:
 		return r.handleSucceedOpsRequest(reqCtx, opsRes.OpsRequest)
-	case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+	case appsv1alpha1.OpsFailedPhase:
+		return r.handleFailedOpsRequest(reqCtx, opsRes.OpsRequest)
+	case appsv1alpha1.OpsCancelledPhase, appsv1alpha1.OpsDryRunCompletedPhase:
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	}
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -190,7 +196,7 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	if opsRequest.IsComplete() || opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		return nil, nil
 	}
-	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase {
+	if slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsScheduledPhase}, opsRequest.Status.Phase) {
 		return &ctrl.Result{}, operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsCancelledPhase)
 	}
 	opsBehaviour := operations.GetOpsManager().OpsMap[opsRequest.Spec.Type]
@@ -235,6 +241,45 @@ func (r *OpsRequestReconciler) handleSucceedOpsRequest(reqCtx intctrlutil.Reques
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
 }
 
+// handleFailedOpsRequest creates the OpsRequest to roll back the changes if spec.rollbackOnFailure is enabled.
+func (r *OpsRequestReconciler) handleFailedOpsRequest(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest) (*ctrl.Result, error) {
+	if !operations.NeedRollback(opsRequest) {
+		return intctrlutil.ResultToP(intctrlutil.Reconciled())
+	}
+	rollbackOps := operations.BuildRollbackOpsRequest(opsRequest)
+	if rollbackOps == nil {
+		r.Recorder.Eventf(opsRequest, corev1.EventTypeWarning, reasonOpsRollbackSkipped,
+			"Skip rolling back the OpsRequest as no last configuration is recorded")
+		return intctrlutil.ResultToP(intctrlutil.Reconciled())
+	}
+	if err := r.Client.Create(reqCtx.Ctx, rollbackOps); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
+		if err = r.Client.Get(reqCtx.Ctx, client.ObjectKeyFromObject(rollbackOps), rollbackOps); err != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
+	}
+	// link the failed OpsRequest and the rollback OpsRequest with each other.
+	if rollbackOps.Status.RollbackOf != opsRequest.Name {
+		rollbackPatch := client.MergeFrom(rollbackOps.DeepCopy())
+		rollbackOps.Status.RollbackOf = opsRequest.Name
+		if err := r.Client.Status().Patch(reqCtx.Ctx, rollbackOps, rollbackPatch); err != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
+		r.Recorder.Eventf(rollbackOps, corev1.EventTypeNormal, reasonOpsRollbackCreated,
+			`Created to roll back the failed OpsRequest "%s"`, opsRequest.Name)
+	}
+	opsPatch := client.MergeFrom(opsRequest.DeepCopy())
+	opsRequest.Status.RollbackOpsRequest = rollbackOps.Name
+	if err := r.Client.Status().Patch(reqCtx.Ctx, opsRequest, opsPatch); err != nil {
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
+	r.Recorder.Eventf(opsRequest, corev1.EventTypeNormal, reasonOpsRollbackCreated,
+		`Created OpsRequest "%s" to roll back the failed changes`, rollbackOps.Name)
+	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+}
+
 // reconcileStatusDuringRunningOrCanceling reconciles the status of OpsRequest when it is running or canceling.
 func (r *OpsRequestReconciler) reconcileStatusDuringRunningOrCanceling(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	opsRequest := opsRes.OpsRequest
@@ -286,6 +331,9 @@ func (r *OpsRequestReconciler) addClusterLabelAndSetOwnerReference(reqCtx intctr
 func (r *OpsRequestReconciler) doOpsRequestAction(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	// process opsRequest entry function
 	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.DryRun {
+		return r.dryRunOpsRequest(reqCtx, opsRes)
+	}
 	opsDeepCopy := opsRequest.DeepCopy()
 	res, err := operations.GetOpsManager().Do(reqCtx, r.Client, opsRes)
 	if err != nil {
@@ -466,6 +514,25 @@ func (r *OpsRequestReconciler) parsePod(ctx context.Context, object client.Objec
 	return requests
 }
 
+// parseDependentOpsRequests enqueues the pending OpsRequests which depend on the OpsRequest.
+func (r *OpsRequestReconciler) parseDependentOpsRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	opsRequest := object.(*appsv1alpha1.OpsRequest)
+	var (
+		requests    []reconcile.Request
+		opsRequests = &appsv1alpha1.OpsRequestList{}
+	)
+	if err := r.Client.List(ctx, opsRequests, client.InNamespace(opsRequest.Namespace)); err != nil {
+		return nil
+	}
+	for _, v := range opsRequests.Items {
+		if v.Status.Phase != appsv1alpha1.OpsPendingPhase || !slices.Contains(v.Spec.DependsOn, opsRequest.Name) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&v)})
+	}
+	return requests
+}
+
 func (r *OpsRequestReconciler) deleteCreatedPodsInKBNamespace(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest) error {
 	namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
 	if namespace == "" {
@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,6 +82,8 @@ var _ = Describe("OpsRequest Controller", func() {
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, intctrlutil.PodSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, intctrlutil.OpsPipelineSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, intctrlutil.OpsFleetSignature, true, inNS, ml)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, intctrlutil.OpsRequestSignature, true, inNS, ml)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, intctrlutil.VolumeSnapshotSignature, true, inNS)
 
@@ -608,6 +611,93 @@ var _ = Describe("OpsRequest Controller", func() {
 			})).Should(Succeed())
 		})
 
+		It("HorizontalScaling in dry-run mode", func() {
+			By("init backup policy template, mysql cluster")
+			testk8s.MockDisableVolumeSnapshot(&testCtx, testk8s.DefaultStorageClassName)
+			createMysqlCluster(3)
+			cluster := &appsv1alpha1.Cluster{}
+			Expect(testCtx.Cli.Get(testCtx.Ctx, clusterKey, cluster)).Should(Succeed())
+			initGeneration := cluster.Generation
+
+			By("create a dry-run opsRequest to scale in")
+			opsName := "hscale-ops-" + testCtx.GetRandomStr()
+			ops := testapps.NewOpsRequestObj(opsName, testCtx.DefaultNamespace,
+				clusterObj.Name, appsv1alpha1.HorizontalScalingType)
+			ops.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: mysqlCompName},
+					Replicas:     2,
+				},
+			}
+			ops.Spec.DryRun = true
+			ops.Labels = nil
+			Expect(testCtx.CreateObj(testCtx.Ctx, ops)).Should(Succeed())
+			opsKey := client.ObjectKeyFromObject(ops)
+
+			By("expect the opsRequest completed in dry-run mode with the affected objects")
+			Eventually(testapps.CheckObj(&testCtx, opsKey, func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsDryRunCompletedPhase))
+				g.Expect(fetched.Status.DryRunResult).ShouldNot(BeNil())
+				g.Expect(fetched.Status.DryRunResult.PreCheckFailures).Should(BeEmpty())
+				kinds := map[string]bool{}
+				for _, obj := range fetched.Status.DryRunResult.AffectedObjects {
+					kinds[obj.Kind] = true
+				}
+				g.Expect(kinds).Should(HaveKey(appsv1alpha1.ClusterKind))
+				g.Expect(kinds).Should(HaveKey(appsv1alpha1.ComponentKind))
+				condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeDryRun)
+				g.Expect(condition).ShouldNot(BeNil())
+				g.Expect(condition.Status).Should(Equal(metav1.ConditionTrue))
+			})).Should(Succeed())
+
+			By("expect the cluster not changed")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, fetched *appsv1alpha1.Cluster) {
+				g.Expect(fetched.Generation).Should(Equal(initGeneration))
+				g.Expect(fetched.Spec.ComponentSpecs[0].Replicas).Should(BeEquivalentTo(3))
+			})).Should(Succeed())
+		})
+
+		It("roll back the failed VerticalScaling opsRequest", func() {
+			By("init backup policy template, mysql cluster")
+			testk8s.MockDisableVolumeSnapshot(&testCtx, testk8s.DefaultStorageClassName)
+			createMysqlCluster(3)
+			cluster := &appsv1alpha1.Cluster{}
+			Expect(testCtx.Cli.Get(testCtx.Ctx, clusterKey, cluster)).Should(Succeed())
+			lastResources := cluster.Spec.ComponentSpecs[0].Resources
+
+			By("create a VerticalScaling opsRequest with rollbackOnFailure")
+			ops := testapps.NewOpsRequestObj("vscale-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterObj.Name, appsv1alpha1.VerticalScalingType)
+			ops.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: mysqlCompName},
+					ResourceRequirements: corev1.ResourceRequirements{Requests: _2c4g, Limits: _2c4g},
+				},
+			}
+			ops.Spec.RollbackOnFailure = true
+			ops.Labels = nil
+			Expect(testCtx.CreateObj(testCtx.Ctx, ops)).Should(Succeed())
+			opsKey := client.ObjectKeyFromObject(ops)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, opsKey)).Should(Equal(appsv1alpha1.OpsRunningPhase))
+
+			By("mock the opsRequest failed")
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, opsKey, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Status.Phase = appsv1alpha1.OpsFailedPhase
+			})()).ShouldNot(HaveOccurred())
+
+			By("expect the rollback opsRequest is created and linked")
+			rollbackKey := types.NamespacedName{Namespace: ops.Namespace, Name: ops.GetRollbackOpsRequestName()}
+			Eventually(testapps.CheckObj(&testCtx, opsKey, func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+				g.Expect(fetched.Status.RollbackOpsRequest).Should(Equal(rollbackKey.Name))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, rollbackKey, func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+				g.Expect(fetched.Status.RollbackOf).Should(Equal(ops.Name))
+				g.Expect(fetched.Spec.Type).Should(Equal(appsv1alpha1.VerticalScalingType))
+				g.Expect(fetched.Spec.VerticalScalingList).Should(HaveLen(1))
+				g.Expect(fetched.Spec.VerticalScalingList[0].ResourceRequirements.Limits.Cpu().Equal(*lastResources.Limits.Cpu())).Should(BeTrue())
+			})).Should(Succeed())
+		})
+
 		It("delete Running opsRequest", func() {
 			By("Create a horizontalScaling ops")
 			testk8s.MockEnableVolumeSnapshot(&testCtx, testk8s.DefaultStorageClassName)
@@ -791,6 +881,305 @@ var _ = Describe("OpsRequest Controller", func() {
 			})).Should(Succeed())
 		})
 
+		It("test opsRequest dependsOn", func() {
+			By("create cluster and mock it to running")
+			replicas := int32(3)
+			createMysqlCluster(replicas)
+			mockCompRunning(replicas)
+
+			By("create first restart ops")
+			ops1 := createRestartOps(clusterObj.Name, 1)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops1))).Should(Equal(appsv1alpha1.OpsRunningPhase))
+
+			By("create second restart ops which depends on the first one, expect it to wait")
+			ops2 := testapps.NewOpsRequestObj("restart-ops-2", testCtx.DefaultNamespace, clusterObj.Name, appsv1alpha1.RestartType)
+			ops2.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: mysqlCompName}}
+			ops2.Spec.DependsOn = []string{ops1.Name}
+			testapps.CreateOpsRequest(testCtx.Ctx, testCtx, ops2)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops2), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingPhase))
+				condition := meta.FindStatusCondition(ops.Status.Conditions, appsv1alpha1.ConditionTypeDependencies)
+				g.Expect(condition).ShouldNot(BeNil())
+				g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonWaitForDependencies))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(clusterObj), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				// ops2 should not be enqueued before the dependencies succeed.
+				opsSlice, _ := opsutil.GetOpsRequestSliceFromCluster(cluster)
+				g.Expect(opsSlice).Should(HaveLen(1))
+			})).Should(Succeed())
+
+			By("mock ops1 phase to Succeed and expect ops2 to run")
+			mockCompRunning(replicas)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops1))).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops2))).Should(Equal(appsv1alpha1.OpsRunningPhase))
+		})
+
+		It("test opsRequest dependsOn a failed opsRequest", func() {
+			By("create cluster and mock it to running")
+			replicas := int32(3)
+			createMysqlCluster(replicas)
+			mockCompRunning(replicas)
+
+			By("create an ops which fails to validate")
+			ops1 := testapps.NewOpsRequestObj("restart-ops-1", testCtx.DefaultNamespace, clusterObj.Name, appsv1alpha1.RestartType)
+			ops1.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: "not-exist"}}
+			testapps.CreateOpsRequest(testCtx.Ctx, testCtx, ops1)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops1))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+
+			By("expect the ops which depends on the failed one to be cancelled")
+			ops2 := testapps.NewOpsRequestObj("restart-ops-2", testCtx.DefaultNamespace, clusterObj.Name, appsv1alpha1.RestartType)
+			ops2.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: mysqlCompName}}
+			ops2.Spec.DependsOn = []string{ops1.Name}
+			testapps.CreateOpsRequest(testCtx.Ctx, testCtx, ops2)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops2), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsCancelledPhase))
+				condition := meta.FindStatusCondition(ops.Status.Conditions, appsv1alpha1.ConditionTypeDependencies)
+				g.Expect(condition).ShouldNot(BeNil())
+				g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonDependencyNotSucceed))
+			})).Should(Succeed())
+		})
+
+		It("test OpsPipeline", func() {
+			By("create cluster and mock it to running")
+			replicas := int32(3)
+			createMysqlCluster(replicas)
+			mockCompRunning(replicas)
+
+			By("create an OpsPipeline with two restart steps")
+			restartSpec := appsv1alpha1.OpsRequestSpec{
+				ClusterRef:  clusterObj.Name,
+				Type:        appsv1alpha1.RestartType,
+				RestartList: []appsv1alpha1.ComponentOps{{ComponentName: mysqlCompName}},
+			}
+			pipeline := &appsv1alpha1.OpsPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "restart-pipeline",
+					Namespace:   testCtx.DefaultNamespace,
+					Annotations: map[string]string{constant.OpsRequestRequestedByAnnotationKey: "requester"},
+				},
+				Spec: appsv1alpha1.OpsPipelineSpec{
+					Steps: []appsv1alpha1.OpsPipelineStep{
+						{Name: "first", Spec: restartSpec},
+						{Name: "second", Spec: restartSpec},
+					},
+				},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, pipeline)).Should(Succeed())
+
+			By("expect only the OpsRequest of the first step to be created and run")
+			firstOpsKey := client.ObjectKey{Name: pipeline.GetStepOpsRequestName("first"), Namespace: pipeline.Namespace}
+			secondOpsKey := client.ObjectKey{Name: pipeline.GetStepOpsRequestName("second"), Namespace: pipeline.Namespace}
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, firstOpsKey)).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			Consistently(testapps.CheckObjExists(&testCtx, secondOpsKey, &appsv1alpha1.OpsRequest{}, false)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, firstOpsKey, func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+				g.Expect(fetched.Annotations[constant.OpsRequestRequestedByAnnotationKey]).Should(Equal("requester"))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(pipeline), func(g Gomega, fetched *appsv1alpha1.OpsPipeline) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+				g.Expect(fetched.Status.Progress).Should(Equal("0/2"))
+			})).Should(Succeed())
+
+			By("mock the first step to Succeed and expect the second step to run")
+			mockCompRunning(replicas)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, firstOpsKey)).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, secondOpsKey)).Should(Equal(appsv1alpha1.OpsRunningPhase))
+
+			By("mock the second step to Succeed and expect the pipeline to succeed")
+			mockCompRunning(replicas)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(pipeline), func(g Gomega, fetched *appsv1alpha1.OpsPipeline) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				g.Expect(fetched.Status.Progress).Should(Equal("2/2"))
+				g.Expect(fetched.Status.Steps).Should(HaveLen(2))
+			})).Should(Succeed())
+		})
+
+		It("test OpsPipeline with an unrelated OpsRequest of the step name", func() {
+			By("create cluster and mock it to running")
+			replicas := int32(3)
+			createMysqlCluster(replicas)
+			mockCompRunning(replicas)
+
+			restartSpec := appsv1alpha1.OpsRequestSpec{
+				ClusterRef:  clusterObj.Name,
+				Type:        appsv1alpha1.RestartType,
+				RestartList: []appsv1alpha1.ComponentOps{{ComponentName: mysqlCompName}},
+			}
+			pipeline := &appsv1alpha1.OpsPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "conflict-pipeline",
+					Namespace: testCtx.DefaultNamespace,
+				},
+				Spec: appsv1alpha1.OpsPipelineSpec{
+					Steps: []appsv1alpha1.OpsPipelineStep{{Name: "first", Spec: restartSpec}},
+				},
+			}
+
+			By("create an OpsRequest with the name of the step in advance")
+			unrelatedOps := &appsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pipeline.GetStepOpsRequestName("first"),
+					Namespace: testCtx.DefaultNamespace,
+					Labels:    map[string]string{constant.AppInstanceLabelKey: clusterObj.Name},
+				},
+				Spec: restartSpec,
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, unrelatedOps)).Should(Succeed())
+
+			By("expect the step to fail instead of adopting the OpsRequest")
+			Expect(testCtx.CreateObj(testCtx.Ctx, pipeline)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(pipeline), func(g Gomega, fetched *appsv1alpha1.OpsPipeline) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+				g.Expect(fetched.Status.Steps).Should(HaveLen(1))
+				g.Expect(fetched.Status.Steps[0].Message).Should(ContainSubstring("not created for the step"))
+			})).Should(Succeed())
+		})
+
+		It("test OpsFleet", func() {
+			By("create cluster and mock it to running")
+			replicas := int32(3)
+			createMysqlCluster(replicas)
+			mockCompRunning(replicas)
+			Expect(testapps.ChangeObj(&testCtx, clusterObj, func(cluster *appsv1alpha1.Cluster) {
+				if cluster.Labels == nil {
+					cluster.Labels = map[string]string{}
+				}
+				cluster.Labels["tenant"] = "fleet-test"
+			})).Should(Succeed())
+
+			By("create an OpsFleet which restarts the clusters of the tenant")
+			fleet := &appsv1alpha1.OpsFleet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "restart-fleet",
+					Namespace: testCtx.DefaultNamespace,
+				},
+				Spec: appsv1alpha1.OpsFleetSpec{
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "fleet-test"}},
+					MaxConcurrency:  1,
+					Template: appsv1alpha1.OpsRequestSpec{
+						Type:        appsv1alpha1.RestartType,
+						RestartList: []appsv1alpha1.ComponentOps{{ComponentName: mysqlCompName}},
+					},
+				},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, fleet)).Should(Succeed())
+
+			By("expect the OpsRequest of the cluster to be created and run")
+			opsKey := client.ObjectKey{Name: fleet.GetClusterOpsRequestName(clusterObj.Name), Namespace: fleet.Namespace}
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, opsKey)).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(fleet), func(g Gomega, fetched *appsv1alpha1.OpsFleet) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+				g.Expect(fetched.Status.Progress).Should(Equal("0/1"))
+				g.Expect(fetched.Status.Clusters).Should(HaveLen(1))
+				g.Expect(fetched.Status.Clusters[0].ClusterName).Should(Equal(clusterObj.Name))
+			})).Should(Succeed())
+
+			By("mock the cluster to running and expect the fleet to succeed")
+			mockCompRunning(replicas)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(fleet), func(g Gomega, fetched *appsv1alpha1.OpsFleet) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				g.Expect(fetched.Status.Progress).Should(Equal("1/1"))
+				g.Expect(fetched.Status.FailedCount).Should(BeZero())
+			})).Should(Succeed())
+		})
+
+		It("test the clusterRef of OpsFleet template and OpsRequest", func() {
+			By("create an OpsFleet whose template omits the clusterRef, should succeed")
+			fleet := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": appsv1alpha1.GroupVersion.String(),
+				"kind":       "OpsFleet",
+				"metadata": map[string]interface{}{
+					"name":      "fleet-without-cluster-ref",
+					"namespace": testCtx.DefaultNamespace,
+				},
+				"spec": map[string]interface{}{
+					"clusterSelector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"tenant": "fleet-without-cluster-ref"},
+					},
+					"template": map[string]interface{}{
+						"type": string(appsv1alpha1.RestartType),
+						"restart": []interface{}{
+							map[string]interface{}{"componentName": mysqlCompName},
+						},
+					},
+				},
+			}}
+			Expect(testCtx.CreateObj(testCtx.Ctx, fleet)).Should(Succeed())
+
+			By("create an OpsRequest which omits the clusterRef, should fail")
+			opsRequest := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": appsv1alpha1.GroupVersion.String(),
+				"kind":       "OpsRequest",
+				"metadata": map[string]interface{}{
+					"name":      "ops-without-cluster-ref",
+					"namespace": testCtx.DefaultNamespace,
+				},
+				"spec": map[string]interface{}{
+					"type": string(appsv1alpha1.RestartType),
+					"restart": []interface{}{
+						map[string]interface{}{"componentName": mysqlCompName},
+					},
+				},
+			}}
+			Expect(testCtx.CreateObj(testCtx.Ctx, opsRequest)).ShouldNot(Succeed())
+		})
+
+		It("test OpsPipeline and OpsFleet with dry-run OpsRequests", func() {
+			By("create cluster and mock it to running")
+			replicas := int32(3)
+			createMysqlCluster(replicas)
+			mockCompRunning(replicas)
+			Expect(testapps.ChangeObj(&testCtx, clusterObj, func(cluster *appsv1alpha1.Cluster) {
+				if cluster.Labels == nil {
+					cluster.Labels = map[string]string{}
+				}
+				cluster.Labels["tenant"] = "dry-run-test"
+			})).Should(Succeed())
+			dryRunSpec := appsv1alpha1.OpsRequestSpec{
+				ClusterRef: clusterObj.Name,
+				Type:       appsv1alpha1.HorizontalScalingType,
+				DryRun:     true,
+				HorizontalScalingList: []appsv1alpha1.HorizontalScaling{
+					{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: mysqlCompName}, Replicas: 2},
+				},
+			}
+
+			By("expect the pipeline with a dry-run step to complete")
+			pipeline := &appsv1alpha1.OpsPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dry-run-pipeline",
+					Namespace: testCtx.DefaultNamespace,
+				},
+				Spec: appsv1alpha1.OpsPipelineSpec{
+					Steps: []appsv1alpha1.OpsPipelineStep{{Name: "first", Spec: dryRunSpec}},
+				},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, pipeline)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(pipeline), func(g Gomega, fetched *appsv1alpha1.OpsPipeline) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				g.Expect(fetched.Status.Progress).Should(Equal("1/1"))
+				g.Expect(fetched.Status.Steps).Should(HaveLen(1))
+				g.Expect(fetched.Status.Steps[0].Phase).Should(Equal(appsv1alpha1.OpsDryRunCompletedPhase))
+			})).Should(Succeed())
+
+			By("expect the fleet with a dry-run template to complete")
+			fleet := &appsv1alpha1.OpsFleet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dry-run-fleet",
+					Namespace: testCtx.DefaultNamespace,
+				},
+				Spec: appsv1alpha1.OpsFleetSpec{
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "dry-run-test"}},
+					Template:        dryRunSpec,
+				},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, fleet)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(fleet), func(g Gomega, fetched *appsv1alpha1.OpsFleet) {
+				g.Expect(fetched.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				g.Expect(fetched.Status.Progress).Should(Equal("1/1"))
+				g.Expect(fetched.Status.FailedCount).Should(BeZero())
+			})).Should(Succeed())
+		})
+
 		It("test opsRequest queue for QueueBySelf", func() {
 			By("create cluster and mock it to running")
 			replicas := int32(3)
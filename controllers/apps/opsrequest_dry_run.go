@@ -0,0 +1,312 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations"
+	workloadsctrl "github.com/apecloud/kubeblocks/controllers/workloads"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// dryRunIgnoredMetaFields are the metadata fields maintained by the API server, which are not reported as changes.
+var dryRunIgnoredMetaFields = []string{"resourceVersion", "generation", "creationTimestamp", "uid", "managedFields"}
+
+// dryRunOpsRequest renders the changes the OpsRequest would make without applying them.
+// The OpsRequest action and the following cluster, component and InstanceSet reconciliations run on top of
+// a DryRunClient, and the recorded changes are reported in the status of the OpsRequest.
+func (r *OpsRequestReconciler) dryRunOpsRequest(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
+	dryRunCli := intctrlutil.NewDryRunClient(r.Client)
+	dryRunCtx := reqCtx
+	// the events emitted during the dry run are dropped.
+	dryRunCtx.Recorder = &record.FakeRecorder{}
+	preCheckFailures, err := operations.GetOpsManager().DryRun(dryRunCtx, dryRunCli, opsRes)
+	if err != nil {
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
+	result := &appsv1alpha1.DryRunResult{PreCheckFailures: preCheckFailures}
+	if len(preCheckFailures) == 0 {
+		if err = r.renderDryRunResult(dryRunCtx, dryRunCli, opsRes, result); err != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
+	}
+
+	opsRequest := opsRes.OpsRequest
+	opsDeepCopy := opsRequest.DeepCopy()
+	opsRequest.Status.DryRunResult = result
+	if err = operations.PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, r.Client, opsRes, opsDeepCopy,
+		appsv1alpha1.OpsDryRunCompletedPhase, appsv1alpha1.NewDryRunCompletedCondition(opsRequest)); err != nil {
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
+	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+}
+
+// renderDryRunResult runs the reconciliations with the DryRunClient and fills the affected objects into the result.
+func (r *OpsRequestReconciler) renderDryRunResult(reqCtx intctrlutil.RequestCtx,
+	dryRunCli *intctrlutil.DryRunClient,
+	opsRes *operations.OpsResource,
+	result *appsv1alpha1.DryRunResult) error {
+	clusterName := opsRes.Cluster.Name
+	namespace := opsRes.Cluster.Namespace
+	if err := r.dryRunClusterPlan(reqCtx, dryRunCli, types.NamespacedName{Namespace: namespace, Name: clusterName}); err != nil {
+		result.PreCheckFailures = append(result.PreCheckFailures, fmt.Sprintf("failed to render the cluster: %s", err.Error()))
+		return nil
+	}
+	var pods []appsv1alpha1.DryRunAffectedObject
+	for compName := range opsRes.OpsRequest.GetComponentNameSet() {
+		compKey := types.NamespacedName{Namespace: namespace, Name: constant.GenerateClusterComponentName(clusterName, compName)}
+		if err := r.dryRunComponentPlan(reqCtx, dryRunCli, compKey); err != nil {
+			result.PreCheckFailures = append(result.PreCheckFailures,
+				fmt.Sprintf("failed to render the component %s: %s", compName, err.Error()))
+			return nil
+		}
+		itsKey := types.NamespacedName{Namespace: namespace, Name: constant.GenerateWorkloadNamePattern(clusterName, compName)}
+		compPods, err := r.dryRunInstanceSet(reqCtx, dryRunCli, itsKey)
+		if err != nil {
+			result.PreCheckFailures = append(result.PreCheckFailures,
+				fmt.Sprintf("failed to render the workload of component %s: %s", compName, err.Error()))
+			return nil
+		}
+		pods = append(pods, compPods...)
+	}
+
+	affectedObjects, err := r.buildDryRunAffectedObjects(dryRunCli.Changes())
+	if err != nil {
+		return err
+	}
+	// the pods are reported by the expected update policy rather than the actions of a single reconciliation.
+	for _, pod := range pods {
+		index := -1
+		for i, obj := range affectedObjects {
+			if obj.Kind == pod.Kind && obj.Name == pod.Name {
+				index = i
+				break
+			}
+		}
+		if index >= 0 {
+			affectedObjects[index] = pod
+		} else {
+			affectedObjects = append(affectedObjects, pod)
+		}
+		if pod.Restart {
+			result.PodsRestart = true
+		}
+	}
+	result.AffectedObjects = affectedObjects
+	return nil
+}
+
+func (r *OpsRequestReconciler) dryRunClusterPlan(reqCtx intctrlutil.RequestCtx, dryRunCli client.Client, key types.NamespacedName) error {
+	clusterCtx := reqCtx
+	clusterCtx.Req = ctrl.Request{NamespacedName: key}
+	planBuilder := newClusterPlanBuilder(clusterCtx, dryRunCli)
+	if err := planBuilder.Init(); err != nil {
+		return err
+	}
+	// the multi-cluster placement is not rendered in dry-run mode.
+	plan, errBuild := buildClusterPlan(planBuilder, nil)
+	return executeDryRunPlan(plan, errBuild)
+}
+
+func (r *OpsRequestReconciler) dryRunComponentPlan(reqCtx intctrlutil.RequestCtx, dryRunCli client.Client, key types.NamespacedName) error {
+	compCtx := reqCtx
+	compCtx.Req = ctrl.Request{NamespacedName: key}
+	planBuilder := newComponentPlanBuilder(compCtx, dryRunCli)
+	if err := planBuilder.Init(); err != nil {
+		return err
+	}
+	// only the transformers without side effects outside the API server are involved,
+	// the lifecycle actions such as account provision and member leave are skipped.
+	plan, errBuild := planBuilder.
+		AddTransformer(
+			&componentLoadResourcesTransformer{},
+			&componentValidationTransformer{},
+			&componentSidecarContainerTransformer{},
+			&componentHostNetworkTransformer{},
+			&componentServiceTransformer{},
+			&componentTLSTransformer{Client: dryRunCli},
+			&componentRelatedParametersTransformer{Client: dryRunCli},
+			&componentCustomVolumesTransformer{},
+			&componentVarsTransformer{},
+			&componentConfigurationTransformer{Client: dryRunCli},
+			&componentWorkloadTransformer{Client: dryRunCli, dryRun: true},
+			&componentOwnershipTransformer{},
+		).Build()
+	return executeDryRunPlan(plan, errBuild)
+}
+
+// dryRunInstanceSet reconciles the InstanceSet with the DryRunClient, and returns the pods which would be updated.
+func (r *OpsRequestReconciler) dryRunInstanceSet(reqCtx intctrlutil.RequestCtx,
+	dryRunCli client.Client, key types.NamespacedName) ([]appsv1alpha1.DryRunAffectedObject, error) {
+	itsReconciler := &workloadsctrl.InstanceSetReconciler{
+		Client:   dryRunCli,
+		Scheme:   r.Scheme,
+		Recorder: reqCtx.Recorder,
+	}
+	if _, err := itsReconciler.Reconcile(reqCtx.Ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		return nil, err
+	}
+	its := &workloads.InstanceSet{}
+	if err := dryRunCli.Get(reqCtx.Ctx, key, its); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if its.Spec.Selector == nil {
+		return nil, nil
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList, client.InNamespace(key.Namespace),
+		client.MatchingLabels(its.Spec.Selector.MatchLabels)); err != nil {
+		return nil, err
+	}
+	var pods []appsv1alpha1.DryRunAffectedObject
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		desired, err := instanceset.IsPodDesired(its, pod)
+		if err != nil {
+			return nil, err
+		}
+		if !desired {
+			continue
+		}
+		recreate, err := instanceset.IsPodRecreationRequired(its, pod)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := instanceset.IsPodUpdated(its, pod)
+		if err != nil {
+			return nil, err
+		}
+		if !recreate && updated {
+			continue
+		}
+		pods = append(pods, appsv1alpha1.DryRunAffectedObject{
+			Kind:    constant.PodKind,
+			Name:    pod.Name,
+			Action:  appsv1alpha1.DryRunUpdateAction,
+			Restart: recreate,
+		})
+	}
+	return pods, nil
+}
+
+func executeDryRunPlan(plan graph.Plan, errBuild error) error {
+	if errExec := plan.Execute(); errExec != nil {
+		return errExec
+	}
+	if errBuild != nil {
+		if _, ok := errBuild.(intctrlutil.RequeueError); ok {
+			return nil
+		}
+	}
+	return errBuild
+}
+
+// buildDryRunAffectedObjects converts the changes recorded by the DryRunClient to the affected objects.
+func (r *OpsRequestReconciler) buildDryRunAffectedObjects(changes []intctrlutil.DryRunChange) ([]appsv1alpha1.DryRunAffectedObject, error) {
+	var affectedObjects []appsv1alpha1.DryRunAffectedObject
+	for _, change := range changes {
+		if _, ok := change.Object.(*appsv1alpha1.OpsRequest); ok {
+			continue
+		}
+		gvk, err := apiutil.GVKForObject(change.Object, r.Scheme)
+		if err != nil {
+			return nil, err
+		}
+		affectedObject := appsv1alpha1.DryRunAffectedObject{
+			Kind: gvk.Kind,
+			Name: change.Object.GetName(),
+		}
+		switch change.Action {
+		case intctrlutil.DryRunCreate:
+			affectedObject.Action = appsv1alpha1.DryRunCreateAction
+		case intctrlutil.DryRunDelete:
+			affectedObject.Action = appsv1alpha1.DryRunDeleteAction
+		default:
+			affectedObject.Action = appsv1alpha1.DryRunUpdateAction
+			fieldChanges, err := diffDryRunObjects(change.Original, change.Object)
+			if err != nil {
+				return nil, err
+			}
+			if len(fieldChanges) == 0 {
+				continue
+			}
+			affectedObject.Changes = fieldChanges
+		}
+		affectedObjects = append(affectedObjects, affectedObject)
+	}
+	return affectedObjects, nil
+}
+
+// diffDryRunObjects returns the changed fields of the object, the status and the metadata maintained by the API server are ignored.
+func diffDryRunObjects(original, obj client.Object) ([]appsv1alpha1.DryRunFieldChange, error) {
+	toMap := func(o client.Object) (map[string]interface{}, error) {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			return nil, err
+		}
+		delete(m, "status")
+		if meta, ok := m["metadata"].(map[string]interface{}); ok {
+			for _, field := range dryRunIgnoredMetaFields {
+				delete(meta, field)
+			}
+		}
+		return m, nil
+	}
+	oldMap, err := toMap(original)
+	if err != nil {
+		return nil, err
+	}
+	newMap, err := toMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	var changes []appsv1alpha1.DryRunFieldChange
+	for _, change := range diffFields(nil, oldMap, newMap) {
+		changes = append(changes, appsv1alpha1.DryRunFieldChange{
+			Path: fieldPathString(change.path),
+			Old:  dryRunValueString(change.oldValue),
+			New:  dryRunValueString(change.newValue),
+		})
+	}
+	return changes, nil
+}
+
+func dryRunValueString(value any) string {
+	if value == nil {
+		return ""
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
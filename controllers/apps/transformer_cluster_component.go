@@ -205,6 +205,8 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1alpha1.Component) *apps
 	compObjCopy.Spec.RuntimeClassName = compProto.Spec.RuntimeClassName
 	compObjCopy.Spec.Sidecars = compProto.Spec.Sidecars
 	compObjCopy.Spec.MonitorEnabled = compProto.Spec.MonitorEnabled
+	compObjCopy.Spec.AutoHeal = compProto.Spec.AutoHeal
+	compObjCopy.Spec.InstancePlacement = compProto.Spec.InstancePlacement
 
 	if reflect.DeepEqual(oldCompObj.Annotations, compObjCopy.Annotations) &&
 		reflect.DeepEqual(oldCompObj.Labels, compObjCopy.Labels) &&
@@ -25,11 +25,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/common"
-
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // clusterCredentialTransformer creates the default cluster connection credential secret
@@ -78,11 +79,38 @@ func (t *clusterConnCredentialTransformer) buildClusterConnCredential(transCtx *
 		return err
 	}
 	if apierrors.IsNotFound(err) {
+		if err = t.writeConnCredentialToStore(transCtx, secret); err != nil {
+			return err
+		}
 		graphCli.Create(dag, secret, inUniversalContext4G())
 	}
 	return nil
 }
 
+// writeConnCredentialToStore writes the connection credential to the external secret store if it is enabled,
+// and marks the secret as synced, so that the credential is deleted from the store along with the secret.
+func (t *clusterConnCredentialTransformer) writeConnCredentialToStore(transCtx *clusterTransformContext, secret *corev1.Secret) error {
+	store, err := secretstore.Default()
+	if err != nil || store == nil {
+		return err
+	}
+	data := map[string][]byte{}
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+	if err = store.Put(transCtx.Context, secretstore.ConnCredentialPath(secret.Namespace, transCtx.Cluster.Name), data); err != nil {
+		return err
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constant.SecretStoreSyncedAnnotationKey] = trueVal
+	return nil
+}
+
 func (t *clusterConnCredentialTransformer) buildSynthesizedComponent(transCtx *clusterTransformContext) *component.SynthesizedComponent {
 	for _, compDef := range transCtx.ClusterDef.Spec.ComponentDefs {
 		if compDef.Service == nil {
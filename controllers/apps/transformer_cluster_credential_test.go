@@ -0,0 +1,65 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+)
+
+var _ = Describe("cluster connection credential", func() {
+	AfterEach(func() {
+		secretstore.SetDefault(nil)
+	})
+
+	It("deletes the connection credential from the secret store along with the secret", func() {
+		store := &injectedSecretStore{secrets: map[string]map[string][]byte{}}
+		secretstore.SetDefault(store)
+		cluster := &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"}}
+		transCtx := &clusterTransformContext{Context: ctx, Cluster: cluster, OrigCluster: cluster}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: constant.GenerateDefaultConnCredential(cluster.Name), Namespace: cluster.Namespace},
+			StringData: map[string]string{"username": "root"},
+		}
+
+		By("write the connection credential to the store")
+		Expect((&clusterConnCredentialTransformer{}).writeConnCredentialToStore(transCtx, secret)).Should(Succeed())
+		secretPath := secretstore.ConnCredentialPath(cluster.Namespace, cluster.Name)
+		Expect(store.secrets).Should(HaveKey(secretPath))
+		Expect(secret.Annotations).Should(HaveKeyWithValue(constant.SecretStoreSyncedAnnotationKey, trueVal))
+
+		By("the other secrets are ignored")
+		other := secret.DeepCopy()
+		other.Name = "other"
+		Expect(deleteConnCredentialFromStore(transCtx, other)).Should(Succeed())
+		Expect(store.secrets).Should(HaveKey(secretPath))
+
+		By("delete the connection credential from the store")
+		Expect(deleteConnCredentialFromStore(transCtx, secret)).Should(Succeed())
+		Expect(store.secrets).ShouldNot(HaveKey(secretPath))
+	})
+})
@@ -40,6 +40,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // clusterDeletionTransformer handles cluster deletion
@@ -126,6 +127,9 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 		if shouldSkipObjOwnedByComp(o, *cluster) || rsm.IsOwnedByRsm(o) {
 			continue
 		}
+		if err = deleteConnCredentialFromStore(transCtx, o); err != nil {
+			return newRequeueError(time.Second*1, err.Error())
+		}
 		graphCli.Delete(dag, o, inUniversalContext4G())
 	}
 	// set cluster action to noop until all the sub-resources deleted
@@ -151,6 +155,21 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 	return graph.ErrPrematureStop
 }
 
+// deleteConnCredentialFromStore deletes the connection credential of the cluster from the external secret store
+// when its secret is deleted.
+func deleteConnCredentialFromStore(transCtx *clusterTransformContext, object client.Object) error {
+	secret, ok := object.(*corev1.Secret)
+	if !ok || secret.Name != constant.GenerateDefaultConnCredential(transCtx.OrigCluster.Name) ||
+		secret.Annotations[constant.SecretStoreSyncedAnnotationKey] != trueVal {
+		return nil
+	}
+	store, err := secretstore.Default()
+	if err != nil || store == nil {
+		return err
+	}
+	return store.Delete(transCtx.Context, secretstore.ConnCredentialPath(secret.Namespace, transCtx.OrigCluster.Name))
+}
+
 func haltPreserveKinds() []client.ObjectList {
 	return []client.ObjectList{
 		&corev1.PersistentVolumeClaimList{},
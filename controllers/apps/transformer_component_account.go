@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,6 +39,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // componentAccountTransformer handles component system accounts.
@@ -56,38 +60,183 @@ func (t *componentAccountTransformer) Transform(ctx graph.TransformContext, dag
 	synthesizeComp := transCtx.SynthesizeComponent
 	graphCli, _ := transCtx.Client.(model.GraphClient)
 
+	store, err := secretstore.Default()
+	if err != nil {
+		return err
+	}
+	_, injector, err := secretstore.DefaultInjector()
+	if err != nil {
+		return err
+	}
+
 	for _, account := range synthesizeComp.SystemAccounts {
-		exist, err := t.checkAccountSecretExist(ctx, synthesizeComp, account)
+		existing, err := t.getAccountSecret(ctx, synthesizeComp, account)
 		if err != nil {
 			return err
 		}
-		if exist {
+		if existing != nil {
+			// write the secrets created before the secret store is enabled to the store
+			if store != nil && existing.Annotations[constant.SecretStoreSyncedAnnotationKey] != trueVal {
+				if err = t.writeAccountSecretToStore(transCtx, store, synthesizeComp, account, existing); err != nil {
+					return err
+				}
+				existingCopy := existing.DeepCopy()
+				if existingCopy.Annotations == nil {
+					existingCopy.Annotations = map[string]string{}
+				}
+				existingCopy.Annotations[constant.SecretStoreSyncedAnnotationKey] = trueVal
+				graphCli.Update(dag, existing, existingCopy, inUniversalContext4G())
+			}
+			continue
+		}
+		if injector != nil {
+			// the password is injected into the pods from the secret store, the account secret is not created.
+			if isAccountProvisionedInStore(transCtx.Component, account.Name) {
+				continue
+			}
+			if err = t.provisionAccountInStore(transCtx, store, synthesizeComp, account); err != nil {
+				return err
+			}
+			markAccountProvisionedInStore(transCtx.Component, account.Name)
 			continue
 		}
 		secret, err := t.buildAccountSecret(transCtx, synthesizeComp, account)
 		if err != nil {
 			return err
 		}
+		// the immutable account secret is deleted to update the password when the password is rotated
+		password, rotatedAt, err := getRotatedAccountPassword(transCtx, synthesizeComp, account)
+		if err != nil {
+			return err
+		}
+		if len(password) > 0 {
+			secret = t.buildAccountSecretWithPassword(synthesizeComp, account, password)
+			secret.Annotations = map[string]string{constant.PasswordRotatedAtAnnotationKey: rotatedAt}
+		}
+		if accountPasswordRotationPolicy(transCtx.Component, account.Name) != nil {
+			// the password is updated in place when it is rotated
+			secret.Immutable = nil
+		}
+		if store != nil {
+			if err = t.writeAccountSecretToStore(transCtx, store, synthesizeComp, account, secret); err != nil {
+				return err
+			}
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[constant.SecretStoreSyncedAnnotationKey] = trueVal
+		}
 		graphCli.Create(dag, secret, inUniversalContext4G())
 	}
+	if !reflect.DeepEqual(transCtx.ComponentOrig.Annotations, transCtx.Component.Annotations) {
+		graphCli.Update(dag, transCtx.ComponentOrig, transCtx.Component, &model.ReplaceIfExistingOption{})
+	}
 	return nil
 }
 
-func (t *componentAccountTransformer) checkAccountSecretExist(ctx graph.TransformContext,
-	synthesizeComp *component.SynthesizedComponent, account appsv1alpha1.SystemAccount) (bool, error) {
+func (t *componentAccountTransformer) getAccountSecret(ctx graph.TransformContext,
+	synthesizeComp *component.SynthesizedComponent, account appsv1alpha1.SystemAccount) (*corev1.Secret, error) {
 	secretKey := types.NamespacedName{
 		Namespace: synthesizeComp.Namespace,
 		Name:      constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name),
 	}
-	err := ctx.GetClient().Get(ctx.GetContext(), secretKey, &corev1.Secret{})
+	secret := &corev1.Secret{}
+	err := ctx.GetClient().Get(ctx.GetContext(), secretKey, secret)
 	switch {
 	case err == nil:
-		return true, nil
+		return secret, nil
 	case apierrors.IsNotFound(err):
-		return false, nil
+		return nil, nil
 	default:
-		return false, err
+		return nil, err
+	}
+}
+
+// writeAccountSecretToStore writes the credential of the account to the external secret store.
+func (t *componentAccountTransformer) writeAccountSecretToStore(ctx graph.TransformContext, store secretstore.Store,
+	synthesizeComp *component.SynthesizedComponent, account appsv1alpha1.SystemAccount, secret *corev1.Secret) error {
+	secretPath := secretstore.AccountPath(synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+	return store.Put(ctx.GetContext(), secretPath, map[string][]byte{
+		constant.AccountNameForSecret:   secret.Data[constant.AccountNameForSecret],
+		constant.AccountPasswdForSecret: secret.Data[constant.AccountPasswdForSecret],
+	})
+}
+
+// provisionAccountInStore writes the credential of the account to the external secret store if it doesn't exist.
+func (t *componentAccountTransformer) provisionAccountInStore(transCtx *componentTransformContext, store secretstore.Store,
+	synthesizeComp *component.SynthesizedComponent, account appsv1alpha1.SystemAccount) error {
+	secretPath := secretstore.AccountPath(synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+	if _, err := store.Get(transCtx.Context, secretPath); err == nil || !errors.Is(err, secretstore.ErrNotFound) {
+		return err
+	}
+	secret, err := t.buildAccountSecret(transCtx, synthesizeComp, account)
+	if err != nil {
+		return err
+	}
+	return t.writeAccountSecretToStore(transCtx, store, synthesizeComp, account, secret)
+}
+
+// isAccountProvisionedInStore checks if the credential of the account has been provisioned in the external secret store,
+// so the store is not requested in every reconciliation.
+func isAccountProvisionedInStore(comp *appsv1alpha1.Component, accountName string) bool {
+	accounts := comp.Annotations[constant.SecretStoreProvisionedAccountsAnnotationKey]
+	return slices.Contains(strings.Split(accounts, ","), accountName)
+}
+
+func markAccountProvisionedInStore(comp *appsv1alpha1.Component, accountName string) {
+	if comp.Annotations == nil {
+		comp.Annotations = map[string]string{}
+	}
+	var accounts []string
+	if value := comp.Annotations[constant.SecretStoreProvisionedAccountsAnnotationKey]; value != "" {
+		accounts = strings.Split(value, ",")
+	}
+	comp.Annotations[constant.SecretStoreProvisionedAccountsAnnotationKey] = strings.Join(append(accounts, accountName), ",")
+}
+
+// getAccountCredential returns the username and the password of the account, they are read from the external secret
+// store if the passwords are injected into the pods from it, and from the account secret otherwise.
+func getAccountCredential(transCtx *componentTransformContext, synthesizeComp *component.SynthesizedComponent,
+	account appsv1alpha1.SystemAccount) ([]byte, []byte, error) {
+	store, _, err := secretstore.DefaultInjector()
+	if err != nil {
+		return nil, nil, err
+	}
+	var data map[string][]byte
+	if store != nil {
+		secretPath := secretstore.AccountPath(synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+		if data, err = store.Get(transCtx.Context, secretPath); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{
+			Namespace: synthesizeComp.Namespace,
+			Name:      constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name),
+		}
+		if err = transCtx.Client.Get(transCtx.Context, secretKey, secret); err != nil {
+			return nil, nil, err
+		}
+		data = secret.Data
+	}
+	return data[constant.AccountNameForSecret], data[constant.AccountPasswdForSecret], nil
+}
+
+// buildAccountInjectionAnnotations returns the pod annotations to inject the passwords of the system accounts
+// from the external secret store, if the store supports it.
+func buildAccountInjectionAnnotations(synthesizeComp *component.SynthesizedComponent) (map[string]string, error) {
+	_, injector, err := secretstore.DefaultInjector()
+	if err != nil || injector == nil {
+		return nil, err
+	}
+	annotations := map[string]string{}
+	for _, account := range synthesizeComp.SystemAccounts {
+		secretPath := secretstore.AccountPath(synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+		for k, v := range injector.InjectionAnnotations(secretstore.AccountInjectionName(account.Name), secretPath, constant.AccountPasswdForSecret) {
+			annotations[k] = v
+		}
 	}
+	return annotations, nil
 }
 
 func (t *componentAccountTransformer) buildAccountSecret(ctx *componentTransformContext,
@@ -26,13 +26,11 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
-	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -218,12 +216,10 @@ func (t *componentAccountProvisionTransformer) provisionAccount(transCtx *compon
 	cond metav1.Condition, lorryCli lorry.Client, account appsv1alpha1.SystemAccount) error {
 
 	synthesizedComp := transCtx.SynthesizeComponent
-	secret, err := t.getAccountSecret(transCtx, synthesizedComp, account)
+	username, password, err := getAccountCredential(transCtx, synthesizedComp, account)
 	if err != nil {
 		return err
 	}
-
-	username, password := secret.Data[constant.AccountNameForSecret], secret.Data[constant.AccountPasswdForSecret]
 	if len(username) == 0 || len(password) == 0 {
 		return nil
 	}
@@ -231,16 +227,3 @@ func (t *componentAccountProvisionTransformer) provisionAccount(transCtx *compon
 	// TODO: re-define the role
 	return lorryCli.CreateUser(transCtx.Context, string(username), string(password), string(lorryModel.SuperUserRole))
 }
-
-func (t *componentAccountProvisionTransformer) getAccountSecret(ctx graph.TransformContext,
-	synthesizeComp *component.SynthesizedComponent, account appsv1alpha1.SystemAccount) (*corev1.Secret, error) {
-	secretKey := types.NamespacedName{
-		Namespace: synthesizeComp.Namespace,
-		Name:      constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name),
-	}
-	secret := &corev1.Secret{}
-	if err := ctx.GetClient().Get(ctx.GetContext(), secretKey, secret); err != nil {
-		return nil, err
-	}
-	return secret, nil
-}
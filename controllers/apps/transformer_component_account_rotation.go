@@ -0,0 +1,300 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+)
+
+const (
+	// accountPreviousPasswdForSecret is the key of the password being rotated in the rotation secret.
+	accountPreviousPasswdForSecret = "previous-password"
+
+	// passwordRotationPending means the new password is persisted in the rotation secret, but not applied yet.
+	passwordRotationPending = "Pending"
+	// passwordRotationApplied means the new password has been applied to the database.
+	passwordRotationApplied = "Applied"
+)
+
+// componentAccountRotationTransformer rotates the passwords of the component system accounts periodically.
+//
+// The password of an account is rotated in the following steps:
+//  1. a rotation secret is created with the new password in the Pending phase when the account secret is older
+//     than the rotation period.
+//  2. after the rotation secret is committed, the new password is applied to the database, the rotation secret
+//     is moved to the Applied phase, and the account secret is updated with the new password in place. The password
+//     is never changed in the database before the secret holding it is committed, and applying it is idempotent,
+//     so it is safe to retry.
+//  3. the instances are restarted to pick up the new password by the componentWorkloadTransformer. The account
+//     secret created immutable before the rotation policy is set is deleted in step 2 instead, and re-created with
+//     the new password by the componentAccountTransformer.
+//  4. the old password is discarded after the overlap period, and the rotation secret is deleted.
+type componentAccountRotationTransformer struct{}
+
+var _ graph.Transformer = &componentAccountRotationTransformer{}
+
+func (t *componentAccountRotationTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+	if common.IsCompactMode(transCtx.ComponentOrig.Annotations) {
+		return nil
+	}
+	if transCtx.Component.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+		return nil
+	}
+	lifecycleActions := transCtx.CompDef.Spec.LifecycleActions
+	if lifecycleActions == nil || lifecycleActions.AccountProvision == nil {
+		return nil
+	}
+	// the rotation is driven by the account secrets, which are not created if the passwords are injected
+	// into the pods from the external secret store.
+	if _, injector, err := secretstore.DefaultInjector(); err != nil || injector != nil {
+		return err
+	}
+
+	var (
+		lorryCli    lorry.Client
+		nextRequeue time.Duration
+	)
+	for _, account := range transCtx.SynthesizeComponent.SystemAccounts {
+		policy := accountPasswordRotationPolicy(transCtx.Component, account.Name)
+		if policy == nil || policy.Period.Duration <= 0 {
+			continue
+		}
+		if lorryCli == nil {
+			var err error
+			lorryCli, err = (&componentAccountProvisionTransformer{}).buildLorryClient(transCtx)
+			if err != nil {
+				return err
+			}
+			if controllerutil.IsNil(lorryCli) {
+				return nil
+			}
+		}
+		requeue, err := t.rotateAccount(transCtx, dag, lorryCli, account, policy)
+		if err != nil {
+			return err
+		}
+		if requeue > 0 && (nextRequeue == 0 || requeue < nextRequeue) {
+			nextRequeue = requeue
+		}
+	}
+	if nextRequeue > 0 {
+		return controllerutil.NewDelayedRequeueError(nextRequeue, "rotate the passwords of system accounts")
+	}
+	return nil
+}
+
+// rotateAccount moves the password rotation of the account one step forward,
+// and returns the duration after which the rotation should be checked again.
+func (t *componentAccountRotationTransformer) rotateAccount(transCtx *componentTransformContext, dag *graph.DAG,
+	lorryCli lorry.Client, account appsv1alpha1.SystemAccount, policy *appsv1alpha1.PasswordRotationPolicy) (time.Duration, error) {
+	synthesizeComp := transCtx.SynthesizeComponent
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	secret, err := getAccountSecretByName(transCtx, synthesizeComp,
+		constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name))
+	if err != nil || secret == nil {
+		// the account secret is being re-created
+		return 0, err
+	}
+	rotationSecret, err := getAccountSecretByName(transCtx, synthesizeComp, accountRotationSecretName(secret.Name))
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case rotationSecret == nil:
+		due := secret.CreationTimestamp.Add(policy.Period.Duration)
+		if time.Now().Before(due) {
+			return time.Until(due), nil
+		}
+		password := (&componentAccountTransformer{}).generatePassword(account)
+		graphCli.Create(dag, t.buildRotationSecret(synthesizeComp, secret, password))
+		return 0, nil
+
+	case rotationSecret.Annotations[constant.PasswordRotationPhaseAnnotationKey] != passwordRotationApplied:
+		// the rotation secret is read from the API server, so the new password has been persisted.
+		if err = t.applyPassword(transCtx, lorryCli, rotationSecret, policy); err != nil {
+			return 0, err
+		}
+		rotatedAt := time.Now().UTC().Format(time.RFC3339)
+		rotationSecretCopy := rotationSecret.DeepCopy()
+		rotationSecretCopy.Annotations[constant.PasswordRotationPhaseAnnotationKey] = passwordRotationApplied
+		rotationSecretCopy.Annotations[constant.PasswordRotatedAtAnnotationKey] = rotatedAt
+		graphCli.Update(dag, rotationSecret, rotationSecretCopy)
+		if secret.Immutable != nil && *secret.Immutable {
+			// the account secret created before the rotation policy is set is immutable,
+			// it will be re-created with the new password
+			graphCli.Delete(dag, secret, inUniversalContext4G())
+			return 0, nil
+		}
+		secretCopy := secret.DeepCopy()
+		secretCopy.Data[constant.AccountPasswdForSecret] = rotationSecret.Data[constant.AccountPasswdForSecret]
+		if secretCopy.Annotations == nil {
+			secretCopy.Annotations = map[string]string{}
+		}
+		secretCopy.Annotations[constant.PasswordRotatedAtAnnotationKey] = rotatedAt
+		// the new password will be written to the secret store by the componentAccountTransformer
+		delete(secretCopy.Annotations, constant.SecretStoreSyncedAnnotationKey)
+		graphCli.Update(dag, secret, secretCopy, inUniversalContext4G())
+		return 0, nil
+
+	default:
+		if !secret.DeletionTimestamp.IsZero() ||
+			string(secret.Data[constant.AccountPasswdForSecret]) != string(rotationSecret.Data[constant.AccountPasswdForSecret]) {
+			// wait for the account secret to be updated or re-created with the new password
+			return 0, nil
+		}
+		if policy.OverlapPeriod != nil && policy.OverlapPeriod.Duration > 0 {
+			rotatedAt, _ := time.Parse(time.RFC3339, rotationSecret.Annotations[constant.PasswordRotatedAtAnnotationKey])
+			if overlapEnd := rotatedAt.Add(policy.OverlapPeriod.Duration); time.Now().Before(overlapEnd) {
+				return time.Until(overlapEnd), nil
+			}
+			if err = lorryCli.DiscardPassword(transCtx.Context, string(secret.Data[constant.AccountNameForSecret]),
+				string(rotationSecret.Data[accountPreviousPasswdForSecret])); err != nil {
+				return 0, err
+			}
+		}
+		graphCli.Delete(dag, rotationSecret)
+		return policy.Period.Duration, nil
+	}
+}
+
+// applyPassword applies the new password in the rotation secret to the database. It may be retried after the password
+// has been applied, if the rotation secret failed to be moved to the Applied phase, so the previous password is
+// restored first when it is retained, otherwise the new password would be retained as the current one.
+func (t *componentAccountRotationTransformer) applyPassword(transCtx *componentTransformContext, lorryCli lorry.Client,
+	rotationSecret *corev1.Secret, policy *appsv1alpha1.PasswordRotationPolicy) error {
+	userName := string(rotationSecret.Data[constant.AccountNameForSecret])
+	password := string(rotationSecret.Data[constant.AccountPasswdForSecret])
+	retainCurrent := policy.OverlapPeriod != nil && policy.OverlapPeriod.Duration > 0
+	if retainCurrent {
+		previous := string(rotationSecret.Data[accountPreviousPasswdForSecret])
+		if err := lorryCli.UpdatePassword(transCtx.Context, userName, previous, false); err != nil {
+			return err
+		}
+	}
+	return lorryCli.UpdatePassword(transCtx.Context, userName, password, retainCurrent)
+}
+
+func (t *componentAccountRotationTransformer) buildRotationSecret(synthesizeComp *component.SynthesizedComponent,
+	secret *corev1.Secret, password []byte) *corev1.Secret {
+	labels := constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name)
+	return builder.NewSecretBuilder(synthesizeComp.Namespace, accountRotationSecretName(secret.Name)).
+		AddLabelsInMap(labels).
+		AddAnnotations(constant.PasswordRotationPhaseAnnotationKey, passwordRotationPending).
+		PutData(constant.AccountNameForSecret, secret.Data[constant.AccountNameForSecret]).
+		PutData(constant.AccountPasswdForSecret, password).
+		PutData(accountPreviousPasswdForSecret, secret.Data[constant.AccountPasswdForSecret]).
+		GetObject()
+}
+
+// accountPasswordRotationPolicy returns the password rotation policy of the account specified in the component.
+func accountPasswordRotationPolicy(comp *appsv1alpha1.Component, accountName string) *appsv1alpha1.PasswordRotationPolicy {
+	for _, account := range comp.Spec.SystemAccounts {
+		if account.Name == accountName {
+			return account.PasswordRotation
+		}
+	}
+	return nil
+}
+
+func accountRotationSecretName(accountSecretName string) string {
+	return accountSecretName + "-rotation"
+}
+
+func getAccountSecretByName(transCtx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: synthesizeComp.Namespace, Name: name}
+	if err := transCtx.Client.Get(transCtx.Context, secretKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// getRotatedAccountPassword returns the new password and the time it is applied if the password of
+// the account has been rotated in the database, which is used to re-create the immutable account secret.
+func getRotatedAccountPassword(transCtx *componentTransformContext, synthesizeComp *component.SynthesizedComponent,
+	account appsv1alpha1.SystemAccount) ([]byte, string, error) {
+	secretName := constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+	rotationSecret, err := getAccountSecretByName(transCtx, synthesizeComp, accountRotationSecretName(secretName))
+	if err != nil || rotationSecret == nil {
+		return nil, "", err
+	}
+	if rotationSecret.Annotations[constant.PasswordRotationPhaseAnnotationKey] != passwordRotationApplied {
+		return nil, "", nil
+	}
+	return rotationSecret.Data[constant.AccountPasswdForSecret], rotationSecret.Annotations[constant.PasswordRotatedAtAnnotationKey], nil
+}
+
+// buildAccountPasswordRotatedAnnotation sets the last time the passwords of the system accounts were rotated
+// in the pod template, to restart the instances to pick up the new passwords.
+func buildAccountPasswordRotatedAnnotation(transCtx *componentTransformContext, protoITS *workloads.InstanceSet) error {
+	synthesizeComp := transCtx.SynthesizeComponent
+	lastRotatedAt := ""
+	for _, account := range synthesizeComp.SystemAccounts {
+		if accountPasswordRotationPolicy(transCtx.Component, account.Name) == nil {
+			continue
+		}
+		secret, err := getAccountSecretByName(transCtx, synthesizeComp,
+			constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name))
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			continue
+		}
+		// RFC3339 timestamps in UTC are ordered lexicographically
+		if rotatedAt := secret.Annotations[constant.PasswordRotatedAtAnnotationKey]; rotatedAt > lastRotatedAt {
+			lastRotatedAt = rotatedAt
+		}
+	}
+	if lastRotatedAt == "" {
+		return nil
+	}
+	if protoITS.Spec.Template.Annotations == nil {
+		protoITS.Spec.Template.Annotations = map[string]string{}
+	}
+	protoITS.Spec.Template.Annotations[constant.PasswordRotatedAtAnnotationKey] = lastRotatedAt
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"path"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+// injectedSecretStore is an in-memory secret store which injects the secrets into the pods.
+type injectedSecretStore struct {
+	sync.Mutex
+	secrets map[string]map[string][]byte
+}
+
+var _ secretstore.Store = &injectedSecretStore{}
+var _ secretstore.PodInjector = &injectedSecretStore{}
+
+func (s *injectedSecretStore) Get(_ context.Context, secretPath string) (map[string][]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+	data, ok := s.secrets[secretPath]
+	if !ok {
+		return nil, secretstore.ErrNotFound
+	}
+	return data, nil
+}
+
+func (s *injectedSecretStore) Put(_ context.Context, secretPath string, data map[string][]byte) error {
+	s.Lock()
+	defer s.Unlock()
+	s.secrets[secretPath] = data
+	return nil
+}
+
+func (s *injectedSecretStore) Delete(_ context.Context, secretPath string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.secrets, secretPath)
+	return nil
+}
+
+func (s *injectedSecretStore) InjectionEnabled() bool {
+	return true
+}
+
+func (s *injectedSecretStore) InjectionAnnotations(name, secretPath, key string) map[string]string {
+	return map[string]string{"inject-" + name: secretPath + "#" + key}
+}
+
+func (s *injectedSecretStore) InjectedFilePath(name string) string {
+	return path.Join("/secrets", name)
+}
+
+var _ = Describe("component account transformer test", func() {
+	const compDefName = "test-compdef"
+	const clusterName = "test-cluster"
+	const compName = "default"
+
+	var transCtx *componentTransformContext
+	var dag *graph.DAG
+	var graphCli model.GraphClient
+	var cluster *appsv1alpha1.Cluster
+	var synthesizedComp *component.SynthesizedComponent
+
+	BeforeEach(func() {
+		By("Create a component definition")
+		compDefObj := testapps.NewComponentDefinitionFactory(compDefName).
+			WithRandomName().
+			SetDefaultSpec().
+			GetObject()
+
+		By("Creating a cluster")
+		cluster = testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+			WithRandomName().
+			AddComponentV2(compName, compDefObj.Name).
+			SetReplicas(1).
+			GetObject()
+
+		By("Creating a component")
+		fullCompName := constant.GenerateClusterComponentName(cluster.Name, compName)
+		compObj := testapps.NewComponentFactory(testCtx.DefaultNamespace, fullCompName, compDefObj.Name).
+			AddLabels(constant.AppInstanceLabelKey, cluster.Name).
+			AddLabels(constant.KBAppClusterUIDLabelKey, string(cluster.UID)).
+			SetReplicas(1).
+			GetObject()
+
+		graphCli = model.NewGraphClient(k8sClient)
+		reqCtx := intctrlutil.RequestCtx{
+			Ctx: ctx,
+			Log: logger,
+		}
+		var err error
+		synthesizedComp, err = component.BuildSynthesizedComponent(reqCtx, k8sClient, cluster, compDefObj, compObj)
+		Expect(err).Should(Succeed())
+		Expect(synthesizedComp.SystemAccounts).ShouldNot(BeEmpty())
+
+		transCtx = &componentTransformContext{
+			Context:             ctx,
+			Client:              graphCli,
+			Logger:              logger,
+			Cluster:             cluster,
+			CompDef:             compDefObj,
+			Component:           compObj,
+			ComponentOrig:       compObj.DeepCopy(),
+			SynthesizeComponent: synthesizedComp,
+		}
+		dag = mockDAG(graphCli, cluster)
+	})
+
+	AfterEach(func() {
+		secretstore.SetDefault(nil)
+	})
+
+	Context("system account secrets", func() {
+		It("creates the account secrets", func() {
+			Expect((&componentAccountTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+			Expect(graphCli.FindAll(dag, &corev1.Secret{})).Should(HaveLen(len(synthesizedComp.SystemAccounts)))
+		})
+
+		It("doesn't create the account secrets if the passwords are injected from the secret store", func() {
+			store := &injectedSecretStore{secrets: map[string]map[string][]byte{}}
+			secretstore.SetDefault(store)
+
+			Expect((&componentAccountTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+			Expect(graphCli.FindAll(dag, &corev1.Secret{})).Should(BeEmpty())
+
+			By("the credentials are written to the secret store")
+			for _, account := range synthesizedComp.SystemAccounts {
+				secretPath := secretstore.AccountPath(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name, account.Name)
+				data, err := store.Get(ctx, secretPath)
+				Expect(err).Should(Succeed())
+				Expect(string(data[constant.AccountNameForSecret])).Should(Equal(account.Name))
+				Expect(data[constant.AccountPasswdForSecret]).ShouldNot(BeEmpty())
+			}
+
+			By("the accounts are marked as provisioned in the component")
+			for _, account := range synthesizedComp.SystemAccounts {
+				Expect(isAccountProvisionedInStore(transCtx.Component, account.Name)).Should(BeTrue())
+			}
+			Expect(graphCli.FindAll(dag, &appsv1alpha1.Component{})).Should(HaveLen(1))
+
+			By("the passwords are kept in the following reconciliations")
+			secretPath := secretstore.AccountPath(synthesizedComp.Namespace, synthesizedComp.ClusterName,
+				synthesizedComp.Name, synthesizedComp.SystemAccounts[0].Name)
+			data, _ := store.Get(ctx, secretPath)
+			transCtx.ComponentOrig = transCtx.Component.DeepCopy()
+			dag = mockDAG(graphCli, cluster)
+			Expect((&componentAccountTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+			Expect(graphCli.FindAll(dag, &corev1.Secret{})).Should(BeEmpty())
+			Expect(store.Get(ctx, secretPath)).Should(Equal(data))
+
+			By("the store is not requested for the provisioned accounts")
+			Expect(store.Delete(ctx, secretPath)).Should(Succeed())
+			dag = mockDAG(graphCli, cluster)
+			Expect((&componentAccountTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+			_, err := store.Get(ctx, secretPath)
+			Expect(err).Should(MatchError(secretstore.ErrNotFound))
+			Expect(graphCli.FindAll(dag, &appsv1alpha1.Component{})).Should(BeEmpty())
+
+			By("the passwords are injected into the pods")
+			annotations, err := buildAccountInjectionAnnotations(synthesizedComp)
+			Expect(err).Should(Succeed())
+			Expect(annotations).Should(HaveKey("inject-" + secretstore.AccountInjectionName(synthesizedComp.SystemAccounts[0].Name)))
+		})
+	})
+})
@@ -25,8 +25,10 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -41,6 +43,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // componentDeletionTransformer handles component deletion
@@ -143,11 +146,17 @@ func (t *componentDeletionTransformer) deleteCompResources(transCtx *componentTr
 			if rsm.IsOwnedByRsm(object) {
 				continue
 			}
+			if err = t.deleteAccountFromStore(transCtx, object, matchLabels); err != nil {
+				return newRequeueError(requeueDuration, err.Error())
+			}
 			graphCli.Delete(dag, object)
 		}
 		graphCli.Status(dag, comp, transCtx.Component)
 		return newRequeueError(time.Second*1, "not all component sub-resources deleted")
 	} else {
+		if err = t.deleteInjectedAccountsFromStore(transCtx, comp, matchLabels, toDeleteKinds); err != nil {
+			return newRequeueError(requeueDuration, err.Error())
+		}
 		graphCli.Delete(dag, comp)
 	}
 
@@ -155,6 +164,55 @@ func (t *componentDeletionTransformer) deleteCompResources(transCtx *componentTr
 	return graph.ErrPrematureStop
 }
 
+// deleteAccountFromStore deletes the credential of the system account from the external secret store
+// when its secret is deleted.
+func (t *componentDeletionTransformer) deleteAccountFromStore(transCtx *componentTransformContext,
+	object client.Object, matchLabels map[string]string) error {
+	secret, ok := object.(*corev1.Secret)
+	if !ok || secret.Annotations[constant.SecretStoreSyncedAnnotationKey] != trueVal {
+		return nil
+	}
+	accountName, ok := secret.Labels[constant.ClusterAccountLabelKey]
+	if !ok {
+		return nil
+	}
+	store, err := secretstore.Default()
+	if err != nil || store == nil {
+		return err
+	}
+	secretPath := secretstore.AccountPath(secret.Namespace, matchLabels[constant.AppInstanceLabelKey],
+		matchLabels[constant.KBAppComponentLabelKey], accountName)
+	return store.Delete(transCtx.Context, secretPath)
+}
+
+// deleteInjectedAccountsFromStore deletes the credentials of the system accounts from the external secret store,
+// if they are injected into the pods and no account secrets are created, when the secrets of the component are deleted.
+func (t *componentDeletionTransformer) deleteInjectedAccountsFromStore(transCtx *componentTransformContext,
+	comp *appsv1alpha1.Component, matchLabels map[string]string, toDeleteKinds []client.ObjectList) error {
+	if !slices.ContainsFunc(toDeleteKinds, func(kind client.ObjectList) bool {
+		_, ok := kind.(*corev1.SecretList)
+		return ok
+	}) {
+		return nil
+	}
+	store, _, err := secretstore.DefaultInjector()
+	if err != nil || store == nil {
+		return err
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err = transCtx.Client.Get(transCtx.Context, types.NamespacedName{Name: comp.Spec.CompDef}, compDef); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	for _, account := range compDef.Spec.SystemAccounts {
+		secretPath := secretstore.AccountPath(comp.Namespace, matchLabels[constant.AppInstanceLabelKey],
+			matchLabels[constant.KBAppComponentLabelKey], account.Name)
+		if err = store.Delete(transCtx.Context, secretPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *componentDeletionTransformer) getCluster(transCtx *componentTransformContext, comp *appsv1alpha1.Component) (*appsv1alpha1.Cluster, error) {
 	clusterName, err := component.GetClusterName(comp)
 	if err != nil {
@@ -173,6 +231,7 @@ func compOwnedKinds() []client.ObjectList {
 		&workloads.InstanceSetList{},
 		&policyv1.PodDisruptionBudgetList{},
 		&corev1.ServiceList{},
+		&networkingv1.NetworkPolicyList{},
 		&corev1.ServiceAccountList{},
 		&rbacv1.RoleBindingList{},
 		&batchv1.JobList{},
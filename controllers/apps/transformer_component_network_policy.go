@@ -0,0 +1,286 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"reflect"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// componentNetworkPolicyTransformer handles the NetworkPolicy of the component.
+type componentNetworkPolicyTransformer struct{}
+
+var _ graph.Transformer = &componentNetworkPolicyTransformer{}
+
+func (t *componentNetworkPolicyTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	key := types.NamespacedName{
+		Namespace: synthesizeComp.Namespace,
+		Name:      constant.GenerateClusterComponentName(synthesizeComp.ClusterName, synthesizeComp.Name),
+	}
+	obj := &networkingv1.NetworkPolicy{}
+	if err := ctx.GetClient().Get(ctx.GetContext(), key, obj, inDataContext4C()); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obj = nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	networkPolicy := transCtx.Component.Spec.NetworkPolicy
+	if networkPolicy == nil || !networkPolicy.Enabled {
+		if obj != nil && model.IsOwnerOf(transCtx.ComponentOrig, obj) {
+			graphCli.Delete(dag, obj, inDataContext4G())
+		}
+		return nil
+	}
+
+	policy := buildComponentNetworkPolicy(transCtx.CompDef, synthesizeComp, networkPolicy.AllowedClients)
+	if obj == nil {
+		graphCli.Create(dag, policy, inDataContext4G())
+		return nil
+	}
+	objCopy := obj.DeepCopy()
+	objCopy.Spec = policy.Spec
+	if objCopy.Labels == nil {
+		objCopy.Labels = map[string]string{}
+	}
+	for k, v := range policy.Labels {
+		objCopy.Labels[k] = v
+	}
+	if !reflect.DeepEqual(obj, objCopy) {
+		graphCli.Update(dag, obj, objCopy, inDataContext4G())
+	}
+	return nil
+}
+
+// buildComponentNetworkPolicy builds the NetworkPolicy which selects the pods of the component by labels,
+// so the pods created by scaling out are covered by the policy as well.
+//
+// The policy allows the traffic:
+//   - from the pods of the same component to the container ports and the lorry port, for the replication between the replicas;
+//   - from the pods of the same cluster to the service ports, for the access between the components;
+//   - from the KubeBlocks namespace and the dataprotection pods to the service ports and the lorry port;
+//   - from anywhere to the metrics ports of the exporters, for the scraping of Prometheus;
+//   - from the allowed clients to the service ports.
+//
+// The pods of the cluster are selected by the managed-by label as well, so the pods which happen to have the same
+// instance label, such as the pods of a Helm release with the same name, are not allowed.
+func buildComponentNetworkPolicy(compDef *appsv1alpha1.ComponentDefinition, synthesizeComp *component.SynthesizedComponent,
+	allowedClients []networkingv1.NetworkPolicyPeer) *networkingv1.NetworkPolicy {
+	var (
+		clusterName = synthesizeComp.ClusterName
+		compName    = synthesizeComp.Name
+	)
+
+	lorryPorts := &networkPolicyPorts{}
+	lorryPorts.add(corev1.ProtocolTCP, intstr.FromString(constant.LorryHTTPPortName))
+	servicePorts := buildNetworkPolicyServicePorts(synthesizeComp)
+	replicationPorts := buildNetworkPolicyContainerPorts(synthesizeComp)
+	replicationPorts.add(corev1.ProtocolTCP, intstr.FromString(constant.LorryHTTPPortName))
+	managementPorts := &networkPolicyPorts{}
+	managementPorts.merge(servicePorts, lorryPorts)
+	monitorPorts := buildNetworkPolicyMonitorPorts(compDef, synthesizeComp)
+
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: constant.GetComponentWellKnownLabels(clusterName, compName),
+					},
+				},
+			},
+			Ports: replicationPorts.ports,
+		},
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{corev1.LabelMetadataName: viper.GetString(constant.CfgKeyCtrlrMgrNS)},
+					},
+				},
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: dptypes.BackupNameLabelKey, Operator: metav1.LabelSelectorOpExists},
+						},
+					},
+				},
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{constant.AppManagedByLabelKey: dptypes.AppName},
+					},
+				},
+			},
+			Ports: managementPorts.ports,
+		},
+	}
+	// the rule without ports allows all ports, so the rules of the service ports and the metrics ports
+	// are added only if there are such ports.
+	if len(servicePorts.ports) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: constant.GetClusterWellKnownLabels(clusterName),
+					},
+				},
+			},
+			Ports: servicePorts.ports,
+		})
+	}
+	if len(monitorPorts.ports) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			Ports: monitorPorts.ports,
+		})
+	}
+	if len(allowedClients) > 0 && len(servicePorts.ports) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From:  allowedClients,
+			Ports: servicePorts.ports,
+		})
+	}
+
+	return builder.NewNetworkPolicyBuilder(synthesizeComp.Namespace, constant.GenerateClusterComponentName(clusterName, compName)).
+		AddLabelsInMap(constant.GetComponentWellKnownLabels(clusterName, compName)).
+		SetPodSelector(metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				constant.AppInstanceLabelKey:    clusterName,
+				constant.KBAppComponentLabelKey: compName,
+			},
+		}).
+		AddPolicyTypes(networkingv1.PolicyTypeIngress).
+		AddIngressRules(rules...).
+		GetObject()
+}
+
+// networkPolicyPorts collects the ports of the NetworkPolicy rules without duplicates.
+type networkPolicyPorts struct {
+	ports  []networkingv1.NetworkPolicyPort
+	exists map[string]bool
+}
+
+func (p *networkPolicyPorts) add(protocol corev1.Protocol, port intstr.IntOrString) {
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	key := string(protocol) + "/" + port.String()
+	if p.exists == nil {
+		p.exists = map[string]bool{}
+	}
+	if p.exists[key] {
+		return
+	}
+	p.exists[key] = true
+	p.ports = append(p.ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port})
+}
+
+func (p *networkPolicyPorts) merge(others ...*networkPolicyPorts) {
+	for _, other := range others {
+		for _, port := range other.ports {
+			p.add(*port.Protocol, *port.Port)
+		}
+	}
+}
+
+// buildNetworkPolicyServicePorts returns the target ports of the component services.
+func buildNetworkPolicyServicePorts(synthesizeComp *component.SynthesizedComponent) *networkPolicyPorts {
+	ports := &networkPolicyPorts{}
+	for _, svc := range synthesizeComp.ComponentServices {
+		for _, svcPort := range svc.Spec.Ports {
+			port := svcPort.TargetPort
+			if port.Type == intstr.Int && port.IntVal == 0 && port.StrVal == "" {
+				port = intstr.FromInt(int(svcPort.Port))
+			}
+			ports.add(svcPort.Protocol, port)
+		}
+	}
+	return ports
+}
+
+// buildNetworkPolicyContainerPorts returns the ports declared by the containers of the component,
+// which include the ports for the replication between the replicas.
+func buildNetworkPolicyContainerPorts(synthesizeComp *component.SynthesizedComponent) *networkPolicyPorts {
+	ports := &networkPolicyPorts{}
+	if synthesizeComp.PodSpec == nil {
+		return ports
+	}
+	for _, container := range synthesizeComp.PodSpec.Containers {
+		for _, containerPort := range container.Ports {
+			ports.add(containerPort.Protocol, intstr.FromInt(int(containerPort.ContainerPort)))
+		}
+	}
+	return ports
+}
+
+// buildNetworkPolicyMonitorPorts returns the metrics ports of the built-in exporter and the metrics sidecars
+// defined by the ComponentDefinition.
+func buildNetworkPolicyMonitorPorts(compDef *appsv1alpha1.ComponentDefinition, synthesizeComp *component.SynthesizedComponent) *networkPolicyPorts {
+	ports := &networkPolicyPorts{}
+	addMetricsPort := func(scrapeConfig appsv1alpha1.PrometheusScrapeConfig, container *corev1.Container) {
+		switch {
+		case scrapeConfig.MetricsPort != "":
+			ports.add(corev1.ProtocolTCP, intstr.FromString(scrapeConfig.MetricsPort))
+		case container != nil && len(container.Ports) > 0:
+			// the first port of the container is scraped if the metrics port is not specified.
+			ports.add(container.Ports[0].Protocol, intstr.FromInt(int(container.Ports[0].ContainerPort)))
+		}
+	}
+	if compDef == nil {
+		return ports
+	}
+	if builtin := compDef.Spec.BuiltinMonitorContainer; builtin != nil && synthesizeComp.PodSpec != nil {
+		for i, container := range synthesizeComp.PodSpec.Containers {
+			if container.Name == builtin.Name {
+				addMetricsPort(builtin.PrometheusScrapeConfig, &synthesizeComp.PodSpec.Containers[i])
+			}
+		}
+	}
+	for i, sidecar := range compDef.Spec.SidecarContainerSpecs {
+		if !slices.Contains(synthesizeComp.Sidecars, sidecar.Name) || sidecar.Monitor == nil ||
+			sidecar.Monitor.SidecarKind != appsv1alpha1.MetricsKind || sidecar.Monitor.ScrapeConfig == nil {
+			continue
+		}
+		addMetricsPort(*sidecar.Monitor.ScrapeConfig, &compDef.Spec.SidecarContainerSpecs[i].Container)
+	}
+	return ports
+}
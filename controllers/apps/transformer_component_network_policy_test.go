@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+var _ = Describe("component network policy", func() {
+	portsOf := func(rule networkingv1.NetworkPolicyIngressRule) []string {
+		var ports []string
+		for _, port := range rule.Ports {
+			ports = append(ports, port.Port.String())
+		}
+		return ports
+	}
+
+	It("builds the rules of the replication, service and metrics ports", func() {
+		compDef := &appsv1alpha1.ComponentDefinition{
+			Spec: appsv1alpha1.ComponentDefinitionSpec{
+				BuiltinMonitorContainer: &appsv1alpha1.BuiltinMonitorContainerRef{Name: "exporter"},
+			},
+		}
+		synthesizeComp := &component.SynthesizedComponent{
+			ClusterName: "mycluster",
+			Name:        "mysql",
+			PodSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "mysql", Ports: []corev1.ContainerPort{{Name: "mysql", ContainerPort: 3306}, {Name: "paxos", ContainerPort: 13306}}},
+					{Name: "exporter", Ports: []corev1.ContainerPort{{Name: "http-metrics", ContainerPort: 9104}}},
+				},
+			},
+			ComponentServices: []appsv1alpha1.ComponentService{
+				{Service: appsv1alpha1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 3306}}}}},
+			},
+		}
+		allowedClients := []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}
+		policy := buildComponentNetworkPolicy(compDef, synthesizeComp, allowedClients)
+		rules := policy.Spec.Ingress
+		Expect(rules).Should(HaveLen(5))
+
+		By("expect the pods of the same component to access the container ports and the lorry port")
+		Expect(rules[0].From[0].PodSelector.MatchLabels).Should(Equal(constant.GetComponentWellKnownLabels("mycluster", "mysql")))
+		Expect(portsOf(rules[0])).Should(Equal([]string{"3306", "13306", "9104", constant.LorryHTTPPortName}))
+
+		By("expect the pods of the same cluster managed by KubeBlocks to access the service ports only")
+		Expect(rules[2].From[0].PodSelector.MatchLabels).Should(Equal(map[string]string{
+			constant.AppManagedByLabelKey: constant.AppName,
+			constant.AppInstanceLabelKey:  "mycluster",
+		}))
+		Expect(portsOf(rules[2])).Should(Equal([]string{"3306"}))
+
+		By("expect the metrics port to be open for the scraping")
+		Expect(rules[3].From).Should(BeEmpty())
+		Expect(portsOf(rules[3])).Should(Equal([]string{"9104"}))
+
+		By("expect the allowed clients to access the service ports")
+		Expect(rules[4].From).Should(Equal(allowedClients))
+		Expect(rules[4].Ports).Should(Equal([]networkingv1.NetworkPolicyPort{rules[2].Ports[0]}))
+		Expect(*rules[4].Ports[0].Port).Should(Equal(intstr.FromInt(3306)))
+	})
+})
@@ -25,6 +25,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -51,6 +52,14 @@ func (f *componentOwnershipTransformer) Transform(ctx graph.TransformContext, da
 		if skipSetCompOwnership(object) {
 			continue
 		}
+		// the objects of the third-party APIs, e.g. the certificates of cert-manager, are not deleted
+		// by the component explicitly, so they are garbage collected by the owner reference only.
+		if _, ok := object.(*unstructured.Unstructured); ok {
+			if err := controllerutil.SetControllerReference(comp, object, rscheme); err != nil {
+				return err
+			}
+			continue
+		}
 		// add component and cluster finalizers at the same time
 		addComponentFinalizer(object, comp)
 		if err := intctrlutil.SetOwnership(comp, object, rscheme, constant.DBClusterFinalizerName); err != nil {
@@ -27,9 +27,12 @@ import (
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
@@ -66,7 +69,7 @@ func (t *componentServiceTransformer) Transform(ctx graph.TransformContext, dag
 		if t.skipDefaultHeadlessSvc(synthesizeComp, &service) {
 			continue
 		}
-		services, err := t.buildCompService(transCtx.Component, synthesizeComp, &service)
+		services, err := t.buildCompService(transCtx, transCtx.Component, synthesizeComp, &service)
 		if err != nil {
 			return err
 		}
@@ -80,21 +83,26 @@ func (t *componentServiceTransformer) Transform(ctx graph.TransformContext, dag
 	return nil
 }
 
-func (t *componentServiceTransformer) buildCompService(comp *appsv1alpha1.Component,
+func (t *componentServiceTransformer) buildCompService(transCtx *componentTransformContext, comp *appsv1alpha1.Component,
 	synthesizeComp *component.SynthesizedComponent, service *appsv1alpha1.ComponentService) ([]*corev1.Service, error) {
 	if service.DisableAutoProvision != nil && *service.DisableAutoProvision {
 		return nil, nil
 	}
 
 	if service.PodService == nil || !*service.PodService {
-		return t.buildServices(comp, synthesizeComp, []*appsv1alpha1.ComponentService{service})
+		return t.buildServices(transCtx, comp, synthesizeComp, []*appsv1alpha1.ComponentService{service})
 	}
-	return t.buildPodService(comp, synthesizeComp, service)
+	return t.buildPodService(transCtx, comp, synthesizeComp, service)
 }
 
-func (t *componentServiceTransformer) buildPodService(comp *appsv1alpha1.Component,
+func (t *componentServiceTransformer) buildPodService(transCtx *componentTransformContext, comp *appsv1alpha1.Component,
 	synthesizeComp *component.SynthesizedComponent, service *appsv1alpha1.ComponentService) ([]*corev1.Service, error) {
-	pods, err := t.podsNameNOrdinal(synthesizeComp)
+	// the pod services select the active instances, which are named after the green set if the blue/green update switched to it.
+	its, err := t.runningInstanceSet(transCtx, synthesizeComp)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := t.podsNameNOrdinal(synthesizeComp, its)
 	if err != nil {
 		return nil, err
 	}
@@ -114,11 +122,27 @@ func (t *componentServiceTransformer) buildPodService(comp *appsv1alpha1.Compone
 		svc.Spec.Selector[constant.KBAppPodNameLabelKey] = name
 		services = append(services, svc)
 	}
-	return t.buildServices(comp, synthesizeComp, services)
+	return t.buildServices(transCtx, comp, synthesizeComp, services)
 }
 
-func (t *componentServiceTransformer) podsNameNOrdinal(synthesizeComp *component.SynthesizedComponent) (map[string]int, error) {
-	podNames := generatePodNames(synthesizeComp)
+func (t *componentServiceTransformer) runningInstanceSet(transCtx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent) (*workloads.InstanceSet, error) {
+	itsKey := types.NamespacedName{
+		Namespace: synthesizeComp.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(synthesizeComp.ClusterName, synthesizeComp.Name),
+	}
+	its := &workloads.InstanceSet{}
+	if err := transCtx.Client.Get(transCtx.Context, itsKey, its); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return its, nil
+}
+
+func (t *componentServiceTransformer) podsNameNOrdinal(synthesizeComp *component.SynthesizedComponent, its *workloads.InstanceSet) (map[string]int, error) {
+	podNames := generatePodNames(synthesizeComp, its)
 	pods := make(map[string]int)
 	for _, name := range podNames {
 		ordinal, err := func() (int, error) {
@@ -137,11 +161,11 @@ func (t *componentServiceTransformer) podsNameNOrdinal(synthesizeComp *component
 	return pods, nil
 }
 
-func (t *componentServiceTransformer) buildServices(comp *appsv1alpha1.Component,
+func (t *componentServiceTransformer) buildServices(transCtx *componentTransformContext, comp *appsv1alpha1.Component,
 	synthesizeComp *component.SynthesizedComponent, compServices []*appsv1alpha1.ComponentService) ([]*corev1.Service, error) {
 	services := make([]*corev1.Service, 0, len(compServices))
 	for _, compService := range compServices {
-		svc, err := t.buildService(comp, synthesizeComp, compService)
+		svc, err := t.buildService(transCtx, comp, synthesizeComp, compService)
 		if err != nil {
 			return nil, err
 		}
@@ -150,7 +174,7 @@ func (t *componentServiceTransformer) buildServices(comp *appsv1alpha1.Component
 	return services, nil
 }
 
-func (t *componentServiceTransformer) buildService(comp *appsv1alpha1.Component,
+func (t *componentServiceTransformer) buildService(transCtx *componentTransformContext, comp *appsv1alpha1.Component,
 	synthesizeComp *component.SynthesizedComponent, service *appsv1alpha1.ComponentService) (*corev1.Service, error) {
 	var (
 		namespace   = synthesizeComp.Namespace
@@ -172,10 +196,34 @@ func (t *componentServiceTransformer) buildService(comp *appsv1alpha1.Component,
 			return nil, err
 		}
 		builder.AddSelector(constant.RoleLabelKey, service.RoleSelector)
+		selectors, err := t.heldInstanceSelector(transCtx, synthesizeComp)
+		if err != nil {
+			return nil, err
+		}
+		builder.AddSelectorsInMap(selectors)
 	}
 	return builder.GetObject(), nil
 }
 
+// heldInstanceSelector returns the selector to exclude the instances held for maintenance from the Service
+// which selects the instances by roles.
+func (t *componentServiceTransformer) heldInstanceSelector(transCtx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent) (map[string]string, error) {
+	its, err := t.runningInstanceSet(transCtx, synthesizeComp)
+	if err != nil || its == nil || len(its.Spec.HeldInstances) == 0 {
+		return nil, err
+	}
+	pods, err := component.ListPodOwnedByComponent(transCtx.Context, transCtx.Client, synthesizeComp.Namespace,
+		constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name))
+	if err != nil {
+		return nil, err
+	}
+	selectors := map[string]string{constant.RoleLabelKey: ""}
+	instanceset.AddHeldInstanceSvcSelector(its, pods, selectors)
+	delete(selectors, constant.RoleLabelKey)
+	return selectors, nil
+}
+
 func (t *componentServiceTransformer) builtinSelector(comp *appsv1alpha1.Component) map[string]string {
 	selectors := map[string]string{
 		constant.AppManagedByLabelKey:   "",
@@ -208,7 +256,9 @@ func (t *componentServiceTransformer) skipDefaultHeadlessSvc(synthesizeComp *com
 	return svcName == defaultHeadlessSvcName
 }
 
-func generatePodNames(synthesizeComp *component.SynthesizedComponent) []string {
+// generatePodNames generates the names of the pods of the component, the names of the active instances are generated
+// if the running InstanceSet is given, which are named after the green set if the blue/green update switched to it.
+func generatePodNames(synthesizeComp *component.SynthesizedComponent, its *workloads.InstanceSet) []string {
 	templateReplicas := func(template appsv1alpha1.InstanceTemplate) int32 {
 		replicas := int32(1)
 		if template.Replicas != nil {
@@ -226,12 +276,20 @@ func generatePodNames(synthesizeComp *component.SynthesizedComponent) []string {
 
 	podNames := make([]string, 0)
 	workloadName := constant.GenerateWorkloadNamePattern(synthesizeComp.ClusterName, synthesizeComp.Name)
+	offlineInstances := synthesizeComp.OfflineInstances
+	if its != nil {
+		workloadName = instanceset.GetInstanceNamePrefix(its)
+		offlineInstances = make([]string, 0, len(synthesizeComp.OfflineInstances))
+		for _, name := range synthesizeComp.OfflineInstances {
+			offlineInstances = append(offlineInstances, instanceset.GetActiveInstanceName(its, name))
+		}
+	}
 	for _, template := range synthesizeComp.Instances {
-		templateNames := instanceset.GenerateInstanceNamesFromTemplate(workloadName, template.Name, templateReplicas(template), synthesizeComp.OfflineInstances)
+		templateNames := instanceset.GenerateInstanceNamesFromTemplate(workloadName, template.Name, templateReplicas(template), offlineInstances)
 		podNames = append(podNames, templateNames...)
 	}
 	if templateReplicasCnt < synthesizeComp.Replicas {
-		names := instanceset.GenerateInstanceNamesFromTemplate(workloadName, "", synthesizeComp.Replicas-templateReplicasCnt, synthesizeComp.OfflineInstances)
+		names := instanceset.GenerateInstanceNamesFromTemplate(workloadName, "", synthesizeComp.Replicas-templateReplicasCnt, offlineInstances)
 		podNames = append(podNames, names...)
 	}
 	return podNames
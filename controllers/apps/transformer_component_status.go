@@ -70,6 +70,8 @@ type componentStatusHandler struct {
 	protoITS *workloads.InstanceSet
 	// podsReady indicates if the component's underlying pods are ready
 	podsReady bool
+	// requeueAfter indicates the duration after which the component status should be reconciled again
+	requeueAfter time.Duration
 }
 
 var _ graph.Transformer = &componentStatusTransformer{}
@@ -94,6 +96,7 @@ func (t *componentStatusTransformer) Transform(ctx graph.TransformContext, dag *
 	synthesizeComp := transCtx.SynthesizeComponent
 	runningITS, _ := transCtx.RunningWorkload.(*workloads.InstanceSet)
 	protoITS, _ := transCtx.ProtoWorkload.(*workloads.InstanceSet)
+	var requeueAfter time.Duration
 	switch {
 	case model.IsObjectUpdating(transCtx.ComponentOrig):
 		transCtx.Logger.Info(fmt.Sprintf("update component status after applying resources, generation: %d", comp.Generation))
@@ -105,6 +108,7 @@ func (t *componentStatusTransformer) Transform(ctx graph.TransformContext, dag *
 			return err
 		}
 		comp = csh.comp
+		requeueAfter = csh.requeueAfter
 	}
 
 	graphCli, _ := transCtx.Client.(model.GraphClient)
@@ -116,6 +120,9 @@ func (t *componentStatusTransformer) Transform(ctx graph.TransformContext, dag *
 		}
 	}
 	graphCli.Status(dag, transCtx.ComponentOrig, comp)
+	if requeueAfter > 0 {
+		return intctrlutil.NewRequeueError(requeueAfter, "requeue to check the instances to be rebuilt")
+	}
 	return nil
 }
 
@@ -219,6 +226,20 @@ func (r *componentStatusHandler) reconcileComponentStatus() error {
 		r.setComponentStatusPhase(appsv1alpha1.AbnormalClusterCompPhase, nil, "component is Abnormal")
 	}
 
+	// rebuild the unrecoverable instances automatically
+	if r.requeueAfter, err = r.reconcileAutoHeal(pods); err != nil {
+		return err
+	}
+
+	// rebuild the instances in the under-represented zones
+	requeueAfter, err := r.reconcileRebalance()
+	if err != nil {
+		return err
+	}
+	if requeueAfter > 0 && (r.requeueAfter == 0 || requeueAfter < r.requeueAfter) {
+		r.requeueAfter = requeueAfter
+	}
+
 	// set primary-pod annotation
 	// TODO(free6om): primary-pod is only used in redis to bootstrap the redis cluster correctly.
 	// it is too hacky to be replaced by a better design.
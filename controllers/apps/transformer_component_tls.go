@@ -22,13 +22,19 @@ package apps
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
@@ -36,6 +42,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/plan"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 )
 
 // componentTLSTransformer handles component configuration render
@@ -55,14 +62,24 @@ func (t *componentTLSTransformer) Transform(ctx graph.TransformContext, dag *gra
 	}
 
 	// build tls cert
-	if err := buildTLSCert(transCtx.Context, transCtx.Client, *synthesizedComp, dag); err != nil {
+	certStatus, err := buildTLSCert(transCtx.Context, transCtx.Client, *synthesizedComp, dag)
+	if err != nil {
 		return err
 	}
+	transCtx.Component.Status.TLSCertificate = certStatus
 
 	if err := checkAndTriggerReRender(transCtx.Context, *synthesizedComp, t.Client); err != nil {
 		return err
 	}
 
+	if err := reloadTLSCert(transCtx, certStatus); err != nil {
+		return err
+	}
+
+	// requeue to renew the certificate before it expires
+	if certStatus != nil && certStatus.RenewalTime != nil {
+		return intctrlutil.NewDelayedRequeueError(time.Until(certStatus.RenewalTime.Time)+time.Second, "renew the TLS certificate")
+	}
 	return nil
 }
 
@@ -131,32 +148,197 @@ func checkAndTriggerReRender(ctx context.Context, synthesizedComp component.Synt
 	return nil
 }
 
-func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) error {
+func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) (*appsv1alpha1.TLSCertificateStatus, error) {
 	tls := synthesizedComp.TLSConfig
 	if tls == nil || !tls.Enable {
-		return nil
+		return nil, nil
 	}
 	if tls.Issuer == nil {
-		return fmt.Errorf("issuer shouldn't be nil when tls enabled")
+		return nil, fmt.Errorf("issuer shouldn't be nil when tls enabled")
 	}
 
 	switch tls.Issuer.Name {
 	case appsv1alpha1.IssuerUserProvided:
 		if err := plan.CheckTLSSecretRef(ctx, cli, synthesizedComp.Namespace, tls.Issuer.SecretRef); err != nil {
-			return err
+			return nil, err
+		}
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: tls.Issuer.SecretRef.Name}
+		if err := cli.Get(ctx, secretKey, secret); err != nil {
+			return nil, err
 		}
+		return buildTLSCertStatus(secret, tls.Issuer.SecretRef.Cert, nil), nil
 	case appsv1alpha1.IssuerKubeBlocks:
-		secret, err := plan.ComposeTLSSecret(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+		return buildKubeBlocksTLSCert(ctx, cli, synthesizedComp, dag)
+	case appsv1alpha1.IssuerCertManager:
+		return buildCertManagerTLSCert(ctx, cli, synthesizedComp, dag)
+	}
+	return nil, nil
+}
+
+// buildKubeBlocksTLSCert creates the self-signed certificate, and rotates it when it is going to expire.
+func buildKubeBlocksTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) (*appsv1alpha1.TLSCertificateStatus, error) {
+	issuer := synthesizedComp.TLSConfig.Issuer
+	validity := plan.DefaultTLSCertValidity
+	if issuer.Duration != nil && issuer.Duration.Duration > 0 {
+		validity = issuer.Duration.Duration
+	}
+	proto, err := plan.ComposeTLSSecretWithValidity(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	graphCli, _ := cli.(model.GraphClient)
+	secret := &corev1.Secret{}
+	if err = cli.Get(ctx, client.ObjectKeyFromObject(proto), secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		graphCli.Create(dag, proto)
+		return buildTLSCertStatus(proto, constant.CertName, issuer.RenewBefore), nil
+	}
+
+	status := buildTLSCertStatus(secret, constant.CertName, issuer.RenewBefore)
+	if status.RenewalTime != nil && time.Now().Before(status.RenewalTime.Time) {
+		return status, nil
+	}
+	// rotate the certificate which is going to expire or can't be parsed, the previous CA is kept in the CA bundle,
+	// so the clients which have not picked up the new CA keep working until the previous one expires.
+	secretCopy := secret.DeepCopy()
+	if secretCopy.Data == nil {
+		secretCopy.Data = map[string][]byte{}
+	}
+	for key, value := range proto.StringData {
+		secretCopy.Data[key] = []byte(value)
+	}
+	secretCopy.Data[constant.CAName] = plan.BuildCABundle(secretCopy.Data[constant.CAName], secret.Data[constant.CAName])
+	graphCli.Update(dag, secret, secretCopy)
+	return buildTLSCertStatus(secretCopy, constant.CertName, issuer.RenewBefore), nil
+}
+
+// buildCertManagerTLSCert creates the cert-manager Certificate, which issues and renews the certificate.
+func buildCertManagerTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) (*appsv1alpha1.TLSCertificateStatus, error) {
+	issuer := synthesizedComp.TLSConfig.Issuer
+	if issuer.CertManager == nil {
+		return nil, fmt.Errorf("issuer.certManager shouldn't be nil when issuer is CertManager")
+	}
+	proto := plan.ComposeCertManagerCertificate(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name, issuer)
+
+	graphCli, _ := cli.(model.GraphClient)
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(plan.CertManagerCertificateGVK)
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(proto), certificate); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		graphCli.Create(dag, proto)
+		return nil, nil
+	}
+	if !reflect.DeepEqual(certificate.Object["spec"], proto.Object["spec"]) {
+		certificateCopy := certificate.DeepCopy()
+		certificateCopy.Object["spec"] = proto.Object["spec"]
+		graphCli.Update(dag, certificate, certificateCopy)
+	}
+
+	// the secret is created by cert-manager after the certificate is issued.
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)}
+	if err := cli.Get(ctx, secretKey, secret); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	status := buildTLSCertStatus(secret, constant.CertName, issuer.RenewBefore)
+	// prefer the renewal time scheduled by cert-manager
+	if renewalTime, ok, _ := unstructured.NestedString(certificate.Object, "status", "renewalTime"); ok {
+		if t, err := time.Parse(time.RFC3339, renewalTime); err == nil {
+			status.RenewalTime = &metav1.Time{Time: t}
+		}
+	}
+	return status, nil
+}
+
+// buildTLSCertStatus builds the status of the certificate in the secret, only the secret name is set if the
+// certificate can't be parsed. The renewal time is not set if renewBefore is nil, for the user-provided certificates.
+func buildTLSCertStatus(secret *corev1.Secret, certKey string, renewBefore *metav1.Duration) *appsv1alpha1.TLSCertificateStatus {
+	status := &appsv1alpha1.TLSCertificateStatus{SecretName: secret.Name}
+	cert, err := plan.ParseTLSCertificate(secret, certKey)
+	if err != nil {
+		return status
+	}
+	status.SerialNumber = plan.GetTLSCertVersion(cert)
+	status.NotBefore = &metav1.Time{Time: cert.NotBefore}
+	status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+	if secret.Labels[constant.KBManagedByKey] == constant.AppName {
+		status.RenewalTime = &metav1.Time{Time: plan.GetTLSCertRenewalTime(cert, renewBefore)}
+	}
+	return status
+}
+
+// reloadTLSCert invokes the reconfigure action on the instances to reload the rotated certificate, if the engine
+// reloads the certificates dynamically. The certificate is reloaded after the rotated secret is committed, and the
+// action fails on the instances whose mounted certificate has not been refreshed yet, which is retried later.
+func reloadTLSCert(transCtx *componentTransformContext, certStatus *appsv1alpha1.TLSCertificateStatus) error {
+	compDef := transCtx.CompDef
+	if certStatus == nil || certStatus.SerialNumber == "" || compDef.Spec.TLSCertReloadPolicy != appsv1alpha1.TLSCertReloadPolicyReload {
+		return nil
+	}
+	origStatus := transCtx.ComponentOrig.Status.TLSCertificate
+	if origStatus == nil || origStatus.SerialNumber == "" {
+		// the instances have been using the certificate since they were created.
+		certStatus.ReloadedSerialNumber = certStatus.SerialNumber
+		return nil
+	}
+	certStatus.ReloadedSerialNumber = origStatus.ReloadedSerialNumber
+	// the serial number in the original status is of the certificate committed.
+	if origStatus.SerialNumber == origStatus.ReloadedSerialNumber {
+		return nil
+	}
+	if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.Reconfigure == nil {
+		return fmt.Errorf("the reconfigure action is required to reload the TLS certificate")
+	}
+	synthesizedComp := transCtx.SynthesizeComponent
+	pods, err := component.ListPodOwnedByComponent(transCtx.Context, transCtx.Client, synthesizedComp.Namespace,
+		constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, synthesizedComp.Name))
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		lorryCli, err := lorry.NewClient(*pod)
 		if err != nil {
 			return err
 		}
-		graphCli, _ := cli.(model.GraphClient)
-		graphCli.Create(dag, secret)
+		if intctrlutil.IsNil(lorryCli) {
+			return intctrlutil.NewDelayedRequeueError(time.Second*10, fmt.Sprintf("the lorry of pod %s is not ready", pod.Name))
+		}
+		if err = lorryCli.Reconfigure(transCtx.Context, origStatus.SerialNumber); err != nil {
+			return intctrlutil.NewDelayedRequeueError(time.Second*10,
+				fmt.Sprintf("reload the TLS certificate on pod %s: %s", pod.Name, err.Error()))
+		}
 	}
-
+	certStatus.ReloadedSerialNumber = origStatus.SerialNumber
 	return nil
 }
 
+// buildTLSCertVersionAnnotation sets the serial number of the TLS certificate in the pod template, to restart
+// the instances in a rolling manner after the certificate is rotated, unless the engine reloads it dynamically.
+func buildTLSCertVersionAnnotation(transCtx *componentTransformContext, runningITS, protoITS *workloads.InstanceSet) {
+	certStatus := transCtx.Component.Status.TLSCertificate
+	if certStatus == nil || certStatus.SerialNumber == "" ||
+		transCtx.CompDef.Spec.TLSCertReloadPolicy == appsv1alpha1.TLSCertReloadPolicyReload {
+		return
+	}
+	if runningITS == nil || runningITS.Spec.Template.Annotations[constant.TLSCertVersionAnnotationKey] == "" {
+		// the instances have been using the certificate since they were created.
+		origStatus := transCtx.ComponentOrig.Status.TLSCertificate
+		if origStatus == nil || origStatus.SerialNumber == "" || origStatus.SerialNumber == certStatus.SerialNumber {
+			return
+		}
+	}
+	if protoITS.Spec.Template.Annotations == nil {
+		protoITS.Spec.Template.Annotations = map[string]string{}
+	}
+	protoITS.Spec.Template.Annotations[constant.TLSCertVersionAnnotationKey] = certStatus.SerialNumber
+}
+
 func updateTLSVolumeAndVolumeMount(podSpec *corev1.PodSpec, clusterName string, synthesizeComp component.SynthesizedComponent) error {
 	tls := synthesizeComp.TLSConfig
 	if tls == nil || !tls.Enable {
@@ -197,7 +379,7 @@ func composeTLSVolume(clusterName string, synthesizeComp component.SynthesizedCo
 
 	var secretName, ca, cert, key string
 	switch tls.Issuer.Name {
-	case appsv1alpha1.IssuerKubeBlocks:
+	case appsv1alpha1.IssuerKubeBlocks, appsv1alpha1.IssuerCertManager:
 		secretName = plan.GenerateTLSSecretName(clusterName, synthesizeComp.Name)
 		ca = constant.CAName
 		cert = constant.CertName
@@ -23,8 +23,10 @@ import (
 	"fmt"
 
 	"github.com/go-errors/errors"
+	"golang.org/x/exp/slices"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 )
 
@@ -45,7 +47,7 @@ func (t *componentValidationTransformer) Transform(ctx graph.TransformContext, d
 	if err = validateEnabledLogs(comp, transCtx.CompDef); err != nil {
 		return newRequeueError(requeueDuration, err.Error())
 	}
-	if err = validateCompReplicas(comp, transCtx.CompDef); err != nil {
+	if err = validateCompReplicas(transCtx.Cluster, comp, transCtx.CompDef); err != nil {
 		return newRequeueError(requeueDuration, err.Error())
 	}
 	if err = validateSidecarContainers(comp, transCtx.CompDef); err != nil {
@@ -100,11 +102,15 @@ func validateEnabledLogConfigs(compDef *appsv1alpha1.ComponentDefinition, enable
 	return invalidLogNames
 }
 
-func validateCompReplicas(comp *appsv1alpha1.Component, compDef *appsv1alpha1.ComponentDefinition) error {
+func validateCompReplicas(cluster *appsv1alpha1.Cluster, comp *appsv1alpha1.Component, compDef *appsv1alpha1.ComponentDefinition) error {
 	if compDef.Spec.ReplicasLimit == nil {
 		return nil
 	}
 	replicas := comp.Spec.Replicas
+	// the instances of the adopted component are brought online one by one, count the instances to be adopted.
+	if isAdoptingCluster(cluster) {
+		replicas += int32(len(comp.Spec.OfflineInstances))
+	}
 	replicasLimit := compDef.Spec.ReplicasLimit
 	if replicas >= replicasLimit.MinReplicas && replicas <= replicasLimit.MaxReplicas {
 		return nil
@@ -115,3 +121,14 @@ func validateCompReplicas(comp *appsv1alpha1.Component, compDef *appsv1alpha1.Co
 func replicasOutOfLimitError(replicas int32, replicasLimit appsv1alpha1.ReplicasLimit) error {
 	return fmt.Errorf("replicas %d out-of-limit [%d, %d]", replicas, replicasLimit.MinReplicas, replicasLimit.MaxReplicas)
 }
+
+// isAdoptingCluster checks if the cluster is being created by an Adopt OpsRequest.
+func isAdoptingCluster(cluster *appsv1alpha1.Cluster) bool {
+	if cluster == nil {
+		return false
+	}
+	opsRequestSlice, _ := opsutil.GetOpsRequestSliceFromCluster(cluster)
+	return slices.ContainsFunc(opsRequestSlice, func(recorder appsv1alpha1.OpsRecorder) bool {
+		return recorder.Type == appsv1alpha1.AdoptType
+	})
+}
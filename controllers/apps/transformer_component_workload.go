@@ -38,12 +38,14 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	rsmcore "github.com/apecloud/kubeblocks/pkg/controller/rsm"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -54,6 +56,10 @@ import (
 // componentWorkloadTransformer handles component workload generation
 type componentWorkloadTransformer struct {
 	client.Client
+
+	// dryRun skips the horizontal scaling which talks to the pods through lorry,
+	// the pods to scale are rendered by the InstanceSet controller in dry-run mode.
+	dryRun bool
 }
 
 // componentWorkloadOps handles component workload ops
@@ -112,6 +118,28 @@ func (t *componentWorkloadTransformer) Transform(ctx graph.TransformContext, dag
 	// build configuration template annotations to workload
 	buildInstanceSetConfigTplAnnotations(protoITS, synthesizeComp)
 
+	// restart the instances to pick up the rotated TLS certificate
+	buildTLSCertVersionAnnotation(transCtx, runningITS, protoITS)
+
+	// restart the instances to pick up the rotated passwords of the system accounts
+	if err = buildAccountPasswordRotatedAnnotation(transCtx, protoITS); err != nil {
+		return err
+	}
+
+	// inject the passwords of the system accounts from the external secret store
+	injectionAnnotations, err := buildAccountInjectionAnnotations(synthesizeComp)
+	if err != nil {
+		return err
+	}
+	if len(injectionAnnotations) > 0 {
+		if protoITS.Spec.Template.Annotations == nil {
+			protoITS.Spec.Template.Annotations = map[string]string{}
+		}
+		for k, v := range injectionAnnotations {
+			protoITS.Spec.Template.Annotations[k] = v
+		}
+	}
+
 	graphCli, _ := transCtx.Client.(model.GraphClient)
 	if runningITS == nil {
 		if protoITS != nil {
@@ -172,6 +200,10 @@ func (t *componentWorkloadTransformer) handleWorkloadUpdate(reqCtx intctrlutil.R
 		return err
 	}
 
+	if t.dryRun {
+		return nil
+	}
+
 	// handle workload horizontal scale
 	if err := cwo.horizontalScale(); err != nil {
 		return err
@@ -304,6 +336,8 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet, synthesizeComp *comp
 	itsObjCopy.Spec.Credential = itsProto.Spec.Credential
 	itsObjCopy.Spec.Instances = itsProto.Spec.Instances
 	itsObjCopy.Spec.OfflineInstances = itsProto.Spec.OfflineInstances
+	itsObjCopy.Spec.PlacementPolicy = itsProto.Spec.PlacementPolicy
+	itsObjCopy.Spec.ProtectLeaderFromEviction = itsProto.Spec.ProtectLeaderFromEviction
 
 	if itsProto.Spec.UpdateStrategy.Type != "" || itsProto.Spec.UpdateStrategy.RollingUpdate != nil {
 		updateUpdateStrategy(itsObjCopy, itsProto)
@@ -377,6 +411,11 @@ func checkNRollbackProtoImages(itsObj, itsProto *workloads.InstanceSet) {
 
 // expandVolume handles workload expand volume
 func (r *componentWorkloadOps) expandVolume() error {
+	// the volumes are rebuilt one by one by the VolumeMigration opsRequest, skip to expand or shrink them in place.
+	migrating, err := r.isVolumeMigrating()
+	if err != nil || migrating {
+		return err
+	}
 	for _, vct := range r.runningITS.Spec.VolumeClaimTemplates {
 		var proto *corev1.PersistentVolumeClaimTemplate
 		for i, v := range r.synthesizeComp.VolumeClaimTemplates {
@@ -397,6 +436,20 @@ func (r *componentWorkloadOps) expandVolume() error {
 	return nil
 }
 
+// isVolumeMigrating checks if a VolumeMigration opsRequest is running on the cluster.
+func (r *componentWorkloadOps) isVolumeMigrating() (bool, error) {
+	opsRecorders, err := opsutil.GetOpsRequestSliceFromCluster(r.cluster)
+	if err != nil {
+		return false, err
+	}
+	for _, recorder := range opsRecorders {
+		if recorder.Type == appsv1alpha1.VolumeMigrationType && !recorder.InQueue {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // horizontalScale handles workload horizontal scale
 func (r *componentWorkloadOps) horizontalScale() error {
 	sts := rsmcore.ConvertInstanceSetToSTS(r.runningITS)
@@ -602,12 +655,16 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn() error {
 
 	// TODO: Move memberLeave to the ITS controller. Instead of performing a switchover, we can directly scale down the non-leader nodes. This is because the pod ordinal is not guaranteed to be continuous.
 	podsToMemberLeave := make([]*corev1.Pod, 0)
-	genPodNamesByDefault := generatePodNames(r.synthesizeComp)
+	genPodNamesByDefault := generatePodNames(r.synthesizeComp, r.runningITS)
 	for _, pod := range pods {
 		// if the pod not exists in the generated pod names, it should be a member that needs to leave
 		if slices.Contains(genPodNamesByDefault, pod.Name) {
 			continue
 		}
+		// the standby instances of the blue/green update leave the group by the InstanceSet.
+		if instanceset.IsStandbyInstance(r.runningITS, pod) {
+			continue
+		}
 		podsToMemberLeave = append(podsToMemberLeave, pod)
 	}
 	for _, pod := range podsToMemberLeave {
@@ -22,6 +22,9 @@ package apps
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -136,3 +139,68 @@ func clientOption(v *model.ObjectVertex) *multicluster.ClientOption {
 	}
 	return multicluster.InControlContext()
 }
+
+// fieldChange describes a changed field between two JSON values.
+type fieldChange struct {
+	// path is the path of the field, the elements are strings for map keys and ints for list indexes.
+	path     []any
+	oldValue any
+	newValue any
+}
+
+// diffFields returns the changed fields between two JSON values in the order of their paths.
+// The maps and the lists of the same length are compared by their elements, other values are compared as a whole.
+func diffFields(path []any, oldValue, newValue any) []fieldChange {
+	if reflect.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+	subPath := func(elem any) []any {
+		return append(append(make([]any, 0, len(path)+1), path...), elem)
+	}
+	switch o := oldValue.(type) {
+	case map[string]any:
+		if n, ok := newValue.(map[string]any); ok {
+			keys := make([]string, 0, len(o)+len(n))
+			for k := range o {
+				keys = append(keys, k)
+			}
+			for k := range n {
+				if _, ok = o[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			var changes []fieldChange
+			for _, k := range keys {
+				changes = append(changes, diffFields(subPath(k), o[k], n[k])...)
+			}
+			return changes
+		}
+	case []any:
+		if n, ok := newValue.([]any); ok && len(o) == len(n) {
+			var changes []fieldChange
+			for i := range o {
+				changes = append(changes, diffFields(subPath(i), o[i], n[i])...)
+			}
+			return changes
+		}
+	}
+	return []fieldChange{{path: path, oldValue: oldValue, newValue: newValue}}
+}
+
+// fieldPathString formats the path of the field, such as spec.ports[0].port.
+func fieldPathString(path []any) string {
+	var b strings.Builder
+	for i, elem := range path {
+		switch v := elem.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", v)
+		default:
+			if i > 0 {
+				b.WriteString(".")
+			}
+			fmt.Fprintf(&b, "%v", v)
+		}
+	}
+	return b.String()
+}
@@ -260,6 +260,10 @@ func (r *BackupReconciler) handleDeletingPhase(reqCtx intctrlutil.RequestCtx, ba
 func (r *BackupReconciler) handleNewPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	if _, ok := backup.Annotations[dptypes.ImportedBackupAnnotationKey]; ok {
+		// wait for the importer to set the status of the imported backup.
+		return intctrlutil.Reconciled()
+	}
 	request, err := r.prepareBackupRequest(reqCtx, backup)
 	if err != nil {
 		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
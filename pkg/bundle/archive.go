@@ -0,0 +1,219 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+// The layout of the archive, which is a gzipped tarball:
+//
+//	manifest.yaml
+//	cluster.yaml
+//	clusterdefinition.yaml
+//	componentdefinitions/<name>.yaml
+//	configurations/<name>.yaml
+//	secrets/<name>.yaml
+//	backuppolicies/<name>.yaml
+//	backupschedules/<name>.yaml
+//	backups/<name>.yaml
+const (
+	manifestFile          = "manifest.yaml"
+	clusterFile           = "cluster.yaml"
+	clusterDefinitionFile = "clusterdefinition.yaml"
+	componentDefsDir      = "componentdefinitions"
+	configurationsDir     = "configurations"
+	secretsDir            = "secrets"
+	backupPoliciesDir     = "backuppolicies"
+	backupSchedulesDir    = "backupschedules"
+	backupsDir            = "backups"
+)
+
+// Write writes the bundle as an archive.
+func (b *Bundle) Write(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	writeFile := func(name string, obj any) error {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if err = tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: b.Manifest.CreatedAt.Time,
+		}); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+	writeObject := func(dir string, gvk schema.GroupVersionKind, obj client.Object) error {
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		return writeFile(path.Join(dir, obj.GetName()+".yaml"), obj)
+	}
+
+	if err := writeFile(manifestFile, b.Manifest); err != nil {
+		return err
+	}
+	if b.Cluster == nil {
+		return errors.New("the bundle has no cluster")
+	}
+	b.Cluster.GetObjectKind().SetGroupVersionKind(appsv1alpha1.GroupVersion.WithKind(appsv1alpha1.ClusterKind))
+	if err := writeFile(clusterFile, b.Cluster); err != nil {
+		return err
+	}
+	if b.ClusterDefinition != nil {
+		b.ClusterDefinition.GetObjectKind().SetGroupVersionKind(appsv1alpha1.GroupVersion.WithKind(appsv1alpha1.ClusterDefinitionKind))
+		if err := writeFile(clusterDefinitionFile, b.ClusterDefinition); err != nil {
+			return err
+		}
+	}
+	for i := range b.ComponentDefinitions {
+		if err := writeObject(componentDefsDir, appsv1alpha1.GroupVersion.WithKind("ComponentDefinition"), &b.ComponentDefinitions[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.Configurations {
+		if err := writeObject(configurationsDir, appsv1alpha1.GroupVersion.WithKind("Configuration"), &b.Configurations[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.Secrets {
+		if err := writeObject(secretsDir, corev1.SchemeGroupVersion.WithKind("Secret"), &b.Secrets[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.BackupPolicies {
+		if err := writeObject(backupPoliciesDir, dpv1alpha1.GroupVersion.WithKind("BackupPolicy"), &b.BackupPolicies[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.BackupSchedules {
+		if err := writeObject(backupSchedulesDir, dpv1alpha1.GroupVersion.WithKind("BackupSchedule"), &b.BackupSchedules[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.Backups {
+		if err := writeObject(backupsDir, dpv1alpha1.GroupVersion.WithKind("Backup"), &b.Backups[i]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// Read reads the bundle from an archive.
+func Read(r io.Reader) (*Bundle, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	b := &Bundle{}
+	hasManifest := false
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if err = b.decodeFile(header.Name, data); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %s", header.Name, err.Error())
+		}
+		if header.Name == manifestFile {
+			hasManifest = true
+		}
+	}
+	switch {
+	case !hasManifest:
+		return nil, fmt.Errorf("%s is missing in the bundle", manifestFile)
+	case b.Manifest.Version != Version:
+		return nil, fmt.Errorf(`unsupported bundle version "%s", expected "%s"`, b.Manifest.Version, Version)
+	case b.Cluster == nil:
+		return nil, fmt.Errorf("%s is missing in the bundle", clusterFile)
+	}
+	return b, nil
+}
+
+func (b *Bundle) decodeFile(name string, data []byte) error {
+	switch name {
+	case manifestFile:
+		return yaml.Unmarshal(data, &b.Manifest)
+	case clusterFile:
+		b.Cluster = &appsv1alpha1.Cluster{}
+		return yaml.Unmarshal(data, b.Cluster)
+	case clusterDefinitionFile:
+		b.ClusterDefinition = &appsv1alpha1.ClusterDefinition{}
+		return yaml.Unmarshal(data, b.ClusterDefinition)
+	}
+	switch path.Dir(name) {
+	case componentDefsDir:
+		return decodeInto(data, &b.ComponentDefinitions)
+	case configurationsDir:
+		return decodeInto(data, &b.Configurations)
+	case secretsDir:
+		return decodeInto(data, &b.Secrets)
+	case backupPoliciesDir:
+		return decodeInto(data, &b.BackupPolicies)
+	case backupSchedulesDir:
+		return decodeInto(data, &b.BackupSchedules)
+	case backupsDir:
+		return decodeInto(data, &b.Backups)
+	}
+	// ignore the unknown files for compatibility.
+	return nil
+}
+
+func decodeInto[T any](data []byte, items *[]T) error {
+	var item T
+	if err := yaml.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	*items = append(*items, item)
+	return nil
+}
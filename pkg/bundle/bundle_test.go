@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestExportAndImport(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = appsv1alpha1.AddToScheme(scheme)
+	_ = dpv1alpha1.AddToScheme(scheme)
+
+	const (
+		namespace   = "prod"
+		clusterName = "mycluster"
+		compName    = "mysql"
+		policyName  = "mycluster-mysql-backup-policy"
+	)
+	clusterLabels := map[string]string{constant.AppInstanceLabelKey: clusterName}
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: namespace, UID: "uid-1"},
+		Spec: appsv1alpha1.ClusterSpec{
+			ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+				{
+					Name:         compName,
+					ComponentDef: "mysql",
+					Replicas:     3,
+					VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+						{Name: "data", Spec: appsv1alpha1.PersistentVolumeClaimSpec{StorageClassName: pointer.String("ebs")}},
+					},
+				},
+			},
+		},
+	}
+	component := &appsv1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-" + compName, Namespace: namespace, Labels: clusterLabels},
+		Spec:       appsv1alpha1.ComponentSpec{CompDef: "mysql-8.0"},
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "mysql-8.0"}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constant.GenerateAccountSecretName(clusterName, compName, "root"),
+			Namespace: namespace,
+			Labels:    map[string]string{constant.AppInstanceLabelKey: clusterName, constant.ClusterAccountLabelKey: "root"},
+		},
+		Data: map[string][]byte{constant.AccountPasswdForSecret: []byte("password")},
+	}
+	config := &appsv1alpha1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-" + compName, Namespace: namespace, Labels: clusterLabels},
+		Spec:       appsv1alpha1.ConfigurationSpec{ClusterRef: clusterName, ComponentName: compName},
+	}
+	policy := &dpv1alpha1.BackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: namespace, Labels: clusterLabels},
+		Spec:       dpv1alpha1.BackupPolicySpec{BackupRepoName: pointer.String("s3-repo")},
+	}
+	now := time.Now()
+	newBackup := func(name string, end time.Time) *dpv1alpha1.Backup {
+		return &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+					dptypes.ClusterUIDLabelKey:      string(cluster.UID),
+					dptypes.BackupPolicyLabelKey:    policyName,
+					dptypes.BackupTypeLabelKey:      string(dpv1alpha1.BackupTypeFull),
+				},
+				Annotations: map[string]string{dptypes.ConnectionPasswordAnnotationKey: "encrypted"},
+			},
+			Spec: dpv1alpha1.BackupSpec{BackupPolicyName: policyName, DeletionPolicy: dpv1alpha1.BackupDeletionPolicyDelete},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:                     dpv1alpha1.BackupPhaseCompleted,
+				BackupRepoName:            "s3-repo",
+				PersistentVolumeClaimName: "pvc-s3-repo",
+				TimeRange:                 &dpv1alpha1.BackupTimeRange{End: &metav1.Time{Time: end}},
+			},
+		}
+	}
+	sourceCli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, component, compDef, secret, config, policy,
+		newBackup("backup-older", now.Add(-time.Hour)), newBackup("backup-latest", now)).Build()
+
+	b, err := Export(ctx, sourceCli, namespace, clusterName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err = b.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.ComponentDefinitions) != 1 || len(b.Secrets) != 1 || len(b.Configurations) != 1 || len(b.BackupPolicies) != 1 {
+		t.Fatalf("unexpected objects in the bundle: %+v", b)
+	}
+	if len(b.Backups) != 1 || b.Backups[0].Name != "backup-latest" {
+		t.Fatalf("expect the latest backup to be exported, but got %+v", b.Backups)
+	}
+	if b.Cluster.UID != "" || len(b.Cluster.ResourceVersion) != 0 {
+		t.Errorf("expect the object meta of the cluster to be cleaned, but got %+v", b.Cluster.ObjectMeta)
+	}
+
+	targetCli := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&dpv1alpha1.Backup{}).Build()
+	imported, err := Import(ctx, targetCli, b, ImportOptions{
+		Namespace:           "staging",
+		StorageClassMapping: map[string]string{"ebs": "local-path"},
+		BackupRepoMapping:   map[string]string{"s3-repo": "minio-repo"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.Namespace != "staging" || *imported.Spec.ComponentSpecs[0].VolumeClaimTemplates[0].Spec.StorageClassName != "local-path" {
+		t.Errorf("expect the namespace and storage class to be mapped, but got %+v", imported)
+	}
+	restoreInfo := map[string]map[string]string{}
+	if err = json.Unmarshal([]byte(imported.Annotations[constant.RestoreFromBackupAnnotationKey]), &restoreInfo); err != nil {
+		t.Fatal(err)
+	}
+	if restoreInfo[compName][constant.BackupNameKeyForRestore] != "backup-latest" ||
+		restoreInfo[compName][constant.BackupNamespaceKeyForRestore] != "staging" {
+		t.Errorf("unexpected restore info: %v", restoreInfo)
+	}
+
+	backup := &dpv1alpha1.Backup{}
+	if err = targetCli.Get(ctx, client.ObjectKey{Namespace: "staging", Name: "backup-latest"}, backup); err != nil {
+		t.Fatal(err)
+	}
+	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Status.BackupRepoName != "minio-repo" {
+		t.Errorf("expect the status of the backup to be imported, but got %+v", backup.Status)
+	}
+	if backup.Spec.DeletionPolicy != dpv1alpha1.BackupDeletionPolicyRetain {
+		t.Errorf("expect the imported backup to be retained, but got %s", backup.Spec.DeletionPolicy)
+	}
+	importedPolicy := &dpv1alpha1.BackupPolicy{}
+	if err = targetCli.Get(ctx, client.ObjectKey{Namespace: "staging", Name: policyName}, importedPolicy); err != nil {
+		t.Fatal(err)
+	}
+	if *importedPolicy.Spec.BackupRepoName != "minio-repo" {
+		t.Errorf("expect the backup repo of the policy to be mapped, but got %s", *importedPolicy.Spec.BackupRepoName)
+	}
+	for _, obj := range []client.Object{&corev1.Secret{}, &appsv1alpha1.Configuration{}} {
+		name := secret.Name
+		if _, ok := obj.(*appsv1alpha1.Configuration); ok {
+			name = config.Name
+		}
+		if err = targetCli.Get(ctx, client.ObjectKey{Namespace: "staging", Name: name}, obj); err != nil {
+			t.Errorf("expect %T %s to be imported: %s", obj, name, err.Error())
+		}
+	}
+
+	// the import can be retried.
+	if _, err = Import(ctx, targetCli, b, ImportOptions{Namespace: "staging"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// Export exports the cluster with the objects it depends on into a bundle.
+func Export(ctx context.Context, cli client.Reader, namespace, clusterName string) (*Bundle, error) {
+	cluster := &appsv1alpha1.Cluster{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, cluster); err != nil {
+		return nil, err
+	}
+	b := &Bundle{
+		Manifest: Manifest{
+			Version:     Version,
+			ClusterName: clusterName,
+			Namespace:   namespace,
+			CreatedAt:   metav1.Now(),
+		},
+	}
+	inNamespace := client.InNamespace(namespace)
+	ofCluster := client.MatchingLabels{constant.AppInstanceLabelKey: clusterName}
+
+	if err := b.exportDefinitions(ctx, cli, cluster); err != nil {
+		return nil, err
+	}
+
+	configurations := &appsv1alpha1.ConfigurationList{}
+	if err := cli.List(ctx, configurations, inNamespace, ofCluster); err != nil {
+		return nil, err
+	}
+	for i := range configurations.Items {
+		config := &configurations.Items[i]
+		cleanObjectMeta(&config.ObjectMeta)
+		config.Status = appsv1alpha1.ConfigurationStatus{}
+		b.Configurations = append(b.Configurations, *config)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := cli.List(ctx, secrets, inNamespace, ofCluster, client.HasLabels{constant.ClusterAccountLabelKey}); err != nil {
+		return nil, err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		cleanObjectMeta(&secret.ObjectMeta)
+		b.Secrets = append(b.Secrets, *secret)
+	}
+
+	if err := b.exportBackups(ctx, cli, cluster); err != nil {
+		return nil, err
+	}
+
+	cleanObjectMeta(&cluster.ObjectMeta)
+	delete(cluster.Annotations, constant.RestoreFromBackupAnnotationKey)
+	cluster.Status = appsv1alpha1.ClusterStatus{}
+	b.Cluster = cluster
+	return b, nil
+}
+
+// exportDefinitions exports the ClusterDefinition and the ComponentDefinitions referenced by the cluster.
+func (b *Bundle) exportDefinitions(ctx context.Context, cli client.Reader, cluster *appsv1alpha1.Cluster) error {
+	if cluster.Spec.ClusterDefRef != "" {
+		clusterDef := &appsv1alpha1.ClusterDefinition{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: cluster.Spec.ClusterDefRef}, clusterDef); err != nil {
+			return err
+		}
+		cleanObjectMeta(&clusterDef.ObjectMeta)
+		clusterDef.Status = appsv1alpha1.ClusterDefinitionStatus{}
+		b.ClusterDefinition = clusterDef
+	}
+
+	// the ComponentDefinition of the cluster component spec may be a name prefix,
+	// the resolved names are taken from the Component objects.
+	compDefNames := map[string]bool{}
+	components := &appsv1alpha1.ComponentList{}
+	if err := cli.List(ctx, components, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}); err != nil {
+		return err
+	}
+	for _, comp := range components.Items {
+		if comp.Spec.CompDef != "" {
+			compDefNames[comp.Spec.CompDef] = true
+		}
+	}
+	if len(components.Items) == 0 {
+		for _, compSpec := range cluster.Spec.ComponentSpecs {
+			if compSpec.ComponentDef != "" {
+				compDefNames[compSpec.ComponentDef] = true
+			}
+		}
+		for _, shardingSpec := range cluster.Spec.ShardingSpecs {
+			if shardingSpec.Template.ComponentDef != "" {
+				compDefNames[shardingSpec.Template.ComponentDef] = true
+			}
+		}
+	}
+	for _, name := range sortedKeys(compDefNames) {
+		compDef := &appsv1alpha1.ComponentDefinition{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: name}, compDef); err != nil {
+			return err
+		}
+		cleanObjectMeta(&compDef.ObjectMeta)
+		compDef.Status = appsv1alpha1.ComponentDefinitionStatus{}
+		b.ComponentDefinitions = append(b.ComponentDefinitions, *compDef)
+	}
+	return nil
+}
+
+// exportBackups exports the BackupPolicies and BackupSchedules of the cluster,
+// and the latest completed full backup of each BackupPolicy.
+func (b *Bundle) exportBackups(ctx context.Context, cli client.Reader, cluster *appsv1alpha1.Cluster) error {
+	inNamespace := client.InNamespace(cluster.Namespace)
+	ofCluster := client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}
+	backupPolicies := &dpv1alpha1.BackupPolicyList{}
+	if err := cli.List(ctx, backupPolicies, inNamespace, ofCluster); err != nil {
+		return err
+	}
+	backupSchedules := &dpv1alpha1.BackupScheduleList{}
+	if err := cli.List(ctx, backupSchedules, inNamespace, ofCluster); err != nil {
+		return err
+	}
+	backups := &dpv1alpha1.BackupList{}
+	if err := cli.List(ctx, backups, inNamespace, client.MatchingLabels{
+		constant.AppInstanceLabelKey: cluster.Name,
+		dptypes.ClusterUIDLabelKey:   string(cluster.UID),
+	}); err != nil {
+		return err
+	}
+
+	repos := map[string]bool{}
+	for i := range backupPolicies.Items {
+		policy := &backupPolicies.Items[i]
+		if backup := latestFullBackup(backups.Items, policy.Name); backup != nil {
+			cleanObjectMeta(&backup.ObjectMeta)
+			b.Backups = append(b.Backups, *backup)
+			if backup.Status.BackupRepoName != "" {
+				repos[backup.Status.BackupRepoName] = true
+			}
+		}
+		cleanObjectMeta(&policy.ObjectMeta)
+		policy.Status = dpv1alpha1.BackupPolicyStatus{}
+		b.BackupPolicies = append(b.BackupPolicies, *policy)
+	}
+	if len(b.Backups) == 0 {
+		return fmt.Errorf(`no completed full backup found for the cluster "%s"`, cluster.Name)
+	}
+	for i := range backupSchedules.Items {
+		schedule := &backupSchedules.Items[i]
+		cleanObjectMeta(&schedule.ObjectMeta)
+		schedule.Status = dpv1alpha1.BackupScheduleStatus{}
+		b.BackupSchedules = append(b.BackupSchedules, *schedule)
+	}
+	b.Manifest.BackupRepos = sortedKeys(repos)
+	return nil
+}
+
+// latestFullBackup returns a copy of the latest completed full backup of the backup policy.
+func latestFullBackup(backups []dpv1alpha1.Backup, policyName string) *dpv1alpha1.Backup {
+	var latest *dpv1alpha1.Backup
+	for i := range backups {
+		backup := &backups[i]
+		if backup.Labels[dptypes.BackupPolicyLabelKey] != policyName ||
+			backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeFull) ||
+			backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		endTime := backup.GetEndTime()
+		if endTime.IsZero() {
+			continue
+		}
+		if latest == nil || endTime.After(latest.GetEndTime().Time) {
+			latest = backup
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	return latest.DeepCopy()
+}
+
+// cleanObjectMeta removes the fields of the object meta which are specific to the source Kubernetes cluster.
+func cleanObjectMeta(objMeta *metav1.ObjectMeta) {
+	*objMeta = metav1.ObjectMeta{
+		Name:        objMeta.Name,
+		Namespace:   objMeta.Namespace,
+		Labels:      objMeta.Labels,
+		Annotations: objMeta.Annotations,
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// Import recreates the objects of the bundle in the target Kubernetes cluster, and the cluster is restored
+// from the backups of the bundle. The objects which already exist are left untouched, so the import can be retried.
+//
+// The objects are created in the order of their dependencies: the definitions, the system account secrets
+// and the Configurations which are reused by the cluster, the backups and the backup policies, and the
+// cluster at last.
+func Import(ctx context.Context, cli client.Client, b *Bundle, opts ImportOptions) (*appsv1alpha1.Cluster, error) {
+	if opts.Namespace == "" {
+		opts.Namespace = b.Manifest.Namespace
+	}
+	if opts.VolumeRestorePolicy == "" {
+		opts.VolumeRestorePolicy = string(dpv1alpha1.VolumeClaimRestorePolicyParallel)
+	}
+
+	// the definitions are shared by the clusters, the existing ones are not overwritten.
+	if b.ClusterDefinition != nil {
+		if err := createIfNotExist(ctx, cli, b.ClusterDefinition.DeepCopy()); err != nil {
+			return nil, err
+		}
+	}
+	for i := range b.ComponentDefinitions {
+		if err := createIfNotExist(ctx, cli, b.ComponentDefinitions[i].DeepCopy()); err != nil {
+			return nil, err
+		}
+	}
+	for i := range b.Secrets {
+		secret := b.Secrets[i].DeepCopy()
+		secret.Namespace = opts.Namespace
+		if err := createIfNotExist(ctx, cli, secret); err != nil {
+			return nil, err
+		}
+	}
+	for i := range b.Configurations {
+		config := b.Configurations[i].DeepCopy()
+		config.Namespace = opts.Namespace
+		if err := createIfNotExist(ctx, cli, config); err != nil {
+			return nil, err
+		}
+	}
+
+	var backups []*dpv1alpha1.Backup
+	for i := range b.Backups {
+		backup, err := importBackup(ctx, cli, &b.Backups[i], opts)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	for i := range b.BackupPolicies {
+		policy := b.BackupPolicies[i].DeepCopy()
+		policy.Namespace = opts.Namespace
+		if policy.Spec.BackupRepoName != nil {
+			repoName := mapName(opts.BackupRepoMapping, *policy.Spec.BackupRepoName)
+			policy.Spec.BackupRepoName = &repoName
+		}
+		if err := createIfNotExist(ctx, cli, policy); err != nil {
+			return nil, err
+		}
+	}
+	for i := range b.BackupSchedules {
+		schedule := b.BackupSchedules[i].DeepCopy()
+		schedule.Namespace = opts.Namespace
+		if err := createIfNotExist(ctx, cli, schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	cluster, err := buildCluster(b.Cluster, backups, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err = createIfNotExist(ctx, cli, cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// importBackup creates the backup and sets its status, which is required to restore from the backup.
+// The backup data is shared with the source cluster, so the backup is retained when it is deleted.
+func importBackup(ctx context.Context, cli client.Client, source *dpv1alpha1.Backup, opts ImportOptions) (*dpv1alpha1.Backup, error) {
+	backup := source.DeepCopy()
+	backup.Namespace = opts.Namespace
+	if backup.Annotations == nil {
+		backup.Annotations = map[string]string{}
+	}
+	backup.Annotations[dptypes.ImportedBackupAnnotationKey] = "true"
+	// the connection password is encrypted by the source KubeBlocks, and the system account secrets are imported instead.
+	delete(backup.Annotations, dptypes.ConnectionPasswordAnnotationKey)
+	backup.Spec.DeletionPolicy = dpv1alpha1.BackupDeletionPolicyRetain
+	backup.Status = dpv1alpha1.BackupStatus{}
+	if err := createIfNotExist(ctx, cli, backup); err != nil {
+		return nil, err
+	}
+
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(backup), backup); err != nil {
+		return nil, err
+	}
+	if backup.Status.Phase == "" {
+		backup.Status = *source.Status.DeepCopy()
+		if backup.Status.BackupRepoName != "" {
+			backup.Status.BackupRepoName = mapName(opts.BackupRepoMapping, backup.Status.BackupRepoName)
+			// the PVC of the BackupRepo in the source cluster, the BackupRepo is used instead.
+			backup.Status.PersistentVolumeClaimName = ""
+		}
+		if err := cli.Status().Update(ctx, backup); err != nil {
+			return nil, err
+		}
+	}
+	return backup, nil
+}
+
+// buildCluster builds the cluster to be imported, with the restore annotation of the backups.
+func buildCluster(source *appsv1alpha1.Cluster, backups []*dpv1alpha1.Backup, opts ImportOptions) (*appsv1alpha1.Cluster, error) {
+	cluster := source.DeepCopy()
+	cluster.Namespace = opts.Namespace
+
+	restoreInfo := map[string]map[string]string{}
+	for _, backup := range backups {
+		annotation, err := restore.GetRestoreFromBackupAnnotation(backup, cluster, opts.VolumeRestorePolicy, "", false)
+		if err != nil {
+			return nil, err
+		}
+		backupRestoreInfo := map[string]map[string]string{}
+		if err = json.Unmarshal([]byte(annotation), &backupRestoreInfo); err != nil {
+			return nil, err
+		}
+		for compName, info := range backupRestoreInfo {
+			if _, ok := restoreInfo[compName]; !ok {
+				restoreInfo[compName] = info
+			}
+		}
+	}
+	if len(restoreInfo) == 0 {
+		return nil, fmt.Errorf(`no backup found to restore the cluster "%s"`, cluster.Name)
+	}
+	restoreAnnotation, err := json.Marshal(restoreInfo)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[constant.RestoreFromBackupAnnotationKey] = string(restoreAnnotation)
+
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.RepoName != "" {
+		cluster.Spec.Backup.RepoName = mapName(opts.BackupRepoMapping, cluster.Spec.Backup.RepoName)
+	}
+	for i := range cluster.Spec.ComponentSpecs {
+		mapStorageClasses(cluster.Spec.ComponentSpecs[i].VolumeClaimTemplates, opts.StorageClassMapping)
+	}
+	for i := range cluster.Spec.ShardingSpecs {
+		mapStorageClasses(cluster.Spec.ShardingSpecs[i].Template.VolumeClaimTemplates, opts.StorageClassMapping)
+	}
+	// the node ports may be occupied in the target Kubernetes cluster.
+	for i := range cluster.Spec.Services {
+		if cluster.Spec.Services[i].Spec.Type != corev1.ServiceTypeNodePort {
+			continue
+		}
+		for j := range cluster.Spec.Services[i].Spec.Ports {
+			cluster.Spec.Services[i].Spec.Ports[j].NodePort = 0
+		}
+	}
+	return cluster, nil
+}
+
+func mapStorageClasses(vcts []appsv1alpha1.ClusterComponentVolumeClaimTemplate, mapping map[string]string) {
+	for i := range vcts {
+		storageClassName := ""
+		if vcts[i].Spec.StorageClassName != nil {
+			storageClassName = *vcts[i].Spec.StorageClassName
+		}
+		if mapped, ok := mapping[storageClassName]; ok {
+			vcts[i].Spec.StorageClassName = &mapped
+		}
+	}
+}
+
+func mapName(mapping map[string]string, name string) string {
+	if mapped, ok := mapping[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+func createIfNotExist(ctx context.Context, cli client.Client, obj client.Object) error {
+	if err := cli.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create %T %s: %s", obj, obj.GetName(), err.Error())
+	}
+	return nil
+}
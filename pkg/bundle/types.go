@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package bundle exports a cluster with the objects it depends on into a portable archive,
+// and imports the archive into another Kubernetes cluster, where the cluster is restored
+// from the backups in the BackupRepo referenced by the bundle.
+package bundle
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+// Version is the version of the bundle format.
+const Version = "v1"
+
+// Manifest describes the bundle.
+type Manifest struct {
+	Version     string      `json:"version"`
+	ClusterName string      `json:"clusterName"`
+	Namespace   string      `json:"namespace"`
+	CreatedAt   metav1.Time `json:"createdAt"`
+	// BackupRepos are the names of the BackupRepos which store the data of the backups in the bundle,
+	// they must be accessible from the target Kubernetes cluster.
+	BackupRepos []string `json:"backupRepos,omitempty"`
+}
+
+// Bundle contains a cluster and the objects it depends on.
+// The system account secrets are contained in plain text, the bundle should be kept securely.
+type Bundle struct {
+	Manifest             Manifest
+	Cluster              *appsv1alpha1.Cluster
+	ClusterDefinition    *appsv1alpha1.ClusterDefinition
+	ComponentDefinitions []appsv1alpha1.ComponentDefinition
+	Configurations       []appsv1alpha1.Configuration
+	Secrets              []corev1.Secret
+	BackupPolicies       []dpv1alpha1.BackupPolicy
+	BackupSchedules      []dpv1alpha1.BackupSchedule
+	// Backups are the latest completed full backups of the cluster, one for each backup policy.
+	Backups []dpv1alpha1.Backup
+}
+
+// ImportOptions specifies how the bundle is imported.
+type ImportOptions struct {
+	// Namespace is the namespace to import the cluster into, defaults to the namespace of the exported cluster.
+	Namespace string
+	// StorageClassMapping maps the storage classes of the volume claim templates to the ones of the
+	// target Kubernetes cluster, the empty key maps the volume claim templates without a storage class.
+	StorageClassMapping map[string]string
+	// BackupRepoMapping maps the BackupRepos referenced by the bundle to the ones of the target Kubernetes cluster.
+	BackupRepoMapping map[string]string
+	// VolumeRestorePolicy is the volume restore policy used to restore the cluster, defaults to Parallel.
+	VolumeRestorePolicy string
+}
@@ -37,6 +37,8 @@ type AppsV1alpha1Interface interface {
 	ComponentVersionsGetter
 	ConfigConstraintsGetter
 	OpsDefinitionsGetter
+	OpsFleetsGetter
+	OpsPipelinesGetter
 	OpsRequestsGetter
 	ServiceDescriptorsGetter
 }
@@ -82,6 +84,14 @@ func (c *AppsV1alpha1Client) OpsDefinitions() OpsDefinitionInterface {
 	return newOpsDefinitions(c)
 }
 
+func (c *AppsV1alpha1Client) OpsFleets(namespace string) OpsFleetInterface {
+	return newOpsFleets(c, namespace)
+}
+
+func (c *AppsV1alpha1Client) OpsPipelines(namespace string) OpsPipelineInterface {
+	return newOpsPipelines(c, namespace)
+}
+
 func (c *AppsV1alpha1Client) OpsRequests(namespace string) OpsRequestInterface {
 	return newOpsRequests(c, namespace)
 }
@@ -64,6 +64,14 @@ func (c *FakeAppsV1alpha1) OpsDefinitions() v1alpha1.OpsDefinitionInterface {
 	return &FakeOpsDefinitions{c}
 }
 
+func (c *FakeAppsV1alpha1) OpsFleets(namespace string) v1alpha1.OpsFleetInterface {
+	return &FakeOpsFleets{c, namespace}
+}
+
+func (c *FakeAppsV1alpha1) OpsPipelines(namespace string) v1alpha1.OpsPipelineInterface {
+	return &FakeOpsPipelines{c, namespace}
+}
+
 func (c *FakeAppsV1alpha1) OpsRequests(namespace string) v1alpha1.OpsRequestInterface {
 	return &FakeOpsRequests{c, namespace}
 }
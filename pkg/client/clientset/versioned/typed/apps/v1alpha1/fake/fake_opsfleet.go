@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOpsFleets implements OpsFleetInterface
+type FakeOpsFleets struct {
+	Fake *FakeAppsV1alpha1
+	ns   string
+}
+
+var opsfleetsResource = v1alpha1.SchemeGroupVersion.WithResource("opsfleets")
+
+var opsfleetsKind = v1alpha1.SchemeGroupVersion.WithKind("OpsFleet")
+
+// Get takes name of the opsFleet, and returns the corresponding opsFleet object, and an error if there is any.
+func (c *FakeOpsFleets) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.OpsFleet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(opsfleetsResource, c.ns, name), &v1alpha1.OpsFleet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsFleet), err
+}
+
+// List takes label and field selectors, and returns the list of OpsFleets that match those selectors.
+func (c *FakeOpsFleets) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.OpsFleetList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(opsfleetsResource, opsfleetsKind, c.ns, opts), &v1alpha1.OpsFleetList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.OpsFleetList{ListMeta: obj.(*v1alpha1.OpsFleetList).ListMeta}
+	for _, item := range obj.(*v1alpha1.OpsFleetList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested opsFleets.
+func (c *FakeOpsFleets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(opsfleetsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a opsFleet and creates it.  Returns the server's representation of the opsFleet, and an error, if there is any.
+func (c *FakeOpsFleets) Create(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.CreateOptions) (result *v1alpha1.OpsFleet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(opsfleetsResource, c.ns, opsFleet), &v1alpha1.OpsFleet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsFleet), err
+}
+
+// Update takes the representation of a opsFleet and updates it. Returns the server's representation of the opsFleet, and an error, if there is any.
+func (c *FakeOpsFleets) Update(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.UpdateOptions) (result *v1alpha1.OpsFleet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(opsfleetsResource, c.ns, opsFleet), &v1alpha1.OpsFleet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsFleet), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOpsFleets) UpdateStatus(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.UpdateOptions) (*v1alpha1.OpsFleet, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(opsfleetsResource, "status", c.ns, opsFleet), &v1alpha1.OpsFleet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsFleet), err
+}
+
+// Delete takes name of the opsFleet and deletes it. Returns an error if one occurs.
+func (c *FakeOpsFleets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(opsfleetsResource, c.ns, name, opts), &v1alpha1.OpsFleet{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOpsFleets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(opsfleetsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.OpsFleetList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched opsFleet.
+func (c *FakeOpsFleets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.OpsFleet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(opsfleetsResource, c.ns, name, pt, data, subresources...), &v1alpha1.OpsFleet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsFleet), err
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOpsPipelines implements OpsPipelineInterface
+type FakeOpsPipelines struct {
+	Fake *FakeAppsV1alpha1
+	ns   string
+}
+
+var opspipelinesResource = v1alpha1.SchemeGroupVersion.WithResource("opspipelines")
+
+var opspipelinesKind = v1alpha1.SchemeGroupVersion.WithKind("OpsPipeline")
+
+// Get takes name of the opsPipeline, and returns the corresponding opsPipeline object, and an error if there is any.
+func (c *FakeOpsPipelines) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.OpsPipeline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(opspipelinesResource, c.ns, name), &v1alpha1.OpsPipeline{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsPipeline), err
+}
+
+// List takes label and field selectors, and returns the list of OpsPipelines that match those selectors.
+func (c *FakeOpsPipelines) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.OpsPipelineList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(opspipelinesResource, opspipelinesKind, c.ns, opts), &v1alpha1.OpsPipelineList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.OpsPipelineList{ListMeta: obj.(*v1alpha1.OpsPipelineList).ListMeta}
+	for _, item := range obj.(*v1alpha1.OpsPipelineList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested opsPipelines.
+func (c *FakeOpsPipelines) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(opspipelinesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a opsPipeline and creates it.  Returns the server's representation of the opsPipeline, and an error, if there is any.
+func (c *FakeOpsPipelines) Create(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.CreateOptions) (result *v1alpha1.OpsPipeline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(opspipelinesResource, c.ns, opsPipeline), &v1alpha1.OpsPipeline{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsPipeline), err
+}
+
+// Update takes the representation of a opsPipeline and updates it. Returns the server's representation of the opsPipeline, and an error, if there is any.
+func (c *FakeOpsPipelines) Update(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.UpdateOptions) (result *v1alpha1.OpsPipeline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(opspipelinesResource, c.ns, opsPipeline), &v1alpha1.OpsPipeline{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsPipeline), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOpsPipelines) UpdateStatus(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.UpdateOptions) (*v1alpha1.OpsPipeline, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(opspipelinesResource, "status", c.ns, opsPipeline), &v1alpha1.OpsPipeline{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsPipeline), err
+}
+
+// Delete takes name of the opsPipeline and deletes it. Returns an error if one occurs.
+func (c *FakeOpsPipelines) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(opspipelinesResource, c.ns, name, opts), &v1alpha1.OpsPipeline{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOpsPipelines) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(opspipelinesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.OpsPipelineList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched opsPipeline.
+func (c *FakeOpsPipelines) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.OpsPipeline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(opspipelinesResource, c.ns, name, pt, data, subresources...), &v1alpha1.OpsPipeline{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsPipeline), err
+}
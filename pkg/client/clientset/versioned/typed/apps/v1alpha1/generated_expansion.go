@@ -36,6 +36,10 @@ type ConfigConstraintExpansion interface{}
 
 type OpsDefinitionExpansion interface{}
 
+type OpsFleetExpansion interface{}
+
+type OpsPipelineExpansion interface{}
+
 type OpsRequestExpansion interface{}
 
 type ServiceDescriptorExpansion interface{}
@@ -0,0 +1,195 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	scheme "github.com/apecloud/kubeblocks/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// OpsFleetsGetter has a method to return a OpsFleetInterface.
+// A group's client should implement this interface.
+type OpsFleetsGetter interface {
+	OpsFleets(namespace string) OpsFleetInterface
+}
+
+// OpsFleetInterface has methods to work with OpsFleet resources.
+type OpsFleetInterface interface {
+	Create(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.CreateOptions) (*v1alpha1.OpsFleet, error)
+	Update(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.UpdateOptions) (*v1alpha1.OpsFleet, error)
+	UpdateStatus(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.UpdateOptions) (*v1alpha1.OpsFleet, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.OpsFleet, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.OpsFleetList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.OpsFleet, err error)
+	OpsFleetExpansion
+}
+
+// opsFleets implements OpsFleetInterface
+type opsFleets struct {
+	client rest.Interface
+	ns     string
+}
+
+// newOpsFleets returns a OpsFleets
+func newOpsFleets(c *AppsV1alpha1Client, namespace string) *opsFleets {
+	return &opsFleets{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the opsFleet, and returns the corresponding opsFleet object, and an error if there is any.
+func (c *opsFleets) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.OpsFleet, err error) {
+	result = &v1alpha1.OpsFleet{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of OpsFleets that match those selectors.
+func (c *opsFleets) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.OpsFleetList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.OpsFleetList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested opsFleets.
+func (c *opsFleets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a opsFleet and creates it.  Returns the server's representation of the opsFleet, and an error, if there is any.
+func (c *opsFleets) Create(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.CreateOptions) (result *v1alpha1.OpsFleet, err error) {
+	result = &v1alpha1.OpsFleet{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(opsFleet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a opsFleet and updates it. Returns the server's representation of the opsFleet, and an error, if there is any.
+func (c *opsFleets) Update(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.UpdateOptions) (result *v1alpha1.OpsFleet, err error) {
+	result = &v1alpha1.OpsFleet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		Name(opsFleet.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(opsFleet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *opsFleets) UpdateStatus(ctx context.Context, opsFleet *v1alpha1.OpsFleet, opts v1.UpdateOptions) (result *v1alpha1.OpsFleet, err error) {
+	result = &v1alpha1.OpsFleet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		Name(opsFleet.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(opsFleet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the opsFleet and deletes it. Returns an error if one occurs.
+func (c *opsFleets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *opsFleets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("opsfleets").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched opsFleet.
+func (c *opsFleets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.OpsFleet, err error) {
+	result = &v1alpha1.OpsFleet{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("opsfleets").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	scheme "github.com/apecloud/kubeblocks/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// OpsPipelinesGetter has a method to return a OpsPipelineInterface.
+// A group's client should implement this interface.
+type OpsPipelinesGetter interface {
+	OpsPipelines(namespace string) OpsPipelineInterface
+}
+
+// OpsPipelineInterface has methods to work with OpsPipeline resources.
+type OpsPipelineInterface interface {
+	Create(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.CreateOptions) (*v1alpha1.OpsPipeline, error)
+	Update(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.UpdateOptions) (*v1alpha1.OpsPipeline, error)
+	UpdateStatus(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.UpdateOptions) (*v1alpha1.OpsPipeline, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.OpsPipeline, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.OpsPipelineList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.OpsPipeline, err error)
+	OpsPipelineExpansion
+}
+
+// opsPipelines implements OpsPipelineInterface
+type opsPipelines struct {
+	client rest.Interface
+	ns     string
+}
+
+// newOpsPipelines returns a OpsPipelines
+func newOpsPipelines(c *AppsV1alpha1Client, namespace string) *opsPipelines {
+	return &opsPipelines{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the opsPipeline, and returns the corresponding opsPipeline object, and an error if there is any.
+func (c *opsPipelines) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.OpsPipeline, err error) {
+	result = &v1alpha1.OpsPipeline{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of OpsPipelines that match those selectors.
+func (c *opsPipelines) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.OpsPipelineList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.OpsPipelineList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested opsPipelines.
+func (c *opsPipelines) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a opsPipeline and creates it.  Returns the server's representation of the opsPipeline, and an error, if there is any.
+func (c *opsPipelines) Create(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.CreateOptions) (result *v1alpha1.OpsPipeline, err error) {
+	result = &v1alpha1.OpsPipeline{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(opsPipeline).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a opsPipeline and updates it. Returns the server's representation of the opsPipeline, and an error, if there is any.
+func (c *opsPipelines) Update(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.UpdateOptions) (result *v1alpha1.OpsPipeline, err error) {
+	result = &v1alpha1.OpsPipeline{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		Name(opsPipeline.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(opsPipeline).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *opsPipelines) UpdateStatus(ctx context.Context, opsPipeline *v1alpha1.OpsPipeline, opts v1.UpdateOptions) (result *v1alpha1.OpsPipeline, err error) {
+	result = &v1alpha1.OpsPipeline{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		Name(opsPipeline.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(opsPipeline).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the opsPipeline and deletes it. Returns an error if one occurs.
+func (c *opsPipelines) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *opsPipelines) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("opspipelines").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched opsPipeline.
+func (c *opsPipelines) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.OpsPipeline, err error) {
+	result = &v1alpha1.OpsPipeline{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("opspipelines").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -42,6 +42,10 @@ type Interface interface {
 	ConfigConstraints() ConfigConstraintInformer
 	// OpsDefinitions returns a OpsDefinitionInformer.
 	OpsDefinitions() OpsDefinitionInformer
+	// OpsFleets returns a OpsFleetInformer.
+	OpsFleets() OpsFleetInformer
+	// OpsPipelines returns a OpsPipelineInformer.
+	OpsPipelines() OpsPipelineInformer
 	// OpsRequests returns a OpsRequestInformer.
 	OpsRequests() OpsRequestInformer
 	// ServiceDescriptors returns a ServiceDescriptorInformer.
@@ -104,6 +108,16 @@ func (v *version) OpsDefinitions() OpsDefinitionInformer {
 	return &opsDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// OpsFleets returns a OpsFleetInformer.
+func (v *version) OpsFleets() OpsFleetInformer {
+	return &opsFleetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// OpsPipelines returns a OpsPipelineInformer.
+func (v *version) OpsPipelines() OpsPipelineInformer {
+	return &opsPipelineInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // OpsRequests returns a OpsRequestInformer.
 func (v *version) OpsRequests() OpsRequestInformer {
 	return &opsRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -62,6 +62,22 @@ type ConfigConstraintListerExpansion interface{}
 // OpsDefinitionLister.
 type OpsDefinitionListerExpansion interface{}
 
+// OpsFleetListerExpansion allows custom methods to be added to
+// OpsFleetLister.
+type OpsFleetListerExpansion interface{}
+
+// OpsFleetNamespaceListerExpansion allows custom methods to be added to
+// OpsFleetNamespaceLister.
+type OpsFleetNamespaceListerExpansion interface{}
+
+// OpsPipelineListerExpansion allows custom methods to be added to
+// OpsPipelineLister.
+type OpsPipelineListerExpansion interface{}
+
+// OpsPipelineNamespaceListerExpansion allows custom methods to be added to
+// OpsPipelineNamespaceLister.
+type OpsPipelineNamespaceListerExpansion interface{}
+
 // OpsRequestListerExpansion allows custom methods to be added to
 // OpsRequestLister.
 type OpsRequestListerExpansion interface{}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// OpsFleetLister helps list OpsFleets.
+// All objects returned here must be treated as read-only.
+type OpsFleetLister interface {
+	// List lists all OpsFleets in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.OpsFleet, err error)
+	// OpsFleets returns an object that can list and get OpsFleets.
+	OpsFleets(namespace string) OpsFleetNamespaceLister
+	OpsFleetListerExpansion
+}
+
+// opsFleetLister implements the OpsFleetLister interface.
+type opsFleetLister struct {
+	indexer cache.Indexer
+}
+
+// NewOpsFleetLister returns a new OpsFleetLister.
+func NewOpsFleetLister(indexer cache.Indexer) OpsFleetLister {
+	return &opsFleetLister{indexer: indexer}
+}
+
+// List lists all OpsFleets in the indexer.
+func (s *opsFleetLister) List(selector labels.Selector) (ret []*v1alpha1.OpsFleet, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.OpsFleet))
+	})
+	return ret, err
+}
+
+// OpsFleets returns an object that can list and get OpsFleets.
+func (s *opsFleetLister) OpsFleets(namespace string) OpsFleetNamespaceLister {
+	return opsFleetNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// OpsFleetNamespaceLister helps list and get OpsFleets.
+// All objects returned here must be treated as read-only.
+type OpsFleetNamespaceLister interface {
+	// List lists all OpsFleets in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.OpsFleet, err error)
+	// Get retrieves the OpsFleet from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.OpsFleet, error)
+	OpsFleetNamespaceListerExpansion
+}
+
+// opsFleetNamespaceLister implements the OpsFleetNamespaceLister
+// interface.
+type opsFleetNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all OpsFleets in the indexer for a given namespace.
+func (s opsFleetNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.OpsFleet, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.OpsFleet))
+	})
+	return ret, err
+}
+
+// Get retrieves the OpsFleet from the indexer for a given namespace and name.
+func (s opsFleetNamespaceLister) Get(name string) (*v1alpha1.OpsFleet, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("opsfleet"), name)
+	}
+	return obj.(*v1alpha1.OpsFleet), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// OpsPipelineLister helps list OpsPipelines.
+// All objects returned here must be treated as read-only.
+type OpsPipelineLister interface {
+	// List lists all OpsPipelines in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.OpsPipeline, err error)
+	// OpsPipelines returns an object that can list and get OpsPipelines.
+	OpsPipelines(namespace string) OpsPipelineNamespaceLister
+	OpsPipelineListerExpansion
+}
+
+// opsPipelineLister implements the OpsPipelineLister interface.
+type opsPipelineLister struct {
+	indexer cache.Indexer
+}
+
+// NewOpsPipelineLister returns a new OpsPipelineLister.
+func NewOpsPipelineLister(indexer cache.Indexer) OpsPipelineLister {
+	return &opsPipelineLister{indexer: indexer}
+}
+
+// List lists all OpsPipelines in the indexer.
+func (s *opsPipelineLister) List(selector labels.Selector) (ret []*v1alpha1.OpsPipeline, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.OpsPipeline))
+	})
+	return ret, err
+}
+
+// OpsPipelines returns an object that can list and get OpsPipelines.
+func (s *opsPipelineLister) OpsPipelines(namespace string) OpsPipelineNamespaceLister {
+	return opsPipelineNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// OpsPipelineNamespaceLister helps list and get OpsPipelines.
+// All objects returned here must be treated as read-only.
+type OpsPipelineNamespaceLister interface {
+	// List lists all OpsPipelines in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.OpsPipeline, err error)
+	// Get retrieves the OpsPipeline from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.OpsPipeline, error)
+	OpsPipelineNamespaceListerExpansion
+}
+
+// opsPipelineNamespaceLister implements the OpsPipelineNamespaceLister
+// interface.
+type opsPipelineNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all OpsPipelines in the indexer for a given namespace.
+func (s opsPipelineNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.OpsPipeline, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.OpsPipeline))
+	})
+	return ret, err
+}
+
+// Get retrieves the OpsPipeline from the indexer for a given namespace and name.
+func (s opsPipelineNamespaceLister) Get(name string) (*v1alpha1.OpsPipeline, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("opspipeline"), name)
+	}
+	return obj.(*v1alpha1.OpsPipeline), nil
+}
@@ -44,3 +44,10 @@ func GetKBReservedAnnotationKeys() []string {
 const (
 	HorizontalScaleBackupPolicyTemplateKey = "apps.kubeblocks.io/horizontal-scale-backup-policy-template"
 )
+
+// the values of DriftPolicyAnnotationKey
+const (
+	DriftPolicyRevert = "Revert" // DriftPolicyRevert reverts the drifted fields to the spec of KubeBlocks, it's the default policy
+	DriftPolicyKeep   = "Keep"   // DriftPolicyKeep keeps the drifted fields and applies the other changes of KubeBlocks
+	DriftPolicyReport = "Report" // DriftPolicyReport reports the drifted fields and leaves them untouched, the other changes of KubeBlocks are applied
+)
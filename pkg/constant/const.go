@@ -47,6 +47,9 @@ const (
 	// customized encryption key for encrypting the password of connection credential.
 	CfgKeyDPEncryptionKey = "DP_ENCRYPTION_KEY"
 
+	// the OpsRequest types which need approval in the specified namespaces.
+	CfgKeyOpsApprovalPolicies = "OPS_APPROVAL_POLICIES"
+
 	CfgKBReconcileWorkers = "KUBEBLOCKS_RECONCILE_WORKERS"
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
@@ -109,6 +112,7 @@ const (
 	KBAppServiceVersionKey                   = "apps.kubeblocks.io/service-version"
 	WorkloadTypeLabelKey                     = "apps.kubeblocks.io/workload-type"
 	KBAppPodNameLabelKey                     = "apps.kubeblocks.io/pod-name"
+	LeaderCandidateExcludedLabelKey          = "workloads.kubeblocks.io/leader-candidate-excluded" // labels the instances held and excluded from the leader candidates
 	ClusterDefLabelKey                       = "clusterdefinition.kubeblocks.io/name"
 	ClusterVerLabelKey                       = "clusterversion.kubeblocks.io/name"
 	ComponentDefinitionLabelKey              = "componentdefinition.kubeblocks.io/name"
@@ -127,6 +131,14 @@ const (
 	OpsRequestTypeLabelKey                   = "ops.kubeblocks.io/ops-type"
 	OpsRequestNameLabelKey                   = "ops.kubeblocks.io/ops-name"
 	OpsRequestNamespaceLabelKey              = "ops.kubeblocks.io/ops-namespace"
+	OpsPipelineNameLabelKey                  = "ops.kubeblocks.io/ops-pipeline-name"
+	OpsPipelineStepLabelKey                  = "ops.kubeblocks.io/ops-pipeline-step"
+	OpsFleetNameLabelKey                     = "ops.kubeblocks.io/ops-fleet-name"
+	OpsRequestRollbackOfLabelKey             = "ops.kubeblocks.io/rollback-of"
+	OpsRequestAutoHealLabelKey               = "ops.kubeblocks.io/auto-heal"
+	OpsRequestRebalanceLabelKey              = "ops.kubeblocks.io/rebalance"
+	OpsRequestRequestedByAnnotationKey       = "ops.kubeblocks.io/requested-by"
+	OpsRequestApprovedByAnnotationKey        = "ops.kubeblocks.io/approved-by"
 	ServiceDescriptorNameLabelKey            = "servicedescriptor.kubeblocks.io/name"
 	VolumeClaimTemplateNameLabelKeyForLegacy = "vct.kubeblocks.io/name" // Deprecated: only compatible with version 0.5, will be removed in 0.7
 
@@ -157,6 +169,20 @@ const (
 	ExtraEnvAnnotationKey                       = "kubeblocks.io/extra-env"
 	LastRoleSnapshotVersionAnnotationKey        = "apps.kubeblocks.io/last-role-snapshot-version"
 	ComponentScaleInAnnotationKey               = "apps.kubeblocks.io/component-scale-in" // ComponentScaleInAnnotationKey specifies whether the component is scaled in
+	ShardRemovingAnnotationKey                  = "apps.kubeblocks.io/shard-removing"     // ShardRemovingAnnotationKey specifies the shard is drained and will be removed first when the shards are scaled in
+	LastAppliedSpecAnnotationKey                = "apps.kubeblocks.io/last-applied-spec"  // LastAppliedSpecAnnotationKey records the hashes of the top-level spec fields last applied by KubeBlocks
+	DriftPolicyAnnotationKey                    = "apps.kubeblocks.io/drift-policy"       // DriftPolicyAnnotationKey specifies how to handle the drift of the object, one of Revert, Keep and Report
+	DriftReportedAnnotationKey                  = "apps.kubeblocks.io/drift-reported"     // DriftReportedAnnotationKey records the hash of the drifted fields left untouched with the Report policy
+	TLSCertVersionAnnotationKey                 = "apps.kubeblocks.io/tls-cert-version"   // TLSCertVersionAnnotationKey records the serial number of the TLS certificate used by the pods
+	// PasswordRotatedAtAnnotationKey records the time the password of the system account is rotated
+	PasswordRotatedAtAnnotationKey = "apps.kubeblocks.io/password-rotated-at"
+	// PasswordRotationPhaseAnnotationKey records the phase of the password rotation in the rotation secret
+	PasswordRotationPhaseAnnotationKey = "apps.kubeblocks.io/password-rotation-phase"
+	// SecretStoreSyncedAnnotationKey marks the secret has been written to the external secret store
+	SecretStoreSyncedAnnotationKey = "apps.kubeblocks.io/secret-store-synced"
+	// SecretStoreProvisionedAccountsAnnotationKey records the system accounts of the component whose credentials
+	// have been provisioned in the external secret store, separated by commas
+	SecretStoreProvisionedAccountsAnnotationKey = "apps.kubeblocks.io/secret-store-provisioned-accounts"
 
 	// kubeblocks.io well-known finalizers
 	DBClusterFinalizerName         = "cluster.kubeblocks.io/finalizer"
@@ -331,3 +357,8 @@ const (
 const (
 	IgnoreUpgradeToInstanceSet = "IGNORE_UPGRADE_TO_INSTANCE_SET"
 )
+
+const (
+	// OpsRequestRequestedBySystem is the requester of the OpsRequests originated by KubeBlocks itself, e.g. auto-heal.
+	OpsRequestRequestedBySystem = "system:kubeblocks"
+)
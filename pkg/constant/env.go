@@ -91,6 +91,9 @@ const (
 	KBEnvLorryHTTPPort   = "LORRY_HTTP_PORT"
 	KBEnvLorryGRPCPort   = "LORRY_GRPC_PORT"
 	KBEnvLorryLogLevel   = "LORRY_LOG_LEVEL"
+	// KBEnvServicePasswordFile is the file which the password is injected to from the external secret store,
+	// it's used if KBEnvServicePassword is not set.
+	KBEnvServicePasswordFile = "KB_SERVICE_PASSWORD_FILE"
 	// KBEnvServiceRoles defines the Roles configured in the cluster definition that are visible to users.
 	KBEnvServiceRoles = "KB_SERVICE_ROLES"
 
@@ -41,6 +41,7 @@ const (
 	PreTerminateAction  = "preTerminate"
 	DataDumpAction      = "dataDump"
 	DataLoadAction      = "dataLoad"
+	ReconfigureAction   = "reconfigure"
 )
 
 // action envs
@@ -138,6 +138,26 @@ func (builder *ComponentBuilder) SetOfflineInstances(offlineInstances []string)
 	return builder
 }
 
+func (builder *ComponentBuilder) SetAutoHeal(autoHeal *appsv1alpha1.AutoHealPolicy) *ComponentBuilder {
+	builder.get().Spec.AutoHeal = autoHeal
+	return builder
+}
+
+func (builder *ComponentBuilder) SetInstancePlacement(placement *appsv1alpha1.InstancePlacementPolicy) *ComponentBuilder {
+	builder.get().Spec.InstancePlacement = placement
+	return builder
+}
+
+func (builder *ComponentBuilder) SetSystemAccounts(systemAccounts []appsv1alpha1.ComponentSystemAccount) *ComponentBuilder {
+	builder.get().Spec.SystemAccounts = systemAccounts
+	return builder
+}
+
+func (builder *ComponentBuilder) SetNetworkPolicy(networkPolicy *appsv1alpha1.ClusterNetworkPolicy) *ComponentBuilder {
+	builder.get().Spec.NetworkPolicy = networkPolicy
+	return builder
+}
+
 func (builder *ComponentBuilder) SetRuntimeClassName(runtimeClassName string) *ComponentBuilder {
 	builder.get().Spec.RuntimeClassName = &runtimeClassName
 	return builder
@@ -107,6 +107,11 @@ func (builder *InstanceSetBuilder) SetVolumeClaimTemplates(templates ...corev1.P
 	return builder
 }
 
+func (builder *InstanceSetBuilder) SetProtectLeaderFromEviction(protect bool) *InstanceSetBuilder {
+	builder.get().Spec.ProtectLeaderFromEviction = protect
+	return builder
+}
+
 func (builder *InstanceSetBuilder) SetPodManagementPolicy(policy apps.PodManagementPolicyType) *InstanceSetBuilder {
 	builder.get().Spec.PodManagementPolicy = policy
 	return builder
@@ -186,3 +191,8 @@ func (builder *InstanceSetBuilder) SetInstances(instances []workloads.InstanceTe
 	builder.get().Spec.Instances = instances
 	return builder
 }
+
+func (builder *InstanceSetBuilder) SetPlacementPolicy(policy *workloads.InstancePlacementPolicy) *InstanceSetBuilder {
+	builder.get().Spec.PlacementPolicy = policy
+	return builder
+}
@@ -95,7 +95,11 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Cluste
 		SetServiceRefs(compSpec.ServiceRefs).
 		SetTLSConfig(compSpec.TLS, compSpec.Issuer).
 		SetInstances(compSpec.Instances).
-		SetOfflineInstances(compSpec.OfflineInstances)
+		SetOfflineInstances(compSpec.OfflineInstances).
+		SetAutoHeal(compSpec.AutoHeal).
+		SetInstancePlacement(compSpec.InstancePlacement).
+		SetSystemAccounts(compSpec.SystemAccounts).
+		SetNetworkPolicy(cluster.Spec.NetworkPolicy)
 	if labels != nil {
 		compBuilder.AddLabelsInMap(labels)
 	}
@@ -49,6 +49,15 @@ const (
 
 	// PreTerminateAction represents the pre-terminate action.
 	PreTerminateAction LifeCycleActionType = "preTerminate"
+
+	// ShardAddAction represents the shard-add action.
+	ShardAddAction LifeCycleActionType = "shardAdd"
+
+	// ShardRemoveAction represents the shard-remove action.
+	ShardRemoveAction LifeCycleActionType = "shardRemove"
+
+	// RebalanceAction represents the rebalance action.
+	RebalanceAction LifeCycleActionType = "rebalance"
 )
 
 // component lifecycle action constants
@@ -535,6 +544,18 @@ func checkLifeCycleAction(synthesizeComp *SynthesizedComponent, actionType LifeC
 		if actions := synthesizeComp.LifecycleActions.PreTerminate; actions != nil {
 			action = actions.CustomHandler
 		}
+	case ShardAddAction:
+		if actions := synthesizeComp.LifecycleActions.ShardAdd; actions != nil {
+			action = actions.CustomHandler
+		}
+	case ShardRemoveAction:
+		if actions := synthesizeComp.LifecycleActions.ShardRemove; actions != nil {
+			action = actions.CustomHandler
+		}
+	case RebalanceAction:
+		if actions := synthesizeComp.LifecycleActions.Rebalance; actions != nil {
+			action = actions.CustomHandler
+		}
 	default:
 		return false, nil
 	}
@@ -549,6 +570,12 @@ func genActionJobName(clusterName, componentName string, actionType LifeCycleAct
 		return fmt.Sprintf("%s-%s-%s", kbPostProvisionJobNamePrefix, clusterName, componentName), nil
 	case PreTerminateAction:
 		return fmt.Sprintf("%s-%s-%s", kbPreTerminateJobNamePrefix, clusterName, componentName), nil
+	case ShardAddAction:
+		return fmt.Sprintf("%s-%s-%s", kbShardAddJobNamePrefix, clusterName, componentName), nil
+	case ShardRemoveAction:
+		return fmt.Sprintf("%s-%s-%s", kbShardRemoveJobNamePrefix, clusterName, componentName), nil
+	case RebalanceAction:
+		return fmt.Sprintf("%s-%s-%s", kbRebalanceJobNamePrefix, clusterName, componentName), nil
 	}
 	return "", errors.New("unsupported lifecycle action type")
 }
@@ -565,6 +592,8 @@ func getActionCmdJobLabels(clusterName, componentName string, actionType LifeCyc
 		labels[kbPostProvisionJobLabelKey] = kbPostProvisionJobLabelValue
 	case PreTerminateAction:
 		labels[kbPreTerminateJobLabelKey] = kbPreTerminateJobLabelValue
+	case ShardAddAction, ShardRemoveAction, RebalanceAction:
+		labels[kbShardActionJobLabelKey] = string(actionType)
 	}
 	return labels
 }
@@ -0,0 +1,86 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// shard action constants
+const (
+	kbShardActionJobLabelKey   = "kubeblocks.io/shard-action-job"
+	kbShardAddJobNamePrefix    = "kb-shard-add-job"
+	kbShardRemoveJobNamePrefix = "kb-shard-remove-job"
+	kbRebalanceJobNamePrefix   = "kb-rebalance-job"
+
+	// kbShardActionShardingName indicates the name of the sharding
+	kbShardActionShardingName = "KB_SHARDING_NAME"
+	// kbShardActionShardingCompList indicates the shards of the sharding after the scaling
+	kbShardActionShardingCompList = "KB_SHARDING_COMPONENT_LIST"
+)
+
+// HasShardAction checks if the shard lifecycle action is defined in the component.
+func HasShardAction(synthesizeComp *SynthesizedComponent, actionType LifeCycleActionType) bool {
+	switch actionType {
+	case ShardAddAction, ShardRemoveAction, RebalanceAction:
+		exist, _ := checkLifeCycleAction(synthesizeComp, actionType)
+		return exist
+	default:
+		return false
+	}
+}
+
+// RenderShardActionJob renders the job to execute the shard lifecycle action against the shard component.
+// shards are the components of the sharding after the scaling. The job is not created here.
+func RenderShardActionJob(ctx context.Context,
+	cli client.Reader,
+	cluster *appsv1alpha1.Cluster,
+	synthesizeComp *SynthesizedComponent,
+	actionType LifeCycleActionType,
+	shardingName string,
+	shards []string) (*batchv1.Job, error) {
+	if !HasShardAction(synthesizeComp, actionType) {
+		return nil, fmt.Errorf("shard action %s not found in component %s", actionType, synthesizeComp.Name)
+	}
+	job, err := renderActionCmdJob(ctx, cli, cluster, synthesizeComp, actionType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env,
+			corev1.EnvVar{
+				Name:  kbShardActionShardingName,
+				Value: shardingName,
+			},
+			corev1.EnvVar{
+				Name:  kbShardActionShardingCompList,
+				Value: strings.Join(shards, ","),
+			})
+	}
+	return job, nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -312,6 +313,16 @@ func buildEnv4DBAccount(synthesizeComp *SynthesizedComponent, clusterCompSpec *a
 
 	if clusterCompSpec == nil || clusterCompSpec.ComponentDef != "" {
 		if sysInitAccount != nil {
+			if _, injector, _ := secretstore.DefaultInjector(); injector != nil {
+				// the account secret is not created, the password is injected into the file from the secret store
+				return []corev1.EnvVar{
+					{Name: constant.KBEnvServiceUser, Value: sysInitAccount.Name},
+					{
+						Name:  constant.KBEnvServicePasswordFile,
+						Value: injector.InjectedFilePath(secretstore.AccountInjectionName(sysInitAccount.Name)),
+					},
+				}
+			}
 			secretName = constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, sysInitAccount.Name)
 		}
 	} else {
@@ -440,7 +451,7 @@ func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedCo
 		constant.ReadWriteAction:     synthesizeComp.LifecycleActions.Readwrite,
 		constant.DataDumpAction:      synthesizeComp.LifecycleActions.DataDump,
 		constant.DataLoadAction:      synthesizeComp.LifecycleActions.DataLoad,
-		// "reconfigure":                synthesizeComp.LifecycleActions.Reconfigure,
+		constant.ReconfigureAction:   synthesizeComp.LifecycleActions.Reconfigure,
 		// "accountProvision": synthesizeComp.LifecycleActions.AccountProvision,
 	}
 
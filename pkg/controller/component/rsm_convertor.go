@@ -28,6 +28,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // BuildWorkloadFrom builds a new Component object based on SynthesizedComponent.
@@ -187,6 +188,7 @@ func getMemberUpdateStrategy(synthesizedComp *SynthesizedComponent) *workloads.M
 		serial                   = workloads.SerialUpdateStrategy
 		parallelUpdate           = workloads.ParallelUpdateStrategy
 		bestEffortParallelUpdate = workloads.BestEffortParallelUpdateStrategy
+		blueGreenUpdate          = workloads.BlueGreenUpdateStrategy
 	)
 	switch *synthesizedComp.UpdateStrategy {
 	case appsv1alpha1.SerialStrategy:
@@ -195,6 +197,8 @@ func getMemberUpdateStrategy(synthesizedComp *SynthesizedComponent) *workloads.M
 		return &parallelUpdate
 	case appsv1alpha1.BestEffortParallelStrategy:
 		return &bestEffortParallelUpdate
+	case appsv1alpha1.BlueGreenStrategy:
+		return &blueGreenUpdate
 	default:
 		return nil
 	}
@@ -305,6 +309,10 @@ func (c *itsCredentialConvertor) convert(args ...any) (any, error) {
 
 	var secretName string
 	if sysInitAccount != nil {
+		if _, injector, _ := secretstore.DefaultInjector(); injector != nil {
+			// the account secret is not created, the role probe of lorry reads the password from the injected file
+			return nil, nil
+		}
 		secretName = constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, sysInitAccount.Name)
 	} else {
 		secretName = constant.GenerateDefaultConnCredential(synthesizeComp.ClusterName)
@@ -166,6 +166,7 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		Roles:              compDefObj.Spec.Roles,
 		UpdateStrategy:     compDefObj.Spec.UpdateStrategy,
 		MinReadySeconds:    compDefObj.Spec.MinReadySeconds,
+		ProtectLeader:      compDefObj.Spec.ProtectLeaderFromEviction,
 		PolicyRules:        compDefObj.Spec.PolicyRules,
 		LifecycleActions:   compDefObj.Spec.LifecycleActions,
 		SystemAccounts:     compDefObj.Spec.SystemAccounts,
@@ -176,6 +177,7 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		ServiceAccountName: comp.Spec.ServiceAccountName,
 		Instances:          comp.Spec.Instances,
 		OfflineInstances:   comp.Spec.OfflineInstances,
+		InstancePlacement:  comp.Spec.InstancePlacement,
 		Sidecars:           comp.Spec.Sidecars,
 		MonitorEnabled:     buildMonitorEnabled(comp),
 	}
@@ -336,6 +338,9 @@ func buildLabelsAndAnnotations(compDef *appsv1alpha1.ComponentDefinition, comp *
 		}
 		// override annotations from component
 		synthesizeComp.Annotations = mergeMaps(baseAnnotations, comp.Annotations)
+		// the drift annotations are specific to the component object, and should not be propagated to the workload.
+		delete(synthesizeComp.Annotations, constant.LastAppliedSpecAnnotationKey)
+		delete(synthesizeComp.Annotations, constant.DriftPolicyAnnotationKey)
 	}
 }
 
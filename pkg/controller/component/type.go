@@ -54,6 +54,7 @@ type SynthesizedComponent struct {
 	EnvFromSources    []corev1.EnvFromSource                 `json:"envFromSources,omitempty"`
 	Instances         []v1alpha1.InstanceTemplate            `json:"instances,omitempty"`
 	OfflineInstances  []string                               `json:"offlineInstances,omitempty"`
+	InstancePlacement *v1alpha1.InstancePlacementPolicy      `json:"instancePlacement,omitempty"`
 
 	// The following fields were introduced with the ComponentDefinition and Component API in KubeBlocks version 0.8.0
 	Roles               []v1alpha1.ReplicaRole              `json:"roles,omitempty"`
@@ -69,6 +70,7 @@ type SynthesizedComponent struct {
 	HostNetwork         *v1alpha1.HostNetwork               `json:"hostNetwork,omitempty"`
 	ComponentServices   []v1alpha1.ComponentService         `json:"componentServices,omitempty"`
 	MinReadySeconds     int32                               `json:"minReadySeconds,omitempty"`
+	ProtectLeader       bool                                `json:"protectLeader,omitempty"`
 	Sidecars            []string                            `json:"sidecars,omitempty"`
 	MonitorEnabled      bool                                `json:"monitorEnabled,omitempty"`
 
@@ -41,6 +41,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 var (
@@ -661,6 +662,9 @@ func resolveCredentialVarRef(ctx context.Context, cli client.Reader, synthesized
 func resolveCredentialUsernameRef(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
 	defineKey string, selector appsv1alpha1.CredentialVarSelector) ([]*corev1.EnvVar, []*corev1.EnvVar, error) {
 	resolveUsername := func(obj any) (*corev1.EnvVar, *corev1.EnvVar) {
+		if credential, ok := obj.(*injectedCredential); ok {
+			return nil, &corev1.EnvVar{Name: defineKey, Value: credential.username}
+		}
 		secret := obj.(*corev1.Secret)
 		if secret.Data != nil {
 			if _, ok := secret.Data[constant.AccountNameForSecret]; ok {
@@ -685,6 +689,9 @@ func resolveCredentialUsernameRef(ctx context.Context, cli client.Reader, synthe
 func resolveCredentialPasswordRef(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
 	defineKey string, selector appsv1alpha1.CredentialVarSelector) ([]*corev1.EnvVar, []*corev1.EnvVar, error) {
 	resolvePassword := func(obj any) (*corev1.EnvVar, *corev1.EnvVar) {
+		if credential, ok := obj.(*injectedCredential); ok {
+			return nil, &corev1.EnvVar{Name: defineKey + credentialFileVarSuffix, Value: credential.passwordFile}
+		}
 		secret := obj.(*corev1.Secret)
 		if secret.Data != nil {
 			if _, ok := secret.Data[constant.AccountPasswdForSecret]; ok {
@@ -884,10 +891,33 @@ func headlessCompServiceGetter(ctx context.Context, cli client.Reader, namespace
 	return &resolvedServiceObj{service: obj}, err
 }
 
+// credentialFileVarSuffix is the suffix of the vars which refer to the files of the injected passwords.
+const credentialFileVarSuffix = "_FILE"
+
+// injectedCredential is the credential of the system account whose password is injected into the pods from the
+// external secret store, the account secret is not created, so the password var is resolved to the var with
+// the suffix credentialFileVarSuffix, whose value is the path of the injected file.
+type injectedCredential struct {
+	username     string
+	passwordFile string
+}
+
 func resolveCredentialVarRefLow(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
 	selector appsv1alpha1.CredentialVarSelector, option *appsv1alpha1.VarOption, resolveVar func(any) (*corev1.EnvVar, *corev1.EnvVar)) ([]*corev1.EnvVar, []*corev1.EnvVar, error) {
 	resolveObjs := func() (map[string]any, error) {
 		getter := func(compName string) (any, error) {
+			if compName == synthesizedComp.Name {
+				_, injector, err := secretstore.DefaultInjector()
+				if err != nil {
+					return nil, err
+				}
+				if injector != nil {
+					return &injectedCredential{
+						username:     selector.Name,
+						passwordFile: injector.InjectedFilePath(secretstore.AccountInjectionName(selector.Name)),
+					}, nil
+				}
+			}
 			key := types.NamespacedName{
 				Namespace: synthesizedComp.Namespace,
 				Name:      constant.GenerateAccountSecretName(synthesizedComp.ClusterName, compName, selector.Name),
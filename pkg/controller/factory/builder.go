@@ -100,6 +100,7 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, component
 		SetServiceName(constant.GenerateServiceNamePattern(itsName)).
 		SetReplicas(synthesizedComp.Replicas).
 		SetMinReadySeconds(synthesizedComp.MinReadySeconds).
+		SetProtectLeaderFromEviction(synthesizedComp.ProtectLeader).
 		SetTemplate(template)
 
 	var vcts []corev1.PersistentVolumeClaim
@@ -108,6 +109,10 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, component
 	}
 	itsBuilder.SetVolumeClaimTemplates(vcts...)
 
+	if synthesizedComp.InstancePlacement != nil {
+		itsBuilder.SetPlacementPolicy(synthesizedComp.InstancePlacement.InstancePlacementPolicy.DeepCopy())
+	}
+
 	if common.IsCompactMode(synthesizedComp.Annotations) {
 		itsBuilder.AddAnnotations(constant.FeatureReconciliationInCompactModeAnnotationKey,
 			synthesizedComp.Annotations[constant.FeatureReconciliationInCompactModeAnnotationKey])
@@ -334,6 +334,14 @@ var _ = Describe("builder", func() {
 			// test member update strategy
 			Expect(its.Spec.MemberUpdateStrategy).ShouldNot(BeNil())
 			Expect(*its.Spec.MemberUpdateStrategy).Should(BeEquivalentTo(workloads.BestEffortParallelUpdateStrategy))
+
+			By("set update strategy to BlueGreen")
+			blueGreen := appsv1alpha1.BlueGreenStrategy
+			csComponent.UpdateStrategy = &blueGreen
+			its, err = BuildInstanceSet(csComponent, nil)
+			Expect(err).Should(BeNil())
+			Expect(its.Spec.MemberUpdateStrategy).ShouldNot(BeNil())
+			Expect(*its.Spec.MemberUpdateStrategy).Should(BeEquivalentTo(workloads.BlueGreenUpdateStrategy))
 		})
 
 		It("builds BackupJob correctly", func() {
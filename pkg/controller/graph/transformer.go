@@ -22,6 +22,7 @@ package graph
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/tools/record"
@@ -50,13 +51,15 @@ type TransformerChain []Transformer
 // Use it in Transformer.Transform when all jobs have done and no need to run following transformers
 var ErrPrematureStop = errors.New("Premature-Stop")
 
-// ApplyTo applies TransformerChain t to dag
+// ApplyTo applies TransformerChain t to dag.
+// The delayed requeue errors are returned after all transformers are applied, and the earliest one is kept,
+// so that a short retry of a transformer is not hidden by a long schedule of another.
 func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	var delayedError error
 	for _, transformer := range r {
 		if err := transformer.Transform(ctx, dag); err != nil {
 			if intctrlutil.IsDelayedRequeueError(err) {
-				if delayedError == nil {
+				if delayedError == nil || requeueAfter(err) < requeueAfter(delayedError) {
 					delayedError = err
 				}
 				continue
@@ -67,6 +70,10 @@ func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	return delayedError
 }
 
+func requeueAfter(err error) time.Duration {
+	return err.(intctrlutil.RequeueError).RequeueAfter()
+}
+
 func ignoredIfPrematureStop(err error) error {
 	if err == ErrPrematureStop {
 		return nil
@@ -0,0 +1,69 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type errorTransformer struct {
+	err     error
+	applied bool
+}
+
+func (t *errorTransformer) Transform(ctx TransformContext, dag *DAG) error {
+	t.applied = true
+	return t.err
+}
+
+func TestApplyToKeepsEarliestDelayedRequeue(t *testing.T) {
+	long := &errorTransformer{err: intctrlutil.NewDelayedRequeueError(24*time.Hour, "long")}
+	short := &errorTransformer{err: intctrlutil.NewDelayedRequeueError(10*time.Second, "short")}
+	last := &errorTransformer{}
+	err := TransformerChain{long, short, last}.ApplyTo(nil, NewDAG())
+	if !intctrlutil.IsDelayedRequeueError(err) {
+		t.Fatalf("expect a delayed requeue error, got %v", err)
+	}
+	if after := err.(intctrlutil.RequeueError).RequeueAfter(); after != 10*time.Second {
+		t.Errorf("expect the earliest requeue to be kept, got %v", after)
+	}
+	if !last.applied {
+		t.Error("expect the transformers after the delayed requeue to be applied")
+	}
+}
+
+func TestApplyToStopsOnError(t *testing.T) {
+	delayed := &errorTransformer{err: intctrlutil.NewDelayedRequeueError(time.Second, "delayed")}
+	failed := &errorTransformer{err: errors.New("failed")}
+	last := &errorTransformer{}
+	if err := (TransformerChain{delayed, failed, last}).ApplyTo(nil, NewDAG()); err != failed.err {
+		t.Errorf("expect the error to be returned, got %v", err)
+	}
+	if last.applied {
+		t.Error("expect the transformers after the error not to be applied")
+	}
+	if err := (TransformerChain{&errorTransformer{err: ErrPrematureStop}, last}).ApplyTo(nil, NewDAG()); err != nil {
+		t.Errorf("expect the premature stop to be ignored, got %v", err)
+	}
+}
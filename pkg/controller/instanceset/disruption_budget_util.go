@@ -0,0 +1,130 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func getLeaderPDBName(itsName string) string {
+	return itsName + "-leader"
+}
+
+func getQuorumPDBName(itsName string) string {
+	return itsName + "-quorum"
+}
+
+// buildPodDisruptionBudgets builds the PodDisruptionBudgets which protect the voting members of the InstanceSet
+// from voluntary disruptions, such as the evictions triggered by node drains.
+//
+// An evicted pod must match no more than one PodDisruptionBudget, so the pods are selected by roles:
+//   - the leader PDB refuses to evict the leader, the leader can be evicted after a switchover has moved leadership
+//     to another member and its role label is updated. It blocks the node drains until the switchover is done,
+//     so it is built only if the InstanceSet opts in by ProtectLeaderFromEviction.
+//   - the quorum PDB selects the other voting members, and keeps enough of them available to form a quorum with the leader.
+//     It selects the leader as well if the leader is not protected by the leader PDB.
+//
+// The quorum is computed from the replicas rather than the observed roles, so that it doesn't shrink while some members
+// are down or their roles have not been probed yet. Only the members observed without voting rights are excluded.
+// Pods whose roles have not been probed yet and the members without voting rights are not protected.
+func buildPodDisruptionBudgets(its *workloads.InstanceSet, pods []*corev1.Pod, labels map[string]string) []client.Object {
+	var leaderRoles, followerRoles, nonVoterRoles []string
+	for _, role := range its.Spec.Roles {
+		switch {
+		case role.IsLeader:
+			leaderRoles = append(leaderRoles, role.Name)
+		case role.CanVote:
+			followerRoles = append(followerRoles, role.Name)
+		default:
+			nonVoterRoles = append(nonVoterRoles, role.Name)
+		}
+	}
+	if len(leaderRoles) == 0 && len(followerRoles) == 0 {
+		return nil
+	}
+
+	replicas := int32(1)
+	if its.Spec.Replicas != nil {
+		replicas = *its.Spec.Replicas
+	}
+	var leaders, nonVoters int32
+	for _, pod := range pods {
+		role := pod.Labels[constant.RoleLabelKey]
+		switch {
+		case slices.Contains(leaderRoles, role):
+			leaders++
+		case slices.Contains(nonVoterRoles, role):
+			nonVoters++
+		}
+	}
+
+	var pdbs []client.Object
+	// the only replica can't be switched over, refusing to evict it blocks the node drains forever.
+	multiReplicas := replicas > 1
+	protectLeader := its.Spec.ProtectLeaderFromEviction && len(leaderRoles) > 0 && multiReplicas
+	if protectLeader {
+		maxUnavailable := intstr.FromInt(0)
+		pdbs = append(pdbs, buildPodDisruptionBudget(its, getLeaderPDBName(its.Name), labels, leaderRoles,
+			policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}))
+	}
+	if len(followerRoles) > 0 {
+		quorum := max(replicas-nonVoters, 0)/2 + 1
+		quorumRoles, minAvailable := followerRoles, max(quorum-leaders, 0)
+		if !protectLeader && multiReplicas {
+			// the leader can be evicted as well, so it is counted in the quorum.
+			quorumRoles, minAvailable = append(slices.Clone(leaderRoles), followerRoles...), quorum
+		}
+		minAvailableVal := intstr.FromInt(int(minAvailable))
+		pdbs = append(pdbs, buildPodDisruptionBudget(its, getQuorumPDBName(its.Name), labels, quorumRoles,
+			policyv1.PodDisruptionBudgetSpec{MinAvailable: &minAvailableVal}))
+	}
+	return pdbs
+}
+
+func buildPodDisruptionBudget(its *workloads.InstanceSet, name string, labels map[string]string,
+	roles []string, spec policyv1.PodDisruptionBudgetSpec) *policyv1.PodDisruptionBudget {
+	spec.Selector = &metav1.LabelSelector{
+		MatchLabels: maps.Clone(labels),
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      constant.RoleLabelKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   roles,
+			},
+		},
+	}
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: its.Namespace,
+			Name:      name,
+			Labels:    maps.Clone(labels),
+		},
+		Spec: spec,
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("disruption budget util test", func() {
+	buildPods := func(roleNames ...string) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for i, role := range roleNames {
+			pods = append(pods, builder.NewPodBuilder(namespace, name+"-"+string(rune('0'+i))).
+				AddLabels(constant.RoleLabelKey, role).
+				GetObject())
+		}
+		return pods
+	}
+
+	BeforeEach(func() {
+		its = builder.NewInstanceSetBuilder(namespace, name).
+			SetReplicas(5).
+			SetRoles(roles).
+			SetProtectLeaderFromEviction(true).
+			GetObject()
+	})
+
+	Context("buildPodDisruptionBudgets", func() {
+		It("should protect the leader and the quorum", func() {
+			labels := getMatchLabels(name)
+			pods := buildPods("leader", "follower", "follower", "logger", "learner")
+			pdbs := buildPodDisruptionBudgets(its, pods, labels)
+			Expect(pdbs).Should(HaveLen(2))
+
+			leaderPDB, ok := pdbs[0].(*policyv1.PodDisruptionBudget)
+			Expect(ok).Should(BeTrue())
+			Expect(leaderPDB.Name).Should(Equal(getLeaderPDBName(name)))
+			Expect(*leaderPDB.Spec.MaxUnavailable).Should(Equal(intstr.FromInt(0)))
+			Expect(leaderPDB.Spec.Selector.MatchLabels).Should(Equal(labels))
+			Expect(leaderPDB.Spec.Selector.MatchExpressions[0].Values).Should(Equal([]string{"leader"}))
+
+			// 4 voters, the quorum is 3 and the leader is protected by the leader PDB.
+			quorumPDB, ok := pdbs[1].(*policyv1.PodDisruptionBudget)
+			Expect(ok).Should(BeTrue())
+			Expect(quorumPDB.Name).Should(Equal(getQuorumPDBName(name)))
+			Expect(*quorumPDB.Spec.MinAvailable).Should(Equal(intstr.FromInt(2)))
+			Expect(quorumPDB.Spec.Selector.MatchExpressions[0].Values).Should(Equal([]string{"follower", "logger"}))
+		})
+
+		It("should keep the quorum without the leader", func() {
+			// 5 voters, the quorum is 3 and all of them are followers as the leader is down.
+			pdbs := buildPodDisruptionBudgets(its, buildPods("follower", "follower", "follower"), getMatchLabels(name))
+			Expect(pdbs).Should(HaveLen(2))
+			quorumPDB, _ := pdbs[1].(*policyv1.PodDisruptionBudget)
+			Expect(*quorumPDB.Spec.MinAvailable).Should(Equal(intstr.FromInt(3)))
+		})
+
+		It("should not shrink the quorum while some members are unlabelled", func() {
+			its.Spec.ProtectLeaderFromEviction = false
+			// 5 voters with 2 of them unlabelled, the quorum is still 3 and no more member can be evicted.
+			pdbs := buildPodDisruptionBudgets(its, buildPods("leader", "follower", "follower", "", ""), getMatchLabels(name))
+			Expect(pdbs).Should(HaveLen(1))
+			quorumPDB, _ := pdbs[0].(*policyv1.PodDisruptionBudget)
+			Expect(*quorumPDB.Spec.MinAvailable).Should(Equal(intstr.FromInt(3)))
+		})
+
+		It("should not protect the leader if not opted in", func() {
+			its.Spec.ProtectLeaderFromEviction = false
+			its.Spec.Replicas = func() *int32 { r := int32(3); return &r }()
+			pdbs := buildPodDisruptionBudgets(its, buildPods("leader", "follower", "follower"), getMatchLabels(name))
+			Expect(pdbs).Should(HaveLen(1))
+			Expect(pdbs[0].GetName()).Should(Equal(getQuorumPDBName(name)))
+			// the leader is counted in the quorum of 3 voters.
+			quorumPDB, _ := pdbs[0].(*policyv1.PodDisruptionBudget)
+			Expect(*quorumPDB.Spec.MinAvailable).Should(Equal(intstr.FromInt(2)))
+			Expect(quorumPDB.Spec.Selector.MatchExpressions[0].Values).Should(Equal([]string{"leader", "follower", "logger"}))
+		})
+
+		It("should not protect the only replica", func() {
+			its.Spec.Replicas = func() *int32 { r := int32(1); return &r }()
+			pdbs := buildPodDisruptionBudgets(its, buildPods("leader"), getMatchLabels(name))
+			Expect(pdbs).Should(HaveLen(1))
+			Expect(pdbs[0].GetName()).Should(Equal(getQuorumPDBName(name)))
+		})
+
+		It("should build nothing without voting roles", func() {
+			its.Spec.Roles = []workloads.ReplicaRole{{Name: "learner", CanVote: false}}
+			Expect(buildPodDisruptionBudgets(its, nil, getMatchLabels(name))).Should(BeEmpty())
+			its.Spec.Roles = nil
+			Expect(buildPodDisruptionBudgets(its, nil, getMatchLabels(name))).Should(BeEmpty())
+		})
+	})
+})
@@ -307,7 +307,7 @@ func getPodUpdatePolicy(its *workloads.InstanceSet, pod *corev1.Pod) (PodUpdateP
 	}
 	templateList := buildInstanceTemplateExts(itsExt)
 	parentName, _ := ParseParentNameAndOrdinal(pod.Name)
-	templateName, _ := strings.CutPrefix(parentName, its.Name)
+	templateName, _ := strings.CutPrefix(parentName, GetInstanceNamePrefix(its))
 	if len(templateName) > 0 {
 		templateName, _ = strings.CutPrefix(templateName, "-")
 	}
@@ -350,3 +350,24 @@ func IsPodUpdated(its *workloads.InstanceSet, pod *corev1.Pod) (bool, error) {
 	policy, err := getPodUpdatePolicy(its, pod)
 	return policy == NoOpsPolicy, err
 }
+
+// IsPodDesired tells whether the pod is still expected by the InstanceSet.
+func IsPodDesired(its *workloads.InstanceSet, pod *corev1.Pod) (bool, error) {
+	updateRevisions, err := getUpdateRevisions(its.Status.UpdateRevisions)
+	if err != nil {
+		return false, err
+	}
+	_, ok := updateRevisions[pod.Name]
+	return ok, nil
+}
+
+// IsPodRecreationRequired tells whether the pod has to be recreated to meet the spec expected in the InstanceSet.
+// It returns false if the pod is not expected by the InstanceSet any more.
+func IsPodRecreationRequired(its *workloads.InstanceSet, pod *corev1.Pod) (bool, error) {
+	desired, err := IsPodDesired(its, pod)
+	if err != nil || !desired {
+		return false, err
+	}
+	policy, err := getPodUpdatePolicy(its, pod)
+	return policy == RecreatePolicy, err
+}
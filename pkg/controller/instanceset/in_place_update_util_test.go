@@ -158,6 +158,17 @@ var _ = Describe("instance util test", func() {
 			policy, err = getPodUpdatePolicy(its, pod2)
 			Expect(err).Should(BeNil())
 			Expect(policy).Should(Equal(RecreatePolicy))
+			required, err := IsPodRecreationRequired(its, pod2)
+			Expect(err).Should(BeNil())
+			Expect(required).Should(BeTrue())
+			orphanPod := pod2.DeepCopy()
+			orphanPod.Name = "orphan"
+			desired, err := IsPodDesired(its, orphanPod)
+			Expect(err).Should(BeNil())
+			Expect(desired).Should(BeFalse())
+			required, err = IsPodRecreationRequired(its, orphanPod)
+			Expect(err).Should(BeNil())
+			Expect(required).Should(BeFalse())
 
 			By("build a pod without revision updated, with basic mutable fields updated")
 			pod3 := pod1.DeepCopy()
@@ -31,6 +31,7 @@ import (
 	"github.com/klauspost/compress/zstd"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -190,11 +191,18 @@ func ValidateDupInstanceNames[T any](instances []T, getNameFunc func(item T) str
 }
 
 func buildInstanceName2TemplateMap(itsExt *instanceSetExt) (map[string]*instanceTemplateExt, error) {
+	return buildInstanceName2TemplateMapOf(itsExt, getActiveSet(itsExt.its))
+}
+
+// buildInstanceName2TemplateMapOf builds the instance name to template map of the instances in the set of the blue/green update.
+func buildInstanceName2TemplateMapOf(itsExt *instanceSetExt, set workloads.InstanceColor) (map[string]*instanceTemplateExt, error) {
 	instanceTemplateList := buildInstanceTemplateExts(itsExt)
 	allNameTemplateMap := make(map[string]*instanceTemplateExt)
+	prefix := getInstanceNamePrefixOf(itsExt.its, set)
+	offlineInstances := getOfflineInstancesOf(itsExt.its, set)
 	var instanceNameList []string
 	for _, template := range instanceTemplateList {
-		instanceNames := GenerateInstanceNamesFromTemplate(itsExt.its.Name, template.Name, template.Replicas, itsExt.its.Spec.OfflineInstances)
+		instanceNames := GenerateInstanceNamesFromTemplate(prefix, template.Name, template.Replicas, offlineInstances)
 		instanceNameList = append(instanceNameList, instanceNames...)
 		for _, name := range instanceNames {
 			allNameTemplateMap[name] = template
@@ -352,6 +360,12 @@ func copyAndMerge(oldObj, newObj client.Object) client.Object {
 		return oldCm
 	}
 
+	copyAndMergePDB := func(oldPDB, newPDB *policyv1.PodDisruptionBudget) client.Object {
+		oldPDB.Labels = mergeMetadataMap(oldPDB.Labels, newPDB.Labels)
+		oldPDB.Spec = newPDB.Spec
+		return oldPDB
+	}
+
 	copyAndMergePod := func(oldPod, newPod *corev1.Pod) client.Object {
 		mergeInPlaceFields(newPod, oldPod)
 		return oldPod
@@ -377,6 +391,8 @@ func copyAndMerge(oldObj, newObj client.Object) client.Object {
 		return copyAndMergeSvc(targetObj.(*corev1.Service), o)
 	case *corev1.ConfigMap:
 		return copyAndMergeCm(targetObj.(*corev1.ConfigMap), o)
+	case *policyv1.PodDisruptionBudget:
+		return copyAndMergePDB(targetObj.(*policyv1.PodDisruptionBudget), o)
 	case *corev1.Pod:
 		return copyAndMergePod(targetObj.(*corev1.Pod), o)
 	case *corev1.PersistentVolumeClaim:
@@ -403,6 +419,10 @@ func validateSpec(its *workloads.InstanceSet, tree *kubebuilderx.ObjectTree) err
 			return fmt.Errorf("duplicate instance template name: %s", template.Name)
 		}
 		templateNames.Insert(template.Name)
+		// the instances of the template can't be told from the ones of the green set by the name.
+		if isBlueGreenUpdate(its) && strings.HasPrefix(template.Name, greenInstanceNameSuffix) {
+			return fmt.Errorf("instance template name %s is reserved by the blue/green update", template.Name)
+		}
 	}
 	// sum of spec.templates[*].replicas should not greater than spec.replicas
 	if replicasInTemplates > *its.Spec.Replicas {
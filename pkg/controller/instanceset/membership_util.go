@@ -0,0 +1,155 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slices"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// joinMembers joins the new instances to the replication group of the members by the MemberJoin action after they are
+// available, and waits for their roles to be probed. It tells whether the new instances are ready to serve as members.
+func joinMembers(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, actionLabelKey string,
+	members, newPods []*corev1.Pod) (bool, error) {
+	for _, pod := range newPods {
+		if isRunningAndAvailable(pod, its.Spec.MinReadySeconds) {
+			continue
+		}
+		if isRunningAndReady(pod) {
+			return false, intctrlutil.NewDelayedRequeueError(time.Duration(its.Spec.MinReadySeconds)*time.Second,
+				fmt.Sprintf("wait for the pod %s to be available", pod.Name))
+		}
+		return false, nil
+	}
+
+	if reconfiguration := its.Spec.MembershipReconfiguration; reconfiguration != nil && reconfiguration.MemberJoinAction != nil {
+		leader := getLeaderPod(its, members)
+		if leader == nil && hasLeaderRole(its) {
+			return false, nil
+		}
+		done, err := runMembershipActions(tree, its, actionLabelKey, rsm.MemberJoinActionType, leader, newPods)
+		if err != nil || !done {
+			return false, err
+		}
+	}
+
+	if its.Spec.RoleProbe != nil && len(its.Spec.Roles) > 0 {
+		for _, pod := range newPods {
+			if len(pod.Labels[constant.RoleLabelKey]) == 0 {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// runMembershipActions runs the membership reconfiguration action for each target pod, and tells whether all of them succeed.
+// The Jobs of the actions are labeled with the actionLabelKey, and the failed ones are deleted and run again.
+func runMembershipActions(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, actionLabelKey, actionType string,
+	leader *corev1.Pod, targets []*corev1.Pod) (bool, error) {
+	leaderName := ""
+	if leader != nil {
+		leaderName = leader.Name
+	}
+	done := true
+	for _, target := range targets {
+		name := fmt.Sprintf("%s-%d-%s", target.Name, its.Generation, actionType)
+		object, err := tree.Get(builder.NewJobBuilder(its.Namespace, name).GetObject())
+		if err != nil {
+			return false, err
+		}
+		job, _ := object.(*batchv1.Job)
+		switch {
+		case job == nil:
+			action := rsm.BuildMembershipAction(its, name, actionType, leaderName, target.Name)
+			action.Labels[actionLabelKey] = actionType
+			if err = controllerutil.SetControllerReference(its, action, model.GetScheme()); err != nil {
+				return false, err
+			}
+			if err = tree.Add(action); err != nil {
+				return false, err
+			}
+			done = false
+		case job.Status.Succeeded > 0:
+		case job.Status.Failed > 0:
+			tree.EventRecorder.Eventf(its, corev1.EventTypeWarning, "MembershipActionFailed",
+				"the %s action of the pod %s failed, retry it", actionType, target.Name)
+			if err = tree.Delete(job); err != nil {
+				return false, err
+			}
+			done = false
+		default:
+			done = false
+		}
+	}
+	return done, nil
+}
+
+// deleteInstance deletes the pod and its volumes.
+func deleteInstance(tree *kubebuilderx.ObjectTree, pod *corev1.Pod) error {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc, err := tree.Get(builder.NewPVCBuilder(pod.Namespace, volume.PersistentVolumeClaim.ClaimName).GetObject())
+		if err != nil {
+			return err
+		}
+		if pvc != nil {
+			if err = tree.Delete(pvc); err != nil {
+				return err
+			}
+		}
+	}
+	return tree.Delete(pod)
+}
+
+func hasLeaderRole(its *workloads.InstanceSet) bool {
+	return slices.ContainsFunc(its.Spec.Roles, func(role workloads.ReplicaRole) bool {
+		return role.IsLeader
+	})
+}
+
+func getLeaderPod(its *workloads.InstanceSet, pods []*corev1.Pod) *corev1.Pod {
+	for _, role := range its.Spec.Roles {
+		if !role.IsLeader {
+			continue
+		}
+		for _, pod := range pods {
+			if pod.Labels[constant.RoleLabelKey] == role.Name {
+				return pod
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"context"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+)
+
+func getTopologyKey(its *workloads.InstanceSet) string {
+	if its.Spec.PlacementPolicy == nil || its.Spec.PlacementPolicy.TopologyKey == "" {
+		return corev1.LabelTopologyZone
+	}
+	return its.Spec.PlacementPolicy.TopologyKey
+}
+
+func getMaxSkew(its *workloads.InstanceSet) int32 {
+	if its.Spec.PlacementPolicy == nil || its.Spec.PlacementPolicy.MaxSkew < 1 {
+		return 1
+	}
+	return its.Spec.PlacementPolicy.MaxSkew
+}
+
+// loadNodes loads the nodes labeled with the topology key of the placement policy into the tree,
+// they are read only and used to resolve the topology domains of the instances.
+func loadNodes(ctx context.Context, reader client.Reader, tree *kubebuilderx.ObjectTree) error {
+	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	if its == nil || model.IsObjectDeleting(its) || its.Spec.PlacementPolicy == nil {
+		return nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := reader.List(ctx, nodes, client.HasLabels{getTopologyKey(its)}); err != nil {
+		return err
+	}
+	for i := range nodes.Items {
+		if err := tree.Add(&nodes.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildPlacementStatus builds the placement status of the instances across the topology domains.
+//
+// The domains are the ones of the schedulable nodes and the ones where the instances are placed. If the skew exceeds
+// the maxSkew, a follower in the most populated domain is suggested to be rebuilt in the least populated schedulable
+// domain. Nothing is suggested unless all the instances are available, and the leader and the held instances are
+// never suggested.
+func buildPlacementStatus(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pods []*corev1.Pod) *workloads.InstancePlacementStatus {
+	if its.Spec.PlacementPolicy == nil {
+		return nil
+	}
+	topologyKey := getTopologyKey(its)
+	status := &workloads.InstancePlacementStatus{TopologyKey: topologyKey}
+
+	nodeDomains := make(map[string]string)
+	schedulableDomains := make(map[string]bool)
+	for _, object := range tree.List(&corev1.Node{}) {
+		node, _ := object.(*corev1.Node)
+		domain, ok := node.Labels[topologyKey]
+		if !ok {
+			continue
+		}
+		nodeDomains[node.Name] = domain
+		if !node.Spec.Unschedulable {
+			schedulableDomains[domain] = true
+		}
+	}
+	domainPods := make(map[string][]*corev1.Pod)
+	for domain := range schedulableDomains {
+		domainPods[domain] = nil
+	}
+	allAvailable := true
+	for _, pod := range pods {
+		if !isRunningAndAvailable(pod, its.Spec.MinReadySeconds) || isTerminating(pod) {
+			allAvailable = false
+		}
+		domain, ok := nodeDomains[pod.Spec.NodeName]
+		if !ok {
+			status.UnplacedInstances = append(status.UnplacedInstances, pod.Name)
+			continue
+		}
+		domainPods[domain] = append(domainPods[domain], pod)
+	}
+	slices.Sort(status.UnplacedInstances)
+
+	var mostPopulated, leastPopulated string
+	for domain, podsInDomain := range domainPods {
+		instances := make([]string, 0, len(podsInDomain))
+		for _, pod := range podsInDomain {
+			instances = append(instances, pod.Name)
+		}
+		slices.Sort(instances)
+		status.Domains = append(status.Domains, workloads.TopologyDomainStatus{Name: domain, Instances: instances})
+	}
+	slices.SortFunc(status.Domains, func(a, b workloads.TopologyDomainStatus) bool {
+		return a.Name < b.Name
+	})
+	for _, domain := range status.Domains {
+		if mostPopulated == "" || len(domain.Instances) > len(domainPods[mostPopulated]) {
+			mostPopulated = domain.Name
+		}
+		if !schedulableDomains[domain.Name] {
+			continue
+		}
+		if leastPopulated == "" || len(domain.Instances) < len(domainPods[leastPopulated]) {
+			leastPopulated = domain.Name
+		}
+	}
+	if leastPopulated == "" {
+		return status
+	}
+	status.Skew = int32(len(domainPods[mostPopulated]) - len(domainPods[leastPopulated]))
+	status.Imbalanced = status.Skew > getMaxSkew(its)
+	if !status.Imbalanced || !allAvailable {
+		return status
+	}
+
+	candidates := slices.Clone(domainPods[mostPopulated])
+	leader := getLeaderPod(its, pods)
+	if hasLeaderRole(its) && leader == nil {
+		// the leader is unknown, any of the instances may be the leader.
+		return status
+	}
+	sortObjects(candidates, rsm.ComposeRolePriorityMap(its.Spec.Roles), false)
+	for _, pod := range candidates {
+		if pod == leader || isHeldInstance(its, pod) {
+			continue
+		}
+		status.RebalanceInstance = pod.Name
+		status.RebalanceTargetDomain = leastPopulated
+		break
+	}
+	return status
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+var _ = Describe("placement util test", func() {
+	buildNode := func(nodeName, zone string, unschedulable bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   nodeName,
+				Labels: map[string]string{corev1.LabelTopologyZone: zone},
+			},
+			Spec: corev1.NodeSpec{Unschedulable: unschedulable},
+		}
+	}
+	buildAvailablePod := func(podName, role, nodeName string) *corev1.Pod {
+		pod := builder.NewPodBuilder(namespace, podName).
+			AddLabels(constant.RoleLabelKey, role).
+			SetNodeName(types.NodeName(nodeName)).
+			GetObject()
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:               corev1.PodReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			},
+		}
+		return pod
+	}
+
+	Context("buildPlacementStatus", func() {
+		It("should work well", func() {
+			its := builder.NewInstanceSetBuilder(namespace, name).SetRoles(roles).GetObject()
+			tree := kubebuilderx.NewObjectTree()
+			tree.SetRoot(its)
+			Expect(tree.Add(buildNode("node-a", "zone-a", false), buildNode("node-b", "zone-b", false),
+				buildNode("node-c", "zone-c", false))).Should(Succeed())
+			pods := []*corev1.Pod{
+				buildAvailablePod(name+"-0", "follower", "node-a"),
+				buildAvailablePod(name+"-1", "leader", "node-a"),
+				buildAvailablePod(name+"-2", "follower", "node-a"),
+				buildAvailablePod(name+"-3", "follower", "node-b"),
+				buildAvailablePod(name+"-4", "follower", ""),
+			}
+
+			By("placement policy not set")
+			Expect(buildPlacementStatus(tree, its, pods)).Should(BeNil())
+
+			By("suggest the follower with the largest ordinal in the most populated domain")
+			its.Spec.PlacementPolicy = &workloads.InstancePlacementPolicy{}
+			status := buildPlacementStatus(tree, its, pods)
+			Expect(status).ShouldNot(BeNil())
+			Expect(status.TopologyKey).Should(Equal(corev1.LabelTopologyZone))
+			Expect(status.Domains).Should(Equal([]workloads.TopologyDomainStatus{
+				{Name: "zone-a", Instances: []string{name + "-0", name + "-1", name + "-2"}},
+				{Name: "zone-b", Instances: []string{name + "-3"}},
+				{Name: "zone-c", Instances: []string{}},
+			}))
+			Expect(status.UnplacedInstances).Should(Equal([]string{name + "-4"}))
+			Expect(status.Skew).Should(BeEquivalentTo(3))
+			Expect(status.Imbalanced).Should(BeTrue())
+			Expect(status.RebalanceInstance).Should(Equal(name + "-2"))
+			Expect(status.RebalanceTargetDomain).Should(Equal("zone-c"))
+
+			By("the held instances are never suggested")
+			its.Spec.HeldInstances = []workloads.HeldInstance{{Name: name + "-2"}}
+			status = buildPlacementStatus(tree, its, pods)
+			Expect(status.RebalanceInstance).Should(Equal(name + "-0"))
+			its.Spec.HeldInstances = []workloads.HeldInstance{{Name: name + "-0"}, {Name: name + "-2"}}
+			status = buildPlacementStatus(tree, its, pods)
+			Expect(status.Imbalanced).Should(BeTrue())
+			Expect(status.RebalanceInstance).Should(BeEmpty())
+			its.Spec.HeldInstances = nil
+
+			By("nothing is suggested if any instance is unavailable")
+			pods[3].Status.Conditions[0].Status = corev1.ConditionFalse
+			status = buildPlacementStatus(tree, its, pods)
+			Expect(status.Imbalanced).Should(BeTrue())
+			Expect(status.RebalanceInstance).Should(BeEmpty())
+			pods[3].Status.Conditions[0].Status = corev1.ConditionTrue
+
+			By("the domains of the unschedulable nodes are not the target")
+			node, err := tree.Get(buildNode("node-c", "", false))
+			Expect(err).Should(BeNil())
+			node.(*corev1.Node).Spec.Unschedulable = true
+			status = buildPlacementStatus(tree, its, pods)
+			Expect(status.Domains).Should(HaveLen(2))
+			Expect(status.Skew).Should(BeEquivalentTo(2))
+			Expect(status.RebalanceTargetDomain).Should(Equal("zone-b"))
+
+			By("balanced within the max skew")
+			its.Spec.PlacementPolicy.MaxSkew = 2
+			status = buildPlacementStatus(tree, its, pods)
+			Expect(status.Imbalanced).Should(BeFalse())
+			Expect(status.RebalanceInstance).Should(BeEmpty())
+		})
+	})
+})
@@ -21,6 +21,7 @@ package instanceset
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -30,7 +31,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
 )
 
-// assistantObjectReconciler manages non-workload objects, such as Service, ConfigMap, etc.
+// assistantObjectReconciler manages non-workload objects, such as Service, ConfigMap, PodDisruptionBudget, etc.
 type assistantObjectReconciler struct{}
 
 func NewAssistantObjectReconciler() kubebuilderx.Reconciler {
@@ -54,6 +55,12 @@ func (a *assistantObjectReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*k
 	labels := getMatchLabels(its.Name)
 	selectors := getSvcSelector(its, false)
 	headlessSelectors := getSvcSelector(its, true)
+	var pods []*corev1.Pod
+	for _, object := range tree.List(&corev1.Pod{}) {
+		pods = append(pods, object.(*corev1.Pod))
+	}
+	addBlueGreenSvcSelector(its, pods, selectors)
+	AddHeldInstanceSvcSelector(its, pods, selectors)
 
 	svc := rsm.BuildSvc(*its, labels, selectors)
 	altSvs := rsm.BuildAlternativeSvs(*its, labels)
@@ -67,6 +74,7 @@ func (a *assistantObjectReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*k
 		objects = append(objects, s)
 	}
 	objects = append(objects, headLessSvc, envConfig)
+	objects = append(objects, buildPodDisruptionBudgets(its, pods, labels)...)
 	for _, object := range objects {
 		if err := rsm.SetOwnership(its, object, model.GetScheme(), finalizer); err != nil {
 			return nil, err
@@ -83,12 +91,13 @@ func (a *assistantObjectReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*k
 	}
 	oldSnapshot := make(map[model.GVKNObjKey]client.Object)
 	svcList := tree.List(&corev1.Service{})
+	pdbList := tree.List(&policyv1.PodDisruptionBudget{})
 	cmList := tree.List(&corev1.ConfigMap{})
 	cmListFiltered, err := filterTemplate(cmList, its.Annotations)
 	if err != nil {
 		return nil, err
 	}
-	for _, objectList := range [][]client.Object{svcList, cmListFiltered} {
+	for _, objectList := range [][]client.Object{svcList, cmListFiltered, pdbList} {
 		for _, object := range objectList {
 			name, err := model.GetGVKName(object)
 			if err != nil {
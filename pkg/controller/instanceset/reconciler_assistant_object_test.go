@@ -23,6 +23,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
@@ -40,6 +42,7 @@ var _ = Describe("assistant object reconciler test", func() {
 			SetTemplate(template).
 			SetVolumeClaimTemplates(volumeClaimTemplates...).
 			SetRoles(roles).
+			SetProtectLeaderFromEviction(true).
 			GetObject()
 	})
 
@@ -55,12 +58,14 @@ var _ = Describe("assistant object reconciler test", func() {
 			By("do reconcile")
 			_, err := reconciler.Reconcile(tree)
 			Expect(err).Should(BeNil())
-			// desired: svc: "bar-headless", cm: "bar"
+			// desired: svc: "bar-headless", cm: "bar", pdb: "bar-leader", "bar-quorum"
 			objects := tree.GetSecondaryObjects()
-			Expect(objects).Should(HaveLen(2))
+			Expect(objects).Should(HaveLen(4))
 			svc := builder.NewHeadlessServiceBuilder(namespace, name+"-headless").GetObject()
 			cm := builder.NewConfigMapBuilder(namespace, rsm.GetEnvConfigMapName(name)).GetObject()
-			for _, object := range []client.Object{svc, cm} {
+			leaderPDB := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: getLeaderPDBName(name)}}
+			quorumPDB := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: getQuorumPDBName(name)}}
+			for _, object := range []client.Object{svc, cm, leaderPDB, quorumPDB} {
 				name, err := model.GetGVKName(object)
 				Expect(err).Should(BeNil())
 				_, ok := objects[*name]
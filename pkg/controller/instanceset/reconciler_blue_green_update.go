@@ -0,0 +1,370 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"time"
+
+	"golang.org/x/exp/slices"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// blueGreenUpdateReconciler updates the instances of the InstanceSet with the BlueGreen MemberUpdateStrategy.
+//
+// The update goes through the phases below, and the instances are never updated in place:
+//  1. Provisioning: the instances of the standby set are created on the new revision, and joined to the replication group
+//     by the MemberJoin action after they are available.
+//  2. Switching: the leader role is moved to the standby set by the Switchover action, and the standby set becomes active.
+//  3. Retaining: the old instances are kept for the rollback period, the update is rolled back if the spec is changed back
+//     during the period. The old instances are torn down after the period, with the MemberLeave action.
+type blueGreenUpdateReconciler struct{}
+
+var _ kubebuilderx.Reconciler = &blueGreenUpdateReconciler{}
+
+const (
+	blueGreenUpdateReason = "BlueGreenUpdate"
+	defaultRollbackPeriod = time.Hour
+)
+
+func NewBlueGreenUpdateReconciler() kubebuilderx.Reconciler {
+	return &blueGreenUpdateReconciler{}
+}
+
+func (r *blueGreenUpdateReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	if model.IsReconciliationPaused(tree.GetRoot()) {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	if !isBlueGreenUpdate(its) {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	if err := validateSpec(its, tree); err != nil {
+		return kubebuilderx.CheckResultWithError(err)
+	}
+	return kubebuilderx.ResultSatisfied
+}
+
+func (r *blueGreenUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilderx.ObjectTree, error) {
+	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	// the update revisions are not computed for the latest spec yet.
+	if its.Status.ObservedGeneration != its.Generation {
+		return tree, nil
+	}
+	if its.Status.BlueGreen == nil {
+		its.Status.BlueGreen = &workloads.BlueGreenUpdateStatus{ActiveSet: workloads.BlueInstanceColor}
+	}
+	itsExt, err := buildInstanceSetExt(its, tree)
+	if err != nil {
+		return nil, err
+	}
+	nameToTemplateMap, err := buildInstanceName2TemplateMap(itsExt)
+	if err != nil {
+		return nil, err
+	}
+
+	var activePods, standbyPods []*corev1.Pod
+	for _, object := range tree.List(&corev1.Pod{}) {
+		pod, _ := object.(*corev1.Pod)
+		if pod.Labels[blueGreenSetLabelKey] == string(getStandbySet(its)) {
+			standbyPods = append(standbyPods, pod)
+		} else if _, ok := nameToTemplateMap[pod.Name]; ok {
+			activePods = append(activePods, pod)
+		}
+	}
+	sortPodsByName(activePods)
+	sortPodsByName(standbyPods)
+
+	switch its.Status.BlueGreen.Phase {
+	case workloads.BlueGreenProvisioningPhase:
+		return r.provision(tree, its, itsExt, activePods, standbyPods)
+	case workloads.BlueGreenSwitchingPhase:
+		return r.switchover(tree, its, activePods, standbyPods)
+	case workloads.BlueGreenRetainingPhase:
+		return r.retain(tree, its, activePods, standbyPods)
+	default:
+		return r.idle(tree, its, len(nameToTemplateMap), activePods)
+	}
+}
+
+// idle starts the blue/green update if any active instance is not updated.
+func (r *blueGreenUpdateReconciler) idle(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet,
+	replicas int, activePods []*corev1.Pod) (*kubebuilderx.ObjectTree, error) {
+	// label the instances created before the BlueGreen MemberUpdateStrategy is enabled.
+	for _, pod := range activePods {
+		if pod.Labels[blueGreenSetLabelKey] == string(getActiveSet(its)) {
+			continue
+		}
+		newPod := pod.DeepCopy()
+		if newPod.Labels == nil {
+			newPod.Labels = map[string]string{}
+		}
+		newPod.Labels[blueGreenSetLabelKey] = string(getActiveSet(its))
+		if err := tree.Update(newPod); err != nil {
+			return nil, err
+		}
+	}
+	// the instances are being aligned with the replicas.
+	if len(activePods) != replicas {
+		return tree, nil
+	}
+	updated, err := isAllPodsUpdated(its, activePods)
+	if err != nil || updated {
+		return tree, err
+	}
+	its.Status.BlueGreen.Phase = workloads.BlueGreenProvisioningPhase
+	tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, blueGreenUpdateReason,
+		"start provisioning the %s instances on revision %s", getStandbySet(its), its.Status.UpdateRevision)
+	return tree, nil
+}
+
+// provision brings up the instances of the standby set on the update revision, and joins them to the replication group.
+func (r *blueGreenUpdateReconciler) provision(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, itsExt *instanceSetExt,
+	activePods, standbyPods []*corev1.Pod) (*kubebuilderx.ObjectTree, error) {
+	nameToTemplateMap, err := buildInstanceName2TemplateMapOf(itsExt, getStandbySet(its))
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. tear down the standby instances which are not expected any more, e.g. the spec is changed again during the update.
+	existing := make(map[string]*corev1.Pod)
+	deleting := false
+	for _, pod := range standbyPods {
+		template, ok := nameToTemplateMap[pod.Name]
+		if ok {
+			revision, err := BuildInstanceTemplateRevision(&template.PodTemplateSpec, its)
+			if err != nil {
+				return nil, err
+			}
+			ok = getPodRevision(pod) == revision
+		}
+		if !ok || isTerminating(pod) {
+			if err := deleteInstance(tree, pod); err != nil {
+				return nil, err
+			}
+			deleting = true
+			continue
+		}
+		existing[pod.Name] = pod
+	}
+	// the instances are recreated after the old ones are gone, as the volumes have the same names.
+	if deleting {
+		return tree, nil
+	}
+
+	// 2. create the standby instances.
+	var pods []*corev1.Pod
+	for name, template := range nameToTemplateMap {
+		if pod, ok := existing[name]; ok {
+			pods = append(pods, pod)
+			continue
+		}
+		inst, err := buildInstanceByTemplate(name, template, its, "")
+		if err != nil {
+			return nil, err
+		}
+		inst.pod.Labels[blueGreenSetLabelKey] = string(getStandbySet(its))
+		setHeldInstanceLabel(its, inst.pod)
+		if err = tree.Add(inst.pod); err != nil {
+			return nil, err
+		}
+		for _, pvc := range inst.pvcs {
+			if oldPvc, err := tree.Get(pvc); err != nil || oldPvc != nil {
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err = tree.Add(pvc); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(pods) != len(nameToTemplateMap) {
+		return tree, nil
+	}
+	sortPodsByName(pods)
+
+	// 3. join the standby instances to the replication group after they are available.
+	joined, err := joinMembers(tree, its, blueGreenActionLabelKey, activePods, pods)
+	if err != nil || !joined {
+		return tree, err
+	}
+
+	its.Status.BlueGreen.Phase = workloads.BlueGreenSwitchingPhase
+	tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, blueGreenUpdateReason,
+		"the %s instances are ready, start switching to them", getStandbySet(its))
+	return tree, nil
+}
+
+// switchover moves the leader role to the standby set, and makes the standby set active.
+// The standby set becomes active directly if the InstanceSet has no leader role or no Switchover action.
+func (r *blueGreenUpdateReconciler) switchover(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet,
+	activePods, standbyPods []*corev1.Pod) (*kubebuilderx.ObjectTree, error) {
+	reconfiguration := its.Spec.MembershipReconfiguration
+	if hasLeaderRole(its) && reconfiguration != nil && reconfiguration.SwitchoverAction != nil && getLeaderPod(its, standbyPods) == nil {
+		leader := getLeaderPod(its, activePods)
+		if leader == nil {
+			return tree, nil
+		}
+		var candidates []*corev1.Pod
+		for _, pod := range standbyPods {
+			if IsLeaderCandidate(its, pod) {
+				candidates = append(candidates, pod)
+			}
+		}
+		if len(candidates) == 0 {
+			return tree, nil
+		}
+		// the leader role is moved to the standby instance with the same ordinal if possible.
+		target := candidates[0]
+		name := getInstanceNameOf(its, leader.Name, getStandbySet(its))
+		if index := slices.IndexFunc(candidates, func(pod *corev1.Pod) bool { return pod.Name == name }); index >= 0 {
+			target = candidates[index]
+		}
+		done, err := runMembershipActions(tree, its, blueGreenActionLabelKey, rsm.SwitchoverActionType, leader, []*corev1.Pod{target})
+		if err != nil || !done {
+			return tree, err
+		}
+		// wait for the role labels to be updated.
+		return tree, nil
+	}
+
+	its.Status.BlueGreen.ActiveSet = getStandbySet(its)
+	its.Status.BlueGreen.Phase = workloads.BlueGreenRetainingPhase
+	its.Status.BlueGreen.SwitchTime = &metav1.Time{Time: time.Now()}
+	// the instances are named after the new active set.
+	if err := buildUpdateRevisions4Status(its, tree); err != nil {
+		return nil, err
+	}
+	tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, blueGreenUpdateReason,
+		"switched to the %s instances, the %s instances are retained for rollback", getActiveSet(its), getStandbySet(its))
+	return tree, nil
+}
+
+// retain keeps the old instances for the rollback period, and tears them down after the period.
+// If the active instances are not updated, which means the spec is changed during the period, e.g. the spec is reverted to
+// roll back the update, the standby set is provisioned again and switched to.
+func (r *blueGreenUpdateReconciler) retain(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet,
+	activePods, standbyPods []*corev1.Pod) (*kubebuilderx.ObjectTree, error) {
+	updated, err := isAllPodsUpdated(its, activePods)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		its.Status.BlueGreen.Phase = workloads.BlueGreenProvisioningPhase
+		its.Status.BlueGreen.SwitchTime = nil
+		tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, blueGreenUpdateReason,
+			"the spec is changed, start provisioning the %s instances on revision %s", getStandbySet(its), its.Status.UpdateRevision)
+		return tree, nil
+	}
+
+	rollbackPeriod := defaultRollbackPeriod
+	if its.Spec.BlueGreenUpdate != nil && its.Spec.BlueGreenUpdate.RollbackPeriod != nil {
+		rollbackPeriod = its.Spec.BlueGreenUpdate.RollbackPeriod.Duration
+	}
+	if switchTime := its.Status.BlueGreen.SwitchTime; switchTime != nil {
+		if remaining := time.Until(switchTime.Add(rollbackPeriod)); remaining > 0 {
+			return tree, intctrlutil.NewDelayedRequeueError(remaining, "wait for the rollback period of the blue/green update")
+		}
+	}
+
+	// remove the old instances from the replication group before tearing them down.
+	var pods []*corev1.Pod
+	for _, pod := range standbyPods {
+		if !isTerminating(pod) {
+			pods = append(pods, pod)
+		}
+	}
+	if reconfiguration := its.Spec.MembershipReconfiguration; len(pods) > 0 && reconfiguration != nil && reconfiguration.MemberLeaveAction != nil {
+		done, err := runMembershipActions(tree, its, blueGreenActionLabelKey, rsm.MemberLeaveActionType, getLeaderPod(its, activePods), pods)
+		if err != nil || !done {
+			return tree, err
+		}
+	}
+	for _, pod := range standbyPods {
+		if err = deleteInstance(tree, pod); err != nil {
+			return nil, err
+		}
+	}
+	// wait for the old instances to be gone.
+	if len(standbyPods) > 0 {
+		return tree, nil
+	}
+
+	for _, object := range tree.List(&batchv1.Job{}) {
+		if _, ok := object.GetLabels()[blueGreenActionLabelKey]; !ok {
+			continue
+		}
+		if err = tree.Delete(object); err != nil {
+			return nil, err
+		}
+	}
+	its.Status.BlueGreen.Phase = ""
+	its.Status.BlueGreen.SwitchTime = nil
+	tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, blueGreenUpdateReason,
+		"the %s instances are torn down, the blue/green update is completed", getStandbySet(its))
+	return tree, nil
+}
+
+func isAllPodsUpdated(its *workloads.InstanceSet, pods []*corev1.Pod) (bool, error) {
+	for _, pod := range pods {
+		updated, err := IsPodUpdated(its, pod)
+		if err != nil || !updated {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func sortPodsByName(pods []*corev1.Pod) {
+	slices.SortFunc(pods, func(a, b *corev1.Pod) bool {
+		return a.Name < b.Name
+	})
+}
+
+// addBlueGreenSvcSelector makes the Service select the active instances of the blue/green update.
+// The Service selects the leader if the InstanceSet has a leader role, which is moved to the active set by the switchover,
+// and the Service doesn't select the instances by the set until all of them are labeled.
+func addBlueGreenSvcSelector(its *workloads.InstanceSet, pods []*corev1.Pod, selectors map[string]string) {
+	if !isBlueGreenUpdate(its) || len(pods) == 0 {
+		return
+	}
+	if _, ok := selectors[constant.RoleLabelKey]; ok {
+		return
+	}
+	for _, pod := range pods {
+		if _, ok := pod.Labels[blueGreenSetLabelKey]; !ok {
+			return
+		}
+	}
+	selectors[blueGreenSetLabelKey] = string(getActiveSet(its))
+}
@@ -0,0 +1,203 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("blue green update reconciler test", func() {
+	BeforeEach(func() {
+		strategy := workloads.BlueGreenUpdateStrategy
+		action := &workloads.Action{Image: "foo", Command: []string{"bar"}}
+		its = builder.NewInstanceSetBuilder(namespace, name).
+			SetUID(uid).
+			SetReplicas(3).
+			AddMatchLabelsInMap(selectors).
+			SetTemplate(template).
+			SetVolumeClaimTemplates(volumeClaimTemplates...).
+			SetRoles(roles).
+			SetPodManagementPolicy(appsv1.ParallelPodManagement).
+			SetMemberUpdateStrategy(&strategy).
+			SetMembershipReconfiguration(&workloads.MembershipReconfiguration{
+				SwitchoverAction:  action,
+				MemberJoinAction:  action,
+				MemberLeaveAction: action,
+			}).
+			GetObject()
+	})
+
+	Context("PreCondition & Reconcile", func() {
+		It("should work well", func() {
+			its.Generation = 1
+			tree := kubebuilderx.NewObjectTree()
+			tree.SetRoot(its)
+			tree.EventRecorder = record.NewFakeRecorder(100)
+
+			By("PreCondition")
+			reconciler = NewBlueGreenUpdateReconciler()
+			Expect(reconciler.PreCondition(tree)).Should(Equal(kubebuilderx.ResultSatisfied))
+
+			reconcile := func(reconcilers ...kubebuilderx.Reconciler) {
+				var err error
+				for _, r := range reconcilers {
+					tree, err = r.Reconcile(tree)
+					Expect(err).Should(BeNil())
+				}
+			}
+			getPod := func(name string) *corev1.Pod {
+				object, err := tree.Get(builder.NewPodBuilder(namespace, name).GetObject())
+				Expect(err).Should(BeNil())
+				if object == nil {
+					return nil
+				}
+				return object.(*corev1.Pod)
+			}
+			makePodsAvailable := func(names ...string) {
+				for _, name := range names {
+					pod := getPod(name)
+					Expect(pod).ShouldNot(BeNil())
+					pod.Status.Phase = corev1.PodRunning
+					pod.Status.Conditions = []corev1.PodCondition{{
+						Type:               corev1.PodReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+					}}
+				}
+			}
+			succeedJobs := func(actionType string, count int) {
+				var jobs []*batchv1.Job
+				for _, object := range tree.List(&batchv1.Job{}) {
+					if object.GetLabels()[blueGreenActionLabelKey] == actionType {
+						jobs = append(jobs, object.(*batchv1.Job))
+					}
+				}
+				Expect(jobs).Should(HaveLen(count))
+				for _, job := range jobs {
+					job.Status.Succeeded = 1
+				}
+			}
+			blueGreen := func() *workloads.BlueGreenUpdateStatus {
+				return tree.GetRoot().(*workloads.InstanceSet).Status.BlueGreen
+			}
+
+			By("create the blue instances")
+			reconciler = NewBlueGreenUpdateReconciler()
+			reconcile(NewFixMetaReconciler(), NewRevisionUpdateReconciler(), NewReplicasAlignmentReconciler())
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(3))
+			Expect(getPod("bar-0").Labels).Should(HaveKeyWithValue(blueGreenSetLabelKey, string(workloads.BlueInstanceColor)))
+			makePodsAvailable("bar-0", "bar-1", "bar-2")
+			getPod("bar-0").Labels[constant.RoleLabelKey] = "leader"
+			reconcile(reconciler)
+			Expect(blueGreen().ActiveSet).Should(Equal(workloads.BlueInstanceColor))
+			Expect(blueGreen().Phase).Should(BeEmpty())
+
+			By("update the spec")
+			its.Generation = 2
+			its.Spec.Template.Spec.Containers[0].Image = "bar:latest"
+			reconcile(NewRevisionUpdateReconciler(), reconciler)
+			Expect(blueGreen().Phase).Should(Equal(workloads.BlueGreenProvisioningPhase))
+
+			By("provision the green instances")
+			reconcile(reconciler, NewReplicasAlignmentReconciler())
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(6))
+			Expect(getPod("bar-green-0").Labels).Should(HaveKeyWithValue(blueGreenSetLabelKey, string(workloads.GreenInstanceColor)))
+			pvc, err := tree.Get(builder.NewPVCBuilder(namespace, "data-bar-green-0").GetObject())
+			Expect(err).Should(BeNil())
+			Expect(pvc).ShouldNot(BeNil())
+			makePodsAvailable("bar-green-0", "bar-green-1", "bar-green-2")
+			reconcile(reconciler)
+			succeedJobs(rsm.MemberJoinActionType, 3)
+			reconcile(reconciler)
+			Expect(blueGreen().Phase).Should(Equal(workloads.BlueGreenSwitchingPhase))
+
+			By("switch to the green instances")
+			reconcile(reconciler)
+			succeedJobs(rsm.SwitchoverActionType, 1)
+			Expect(tree.Get(builder.NewJobBuilder(namespace, "bar-green-0-2-switchover").GetObject())).ShouldNot(BeNil())
+			reconcile(reconciler)
+			Expect(blueGreen().Phase).Should(Equal(workloads.BlueGreenSwitchingPhase))
+			delete(getPod("bar-0").Labels, constant.RoleLabelKey)
+			getPod("bar-green-0").Labels[constant.RoleLabelKey] = "leader"
+			reconcile(reconciler)
+			Expect(blueGreen().ActiveSet).Should(Equal(workloads.GreenInstanceColor))
+			Expect(blueGreen().Phase).Should(Equal(workloads.BlueGreenRetainingPhase))
+			updateRevisions, err := getUpdateRevisions(its.Status.UpdateRevisions)
+			Expect(err).Should(BeNil())
+			Expect(updateRevisions).Should(HaveKey("bar-green-0"))
+			reconcile(NewReplicasAlignmentReconciler())
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(6))
+
+			By("retain the blue instances for the rollback period")
+			tree, err = reconciler.Reconcile(tree)
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+
+			By("tear down the blue instances")
+			blueGreen().SwitchTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			reconcile(reconciler)
+			succeedJobs(rsm.MemberLeaveActionType, 3)
+			reconcile(reconciler)
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(3))
+			Expect(getPod("bar-0")).Should(BeNil())
+			pvc, err = tree.Get(builder.NewPVCBuilder(namespace, "data-bar-0").GetObject())
+			Expect(err).Should(BeNil())
+			Expect(pvc).Should(BeNil())
+			reconcile(reconciler)
+			Expect(blueGreen().Phase).Should(BeEmpty())
+			Expect(tree.List(&batchv1.Job{})).Should(BeEmpty())
+
+			By("take the instance offline by the name of the blue set")
+			Expect(GetActiveInstanceName(its, "bar-1")).Should(Equal("bar-green-1"))
+			Expect(GetActiveInstanceName(its, "bar-green-1")).Should(Equal("bar-green-1"))
+			its.Generation = 3
+			its.Spec.Replicas = func() *int32 { r := int32(2); return &r }()
+			its.Spec.OfflineInstances = []string{"bar-1"}
+			reconcile(NewRevisionUpdateReconciler(), NewReplicasAlignmentReconciler())
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(2))
+			Expect(getPod("bar-green-1")).Should(BeNil())
+			Expect(getPod("bar-green-2")).ShouldNot(BeNil())
+		})
+	})
+
+	Context("validate the spec", func() {
+		It("reserves the instance template names of the green set", func() {
+			its.Spec.Instances = []workloads.InstanceTemplate{{Name: "green-zone"}}
+			tree := kubebuilderx.NewObjectTree()
+			tree.SetRoot(its)
+			Expect(validateSpec(its, tree)).ShouldNot(Succeed())
+		})
+	})
+})
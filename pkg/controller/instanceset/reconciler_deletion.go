@@ -45,7 +45,14 @@ func (r *deletionReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuil
 	// retain all pvcs
 	// TODO(free6om): respect PVCManagementPolicy
 	allObjects := tree.GetSecondaryObjects()
-	objects := filterByType[*corev1.PersistentVolumeClaim](allObjects)
+	var objects []client.Object
+	for _, object := range filterByType[*corev1.PersistentVolumeClaim](allObjects) {
+		// the nodes are loaded to resolve the topology domains only
+		if _, ok := object.(*corev1.Node); ok {
+			continue
+		}
+		objects = append(objects, object)
+	}
 	if len(objects) > 0 {
 		return tree, tree.Delete(objects...)
 	}
@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package instanceset
 
 import (
+	"reflect"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
@@ -74,10 +77,16 @@ func (r *instanceAlignmentReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (
 	}
 	oldNameSet := sets.NewString()
 	oldInstanceMap := make(map[string]*corev1.Pod)
-	oldInstanceList := tree.List(&corev1.Pod{})
-	for _, object := range oldInstanceList {
-		oldNameSet.Insert(object.GetName())
+	var oldInstanceList []client.Object
+	for _, object := range tree.List(&corev1.Pod{}) {
 		pod, _ := object.(*corev1.Pod)
+		// the standby instances of the blue/green update are managed by the blueGreenUpdateReconciler,
+		// and the surge instances are managed by the updateReconciler.
+		if isStandbyInstance(its, pod) || isSurgeInstance(pod) {
+			continue
+		}
+		oldInstanceList = append(oldInstanceList, object)
+		oldNameSet.Insert(object.GetName())
 		oldInstanceMap[object.GetName()] = pod
 	}
 	createNameSet := newNameSet.Difference(oldNameSet)
@@ -120,6 +129,10 @@ func (r *instanceAlignmentReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (
 		if err != nil {
 			return nil, err
 		}
+		if isBlueGreenUpdate(its) {
+			inst.pod.Labels[blueGreenSetLabelKey] = string(getActiveSet(its))
+		}
+		setHeldInstanceLabel(its, inst.pod)
 		if err := tree.Add(inst.pod); err != nil {
 			return nil, err
 		}
@@ -168,6 +181,25 @@ func (r *instanceAlignmentReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (
 		deleteCount--
 	}
 
+	// 4. label the held instances, which are excluded from the Services selecting the instances by roles.
+	for _, object := range oldInstanceList {
+		if current, err := tree.Get(object); err != nil || current == nil {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		pod, _ := object.(*corev1.Pod)
+		newPod := pod.DeepCopy()
+		setHeldInstanceLabel(its, newPod)
+		if reflect.DeepEqual(newPod.Labels, pod.Labels) {
+			continue
+		}
+		if err := tree.Update(newPod); err != nil {
+			return nil, err
+		}
+	}
+
 	return tree, nil
 }
 
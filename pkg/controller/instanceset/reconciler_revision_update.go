@@ -50,9 +50,22 @@ func (r *revisionUpdateReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *
 
 func (r *revisionUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilderx.ObjectTree, error) {
 	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	if err := buildUpdateRevisions4Status(its, tree); err != nil {
+		return nil, err
+	}
+	// The 'ObservedGeneration' field is used to indicate whether the revisions have been updated.
+	// Computing these revisions in each reconciliation loop can be time-consuming, so we optimize it by
+	// performing the computation only when the 'spec' is updated.
+	its.Status.ObservedGeneration = its.Generation
+
+	return tree, nil
+}
+
+// buildUpdateRevisions4Status computes the expected instance names and their corresponding revisions, and persists them to the status.
+func buildUpdateRevisions4Status(its *workloads.InstanceSet, tree *kubebuilderx.ObjectTree) error {
 	itsExt, err := buildInstanceSetExt(its, tree)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// 1. build all templates by applying instance template overrides to default pod template
@@ -61,10 +74,10 @@ func (r *revisionUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*ku
 	// build instance revision list from instance templates
 	var instanceRevisionList []instanceRevision
 	for _, template := range instanceTemplateList {
-		instanceNames := GenerateInstanceNamesFromTemplate(its.Name, template.Name, template.Replicas, itsExt.its.Spec.OfflineInstances)
+		instanceNames := GenerateInstanceNamesFromTemplate(GetInstanceNamePrefix(its), template.Name, template.Replicas, getOfflineInstancesOf(its, getActiveSet(its)))
 		revision, err := BuildInstanceTemplateRevision(&template.PodTemplateSpec, its)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, name := range instanceNames {
 			instanceRevisionList = append(instanceRevisionList, instanceRevision{name: name, revision: revision})
@@ -75,7 +88,7 @@ func (r *revisionUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*ku
 		return r.name
 	}
 	if err := ValidateDupInstanceNames(instanceRevisionList, getNameFunc); err != nil {
-		return nil, err
+		return err
 	}
 
 	updatedRevisions := make(map[string]string, len(instanceRevisionList))
@@ -86,7 +99,7 @@ func (r *revisionUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*ku
 	// 3. persistent these revisions to status
 	revisions, err := buildUpdateRevisions(updatedRevisions)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	its.Status.UpdateRevisions = revisions
 	updateRevision := ""
@@ -94,12 +107,7 @@ func (r *revisionUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*ku
 		updateRevision = instanceRevisionList[len(instanceRevisionList)-1].revision
 	}
 	its.Status.UpdateRevision = updateRevision
-	// The 'ObservedGeneration' field is used to indicate whether the revisions have been updated.
-	// Computing these revisions in each reconciliation loop can be time-consuming, so we optimize it by
-	// performing the computation only when the 'spec' is updated.
-	its.Status.ObservedGeneration = its.Generation
-
-	return tree, nil
+	return nil
 }
 
 var _ kubebuilderx.Reconciler = &revisionUpdateReconciler{}
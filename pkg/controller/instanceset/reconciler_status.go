@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package instanceset
 
 import (
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
@@ -51,6 +52,10 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 	var podList []corev1.Pod
 	for _, object := range pods {
 		pod, _ := object.(*corev1.Pod)
+		// the standby instances of the blue/green update and the surge instances are not counted.
+		if isStandbyInstance(its, pod) || isSurgeInstance(pod) {
+			continue
+		}
 		podList = append(podList, *pod)
 	}
 	// 2. calculate status summary
@@ -102,7 +107,24 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 		its.Status.CurrentReplicas = totalReplicas
 	}
 
-	// 3. set members status
+	// 3. list the held instances
+	var heldInstances []string
+	for i := range podList {
+		if isHeldInstance(its, &podList[i]) {
+			heldInstances = append(heldInstances, podList[i].Name)
+		}
+	}
+	slices.Sort(heldInstances)
+	its.Status.HeldInstances = heldInstances
+
+	// 4. build the placement status across the topology domains
+	var placementPods []*corev1.Pod
+	for i := range podList {
+		placementPods = append(placementPods, &podList[i])
+	}
+	its.Status.Placement = buildPlacementStatus(tree, its, placementPods)
+
+	// 5. set members status
 	rsm.SetMembersStatus(its, &podList)
 
 	return tree, nil
@@ -149,6 +149,13 @@ var _ = Describe("status reconciler test", func() {
 			Expect(its.Status.CurrentReplicas).Should(BeEquivalentTo(replicas))
 			Expect(its.Status.CurrentRevisions).Should(Equal(its.Status.UpdateRevisions))
 			Expect(its.Status.CurrentGeneration).Should(BeEquivalentTo(its.Generation))
+			Expect(its.Status.HeldInstances).Should(BeEmpty())
+
+			By("hold an instance")
+			its.Spec.HeldInstances = []workloads.HeldInstance{{Name: pods[0].GetName()}, {Name: "not-exist"}}
+			_, err = reconciler.Reconcile(newTree)
+			Expect(err).Should(BeNil())
+			Expect(its.Status.HeldInstances).Should(Equal([]string{pods[0].GetName()}))
 		})
 	})
 })
@@ -21,13 +21,17 @@ package instanceset
 
 import (
 	"fmt"
+	"strings"
 
+	"golang.org/x/exp/slices"
 	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
@@ -35,6 +39,8 @@ import (
 
 // updateReconciler handles the updates of instances based on the UpdateStrategy.
 // Currently, two update strategies are supported: 'OnDelete' and 'RollingUpdate'.
+// With 'RollingUpdate', up to spec.maxSurge extra instances are created and joined before the old instances are taken down.
+// The instances of the InstanceSet with the BlueGreen MemberUpdateStrategy are updated by the blueGreenUpdateReconciler instead.
 type updateReconciler struct{}
 
 var _ kubebuilderx.Reconciler = &updateReconciler{}
@@ -51,6 +57,10 @@ func (r *updateReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuil
 		return kubebuilderx.ResultUnsatisfied
 	}
 	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	// the instances are updated by the blueGreenUpdateReconciler.
+	if isBlueGreenUpdate(its) {
+		return kubebuilderx.ResultUnsatisfied
+	}
 	if err := validateSpec(its, tree); err != nil {
 		return kubebuilderx.CheckResultWithError(err)
 	}
@@ -77,10 +87,14 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 	}
 	oldNameSet := sets.NewString()
 	oldInstanceMap := make(map[string]*corev1.Pod)
-	var oldPodList []*corev1.Pod
+	var oldPodList, surgePodList []*corev1.Pod
 	for _, object := range tree.List(&corev1.Pod{}) {
-		oldNameSet.Insert(object.GetName())
 		pod, _ := object.(*corev1.Pod)
+		if isSurgeInstance(pod) {
+			surgePodList = append(surgePodList, pod)
+			continue
+		}
+		oldNameSet.Insert(object.GetName())
 		oldInstanceMap[object.GetName()] = pod
 		oldPodList = append(oldPodList, pod)
 	}
@@ -101,18 +115,33 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 	if err != nil {
 		return nil, err
 	}
-	currentUnavailable := 0
+	// the held instances are left alone, they are neither updated nor counted in the unavailable ones.
+	var podsToUpdate []*corev1.Pod
 	for _, pod := range oldPodList {
+		if !isHeldInstance(its, pod) {
+			podsToUpdate = append(podsToUpdate, pod)
+		}
+	}
+	currentUnavailable := 0
+	for _, pod := range podsToUpdate {
 		if !isHealthy(pod) {
 			currentUnavailable++
 		}
 	}
 	unavailable := maxUnavailable - currentUnavailable
 
+	// the surge instances are ready before any old instance is taken down.
+	priorities := rsm.ComposeRolePriorityMap(its.Spec.Roles)
+	sortObjects(podsToUpdate, priorities, false)
+	surgeReady, err := reconcileSurgeInstances(tree, its, itsExt, podsToUpdate, surgePodList, partition)
+	if err != nil || !surgeReady {
+		return tree, err
+	}
+
 	// TODO(free6om): compute updateCount from PodManagementPolicy(Serial/OrderedReady, Parallel, BestEffortParallel).
 	// align MemberUpdateStrategy with PodManagementPolicy if it has nil value.
 	itsForPlan := getInstanceSetForUpdatePlan(its)
-	plan := rsm.NewUpdatePlan(*itsForPlan, oldPodList, IsPodUpdated)
+	plan := rsm.NewUpdatePlan(*itsForPlan, podsToUpdate, IsPodUpdated)
 	podsToBeUpdated, err := plan.Execute()
 	if err != nil {
 		return nil, err
@@ -121,9 +150,7 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 
 	updatingPods := 0
 	updatedPods := 0
-	priorities := rsm.ComposeRolePriorityMap(its.Spec.Roles)
-	sortObjects(oldPodList, priorities, false)
-	for _, pod := range oldPodList {
+	for _, pod := range filterPodsInUpdatePartition(its, podsToUpdate) {
 		if updatingPods >= updateCount || updatingPods >= unavailable {
 			break
 		}
@@ -166,6 +193,204 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 	return tree, nil
 }
 
+// reconcileSurgeInstances creates the surge instances on the update revision and joins them to the replication group
+// before the old instances are taken down, and tears them down once they are not needed. It tells whether the old
+// instances can be updated, the pods are expected to be sorted in the update order.
+func reconcileSurgeInstances(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, itsExt *instanceSetExt,
+	pods, surgePods []*corev1.Pod, partition int) (bool, error) {
+	maxSurge, err := parseMaxSurge(its.Spec.MaxSurge, len(pods))
+	if err != nil {
+		return false, err
+	}
+	var template *instanceTemplateExt
+	revision := ""
+	if maxSurge > 0 {
+		template = getSurgeTemplate(itsExt)
+	}
+	pending := 0
+	if template != nil {
+		if revision, err = BuildInstanceTemplateRevision(&template.PodTemplateSpec, its); err != nil {
+			return false, err
+		}
+		for i, pod := range filterPodsInUpdatePartition(its, pods) {
+			if i >= partition {
+				break
+			}
+			updated, err := IsPodUpdated(its, pod)
+			if err != nil {
+				return false, err
+			}
+			if !updated {
+				pending++
+			}
+		}
+	}
+	desiredNames := sets.New[string]()
+	for i := 0; i < min(maxSurge, pending); i++ {
+		desiredNames.Insert(fmt.Sprintf("%s-surge-%d", its.Name, i))
+	}
+
+	// 1. tear down the surge instances which are not needed any more, or not on the update revision.
+	existing := make(map[string]*corev1.Pod)
+	var obsoletePods []*corev1.Pod
+	for _, pod := range surgePods {
+		if !desiredNames.Has(pod.Name) || getPodRevision(pod) != revision || isTerminating(pod) {
+			obsoletePods = append(obsoletePods, pod)
+			continue
+		}
+		existing[pod.Name] = pod
+	}
+	if len(obsoletePods) > 0 {
+		sortPodsByName(obsoletePods)
+		return false, tearDownSurgeInstances(tree, its, pods, obsoletePods)
+	}
+	if len(desiredNames) == 0 {
+		for _, object := range tree.List(&batchv1.Job{}) {
+			if _, ok := object.GetLabels()[surgeActionLabelKey]; !ok {
+				continue
+			}
+			if err = tree.Delete(object); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	// 2. create the surge instances, the volumes of the torn down ones may be deleted after the pods are created.
+	var newPods []*corev1.Pod
+	for _, name := range sets.List(desiredNames) {
+		inst, err := buildInstanceByTemplate(name, template, its, revision)
+		if err != nil {
+			return false, err
+		}
+		if pod, ok := existing[name]; ok {
+			newPods = append(newPods, pod)
+		} else {
+			inst.pod.Labels[surgeInstanceLabelKey] = "true"
+			setHeldInstanceLabel(its, inst.pod)
+			if err = tree.Add(inst.pod); err != nil {
+				return false, err
+			}
+		}
+		for _, pvc := range inst.pvcs {
+			oldPvc, err := tree.Get(pvc)
+			if err != nil {
+				return false, err
+			}
+			if oldPvc == nil {
+				if err = tree.Add(pvc); err != nil {
+					return false, err
+				}
+			}
+		}
+	}
+	if len(newPods) != len(desiredNames) {
+		return false, nil
+	}
+
+	// 3. join the surge instances to the replication group after they are available.
+	return joinMembers(tree, its, surgeActionLabelKey, pods, newPods)
+}
+
+// tearDownSurgeInstances removes the surge instances from the replication group by the MemberLeave action and deletes them.
+// The leader role is moved to the other instances by the Switchover action first if a surge instance is the leader.
+func tearDownSurgeInstances(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pods, surgePods []*corev1.Pod) error {
+	var alivePods []*corev1.Pod
+	for _, pod := range surgePods {
+		if !isTerminating(pod) {
+			alivePods = append(alivePods, pod)
+		}
+	}
+	reconfiguration := its.Spec.MembershipReconfiguration
+	if leader := getLeaderPod(its, alivePods); leader != nil && reconfiguration != nil && reconfiguration.SwitchoverAction != nil {
+		index := slices.IndexFunc(pods, func(pod *corev1.Pod) bool {
+			return isHealthy(pod) && IsLeaderCandidate(its, pod)
+		})
+		if index < 0 {
+			return nil
+		}
+		// wait for the role labels to be updated after the switchover.
+		_, err := runMembershipActions(tree, its, surgeActionLabelKey, rsm.SwitchoverActionType, leader, []*corev1.Pod{pods[index]})
+		return err
+	}
+	if len(alivePods) > 0 && reconfiguration != nil && reconfiguration.MemberLeaveAction != nil {
+		done, err := runMembershipActions(tree, its, surgeActionLabelKey, rsm.MemberLeaveActionType, getLeaderPod(its, pods), alivePods)
+		if err != nil || !done {
+			return err
+		}
+	}
+	for _, pod := range surgePods {
+		if err := deleteInstance(tree, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSurgeTemplate returns the template of the surge instances, which is the default instance template if it has replicas.
+func getSurgeTemplate(itsExt *instanceSetExt) *instanceTemplateExt {
+	templates := buildInstanceTemplateExts(itsExt)
+	if len(templates) == 0 {
+		return nil
+	}
+	for _, template := range templates {
+		if len(template.Name) == 0 {
+			return template
+		}
+	}
+	return templates[0]
+}
+
+func parseMaxSurge(maxSurge *intstr.IntOrString, replicas int) (int, error) {
+	if maxSurge == nil {
+		return 0, nil
+	}
+	return intstr.GetScaledValueFromIntOrPercent(maxSurge, replicas, true)
+}
+
+// filterPodsInUpdatePartition filters the Pods which can be updated according to the spec.updatePartition,
+// the Pods are expected to be sorted in the update order.
+func filterPodsInUpdatePartition(its *workloads.InstanceSet, pods []*corev1.Pod) []*corev1.Pod {
+	partition := its.Spec.UpdatePartition
+	if partition == nil {
+		return pods
+	}
+	var filtered []*corev1.Pod
+	for _, pod := range pods {
+		if partition.Replicas != nil && len(filtered) >= int(*partition.Replicas) {
+			break
+		}
+		if len(partition.Roles) > 0 && !slices.ContainsFunc(partition.Roles, func(role string) bool {
+			return strings.EqualFold(role, pod.Labels[constant.RoleLabelKey])
+		}) {
+			continue
+		}
+		filtered = append(filtered, pod)
+	}
+	return filtered
+}
+
+// IsUpdatePartitionReady checks if all the Pods in the spec.updatePartition have been updated to the latest revision
+// and are healthy.
+func IsUpdatePartitionReady(its *workloads.InstanceSet, pods []*corev1.Pod) (bool, error) {
+	// the update revisions are not computed for the latest spec yet.
+	if its.Status.ObservedGeneration != its.Generation {
+		return false, nil
+	}
+	sortedPods := slices.Clone(pods)
+	sortObjects(sortedPods, rsm.ComposeRolePriorityMap(its.Spec.Roles), false)
+	for _, pod := range filterPodsInUpdatePartition(its, sortedPods) {
+		updated, err := IsPodUpdated(its, pod)
+		if err != nil {
+			return false, err
+		}
+		if !updated || !isHealthy(pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func getInstanceSetForUpdatePlan(its *workloads.InstanceSet) *workloads.InstanceSet {
 	if its.Spec.MemberUpdateStrategy != nil {
 		return its
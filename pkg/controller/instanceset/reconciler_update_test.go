@@ -27,14 +27,17 @@ import (
 
 	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
 )
 
 var _ = Describe("update reconciler test", func() {
@@ -145,10 +148,21 @@ var _ = Describe("update reconciler test", func() {
 			Expect(err).Should(BeNil())
 			expectUpdatedPods(defaultTree, []string{"bar-3"})
 
+			By("reconcile with the held instance bar-3")
+			// expected: bar-3 is left alone, and bar-2 being deleted
+			heldTree, err := newTree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok := heldTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			root.Spec.HeldInstances = []workloads.HeldInstance{{Name: "bar-3"}}
+			_, err = reconciler.Reconcile(heldTree)
+			Expect(err).Should(BeNil())
+			expectUpdatedPods(heldTree, []string{"bar-2"})
+
 			By("reconcile with Partition=50% and MaxUnavailable=2")
 			partitionTree, err := newTree.DeepCopy()
 			Expect(err).Should(BeNil())
-			root, ok := partitionTree.GetRoot().(*workloads.InstanceSet)
+			root, ok = partitionTree.GetRoot().(*workloads.InstanceSet)
 			Expect(ok).Should(BeTrue())
 			partition := int32(3)
 			maxUnavailable := intstr.FromInt32(2)
@@ -187,6 +201,148 @@ var _ = Describe("update reconciler test", func() {
 			Expect(err).Should(BeNil())
 			expectUpdatedPods(partitionTree, []string{"bar-1"})
 
+			By("reconcile with UpdatePartition.Replicas=1 and MaxUnavailable=2")
+			updatePartitionTree, err := newTree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = updatePartitionTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			partitionReplicas := int32(1)
+			root.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					MaxUnavailable: &maxUnavailable,
+				},
+			}
+			root.Spec.UpdatePartition = &workloads.UpdatePartition{Replicas: &partitionReplicas}
+			objects := updatePartitionTree.List(&corev1.Pod{})
+			podList := make([]*corev1.Pod, 0, len(objects))
+			for _, object := range objects {
+				podList = append(podList, object.(*corev1.Pod))
+			}
+			ready, err := IsUpdatePartitionReady(root, podList)
+			Expect(err).Should(BeNil())
+			Expect(ready).Should(BeFalse())
+			// order: bar-3, bar-2, bar-1, bar-0, bar-foo-1, bar-foo-0, bar-hello-0
+			// expected: bar-3 being deleted
+			_, err = reconciler.Reconcile(updatePartitionTree)
+			Expect(err).Should(BeNil())
+			expectUpdatedPods(updatePartitionTree, []string{"bar-3"})
+
+			By("update 'bar-3' revision to the updated value")
+			updatePartitionTree, err = newTree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = updatePartitionTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			root.Spec.UpdatePartition = &workloads.UpdatePartition{Replicas: &partitionReplicas}
+			podList = podList[:0]
+			for _, object := range updatePartitionTree.List(&corev1.Pod{}) {
+				pod := object.(*corev1.Pod)
+				if pod.Name == "bar-3" {
+					makePodLatestRevision(pod)
+				}
+				podList = append(podList, pod)
+			}
+			ready, err = IsUpdatePartitionReady(root, podList)
+			Expect(err).Should(BeNil())
+			Expect(ready).Should(BeTrue())
+
+			By("reconcile with UpdatePartition.Roles=[follower] and MaxUnavailable=2")
+			updatePartitionTree, err = newTree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = updatePartitionTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			root.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					MaxUnavailable: &maxUnavailable,
+				},
+			}
+			root.Spec.UpdatePartition = &workloads.UpdatePartition{Roles: []string{"follower"}}
+			for _, object := range updatePartitionTree.List(&corev1.Pod{}) {
+				pod := object.(*corev1.Pod)
+				switch pod.Name {
+				case "bar-0", "bar-hello-0":
+					pod.Labels[constant.RoleLabelKey] = "follower"
+				case "bar-1":
+					pod.Labels[constant.RoleLabelKey] = "leader"
+				}
+			}
+			// expected: only the followers bar-0 and bar-hello-0 being deleted
+			_, err = reconciler.Reconcile(updatePartitionTree)
+			Expect(err).Should(BeNil())
+			expectUpdatedPods(updatePartitionTree, []string{"bar-0", "bar-hello-0"})
+
+			By("reconcile with MaxSurge=1")
+			surgeTree, err := newTree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = surgeTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			maxSurge := intstr.FromInt32(1)
+			root.Spec.MaxSurge = &maxSurge
+			action := &workloads.Action{Image: "foo", Command: []string{"bar"}}
+			root.Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{
+				MemberJoinAction:  action,
+				MemberLeaveAction: action,
+			}
+			getSurgeObject := func(object client.Object) client.Object {
+				object, err := surgeTree.Get(object)
+				Expect(err).Should(BeNil())
+				return object
+			}
+			object := getSurgeObject(builder.NewPodBuilder(namespace, "bar-1").GetObject())
+			object.GetLabels()[constant.RoleLabelKey] = "leader"
+			surgePod := builder.NewPodBuilder(namespace, "bar-surge-0").GetObject()
+			joinJob := builder.NewJobBuilder(namespace, "bar-surge-0-1-member-join").GetObject()
+			leaveJob := builder.NewJobBuilder(namespace, "bar-surge-0-1-member-leave").GetObject()
+			// expected: the surge instance is created, and no instance is taken down before it joins.
+			_, err = reconciler.Reconcile(surgeTree)
+			Expect(err).Should(BeNil())
+			Expect(surgeTree.List(&corev1.Pod{})).Should(HaveLen(int(replicas) + 1))
+			object = getSurgeObject(surgePod)
+			Expect(object).ShouldNot(BeNil())
+			Expect(object.GetLabels()).Should(HaveKeyWithValue(surgeInstanceLabelKey, "true"))
+			Expect(getSurgeObject(builder.NewPVCBuilder(namespace, "data-bar-surge-0").GetObject())).ShouldNot(BeNil())
+			object.(*corev1.Pod).Status.Phase = corev1.PodRunning
+			object.(*corev1.Pod).Status.Conditions = append(object.(*corev1.Pod).Status.Conditions, condition)
+			_, err = reconciler.Reconcile(surgeTree)
+			Expect(err).Should(BeNil())
+			Expect(surgeTree.List(&corev1.Pod{})).Should(HaveLen(int(replicas) + 1))
+			object = getSurgeObject(joinJob)
+			Expect(object).ShouldNot(BeNil())
+			// expected: bar-3 being deleted after the surge instance joins.
+			object.(*batchv1.Job).Status.Succeeded = 1
+			_, err = reconciler.Reconcile(surgeTree)
+			Expect(err).Should(BeNil())
+			Expect(surgeTree.List(&corev1.Pod{})).Should(HaveLen(int(replicas)))
+			Expect(getSurgeObject(builder.NewPodBuilder(namespace, "bar-3").GetObject())).Should(BeNil())
+
+			By("tear down the surge instance after the update completes")
+			surgeTree, err = newTree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = surgeTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			root.Spec.MaxSurge = &maxSurge
+			root.Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{
+				MemberJoinAction:  action,
+				MemberLeaveAction: action,
+			}
+			for _, object := range surgeTree.List(&corev1.Pod{}) {
+				makePodLatestRevision(object.(*corev1.Pod))
+			}
+			surgePod.Labels = map[string]string{surgeInstanceLabelKey: "true"}
+			joinJob.Labels = map[string]string{surgeActionLabelKey: rsm.MemberJoinActionType}
+			Expect(surgeTree.Add(surgePod, joinJob)).Should(Succeed())
+			_, err = reconciler.Reconcile(surgeTree)
+			Expect(err).Should(BeNil())
+			object = getSurgeObject(leaveJob)
+			Expect(object).ShouldNot(BeNil())
+			object.(*batchv1.Job).Status.Succeeded = 1
+			_, err = reconciler.Reconcile(surgeTree)
+			Expect(err).Should(BeNil())
+			Expect(getSurgeObject(surgePod)).Should(BeNil())
+			Expect(surgeTree.List(&corev1.Pod{})).Should(HaveLen(int(replicas)))
+			_, err = reconciler.Reconcile(surgeTree)
+			Expect(err).Should(BeNil())
+			Expect(surgeTree.List(&batchv1.Job{})).Should(BeEmpty())
+
 			By("reconcile with UpdateStrategy='OnDelete'")
 			onDeleteTree, err := newTree.DeepCopy()
 			Expect(err).Should(BeNil())
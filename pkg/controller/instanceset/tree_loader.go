@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -50,6 +51,11 @@ func (r *treeLoader) Load(ctx context.Context, reader client.Reader, req ctrl.Re
 		return nil, err
 	}
 
+	// load the nodes to track the topology domains of the instances if the placement policy is set
+	if err = loadNodes(ctx, reader, tree); err != nil {
+		return nil, err
+	}
+
 	tree.EventRecorder = recorder
 	tree.Logger = logger
 	tree.SetFinalizer(finalizer)
@@ -85,6 +91,7 @@ func ownedKinds() []client.ObjectList {
 		&corev1.PodList{},
 		&corev1.PersistentVolumeClaimList{},
 		&batchv1.JobList{},
+		&policyv1.PodDisruptionBudgetList{},
 	}
 }
 
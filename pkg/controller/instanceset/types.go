@@ -32,4 +32,18 @@ const (
 
 	finalizer = "instanceset.workloads.kubeblocks.io/finalizer"
 	managedBy = "InstanceSet"
+
+	// blueGreenSetLabelKey labels the instances with the set they belong to in the blue/green update.
+	blueGreenSetLabelKey = "workloads.kubeblocks.io/blue-green-set"
+	// blueGreenActionLabelKey labels the Jobs of the membership reconfiguration actions run by the blue/green update.
+	blueGreenActionLabelKey = "workloads.kubeblocks.io/blue-green-action"
+	greenInstanceNameSuffix = "green"
+
+	// heldInstanceLabelKey labels whether the instance is held for maintenance, which is set when any instance is held.
+	heldInstanceLabelKey = "workloads.kubeblocks.io/held"
+
+	// surgeInstanceLabelKey labels the extra instances created over the replicas during the rolling update.
+	surgeInstanceLabelKey = "workloads.kubeblocks.io/surge-instance"
+	// surgeActionLabelKey labels the Jobs of the membership reconfiguration actions run for the surge instances.
+	surgeActionLabelKey = "workloads.kubeblocks.io/surge-action"
 )
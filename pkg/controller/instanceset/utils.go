@@ -20,7 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package instanceset
 
 import (
+	"strconv"
+	"strings"
+
 	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -54,6 +58,143 @@ func mergeList[E any](src, dst *[]E, f func(E) func(E) bool) {
 	}
 }
 
+// isBlueGreenUpdate tells whether the instances are updated by the blue/green update.
+func isBlueGreenUpdate(its *workloads.InstanceSet) bool {
+	return its.Spec.MemberUpdateStrategy != nil && *its.Spec.MemberUpdateStrategy == workloads.BlueGreenUpdateStrategy
+}
+
+// isBlueGreenUpdating tells whether a blue/green update is in progress, the instances of the standby set
+// are managed by the blueGreenUpdateReconciler during the update.
+func isBlueGreenUpdating(its *workloads.InstanceSet) bool {
+	return isBlueGreenUpdate(its) && its.Status.BlueGreen != nil && len(its.Status.BlueGreen.Phase) > 0
+}
+
+func getActiveSet(its *workloads.InstanceSet) workloads.InstanceColor {
+	if its.Status.BlueGreen != nil && its.Status.BlueGreen.ActiveSet == workloads.GreenInstanceColor {
+		return workloads.GreenInstanceColor
+	}
+	return workloads.BlueInstanceColor
+}
+
+func getStandbySet(its *workloads.InstanceSet) workloads.InstanceColor {
+	if getActiveSet(its) == workloads.GreenInstanceColor {
+		return workloads.BlueInstanceColor
+	}
+	return workloads.GreenInstanceColor
+}
+
+// GetInstanceNamePrefix returns the name prefix of the active instances.
+func GetInstanceNamePrefix(its *workloads.InstanceSet) string {
+	return getInstanceNamePrefixOf(its, getActiveSet(its))
+}
+
+// getInstanceNamePrefixOf returns the name prefix of the instances in the set, the instances of the green set
+// are named after the InstanceSet with the "-green" suffix.
+func getInstanceNamePrefixOf(its *workloads.InstanceSet, set workloads.InstanceColor) string {
+	if set == workloads.GreenInstanceColor {
+		return its.Name + "-" + greenInstanceNameSuffix
+	}
+	return its.Name
+}
+
+// GetActiveInstanceName returns the name of the active instance with the same template and ordinal as the given one,
+// so that the instances specified by the name of either set, e.g. in the OpsRequests and the offline instances,
+// are resolved to the active instances after the blue/green update switched the sets.
+func GetActiveInstanceName(its *workloads.InstanceSet, name string) string {
+	return getInstanceNameOf(its, name, getActiveSet(its))
+}
+
+// getInstanceNameOf returns the name of the instance in the set with the same template and ordinal as the given one.
+func getInstanceNameOf(its *workloads.InstanceSet, name string, set workloads.InstanceColor) string {
+	// the green prefix is checked first, as it has the blue prefix as its prefix.
+	for _, prefix := range []string{getInstanceNamePrefixOf(its, workloads.GreenInstanceColor), its.Name} {
+		if strings.HasPrefix(name, prefix+"-") {
+			return getInstanceNamePrefixOf(its, set) + strings.TrimPrefix(name, prefix)
+		}
+	}
+	return name
+}
+
+// getOfflineInstancesOf returns the offline instances resolved to the names of the instances in the set.
+func getOfflineInstancesOf(its *workloads.InstanceSet, set workloads.InstanceColor) []string {
+	var offlineInstances []string
+	for _, name := range its.Spec.OfflineInstances {
+		offlineInstances = append(offlineInstances, getInstanceNameOf(its, name, set))
+	}
+	return offlineInstances
+}
+
+// IsStandbyInstance tells whether the pod belongs to the standby set of an ongoing blue/green update.
+func IsStandbyInstance(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	return isStandbyInstance(its, pod)
+}
+
+// isStandbyInstance tells whether the pod belongs to the standby set of an ongoing blue/green update.
+func isStandbyInstance(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	return isBlueGreenUpdating(its) && pod.Labels[blueGreenSetLabelKey] == string(getStandbySet(its))
+}
+
+// isSurgeInstance tells whether the pod is an extra instance created over the replicas during the rolling update.
+func isSurgeInstance(pod *corev1.Pod) bool {
+	return pod.Labels[surgeInstanceLabelKey] == "true"
+}
+
+// getHeldInstance returns the held instance of the pod, or nil if the pod is not held.
+func getHeldInstance(its *workloads.InstanceSet, podName string) *workloads.HeldInstance {
+	for i := range its.Spec.HeldInstances {
+		if its.Spec.HeldInstances[i].Name == podName {
+			return &its.Spec.HeldInstances[i]
+		}
+	}
+	return nil
+}
+
+func isHeldInstance(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	return getHeldInstance(its, pod.Name) != nil
+}
+
+// IsLeaderCandidate tells whether the leader role can be switched over to the pod.
+func IsLeaderCandidate(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	held := getHeldInstance(its, pod.Name)
+	return held == nil || !held.ExcludeFromLeaderCandidates
+}
+
+// setHeldInstanceLabel labels whether the pod is held if any instance is held, and labels the pod excluded from
+// the leader candidates, which is respected by the HA of lorry.
+func setHeldInstanceLabel(its *workloads.InstanceSet, pod *corev1.Pod) {
+	if IsLeaderCandidate(its, pod) {
+		delete(pod.Labels, constant.LeaderCandidateExcludedLabelKey)
+	}
+	if len(its.Spec.HeldInstances) == 0 {
+		return
+	}
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[heldInstanceLabelKey] = strconv.FormatBool(isHeldInstance(its, pod))
+	if !IsLeaderCandidate(its, pod) {
+		pod.Labels[constant.LeaderCandidateExcludedLabelKey] = strconv.FormatBool(true)
+	}
+}
+
+// AddHeldInstanceSvcSelector excludes the held instances from the Service which selects the instances by roles,
+// either of the InstanceSet or of the Component. The Service doesn't select the instances by the held label
+// until all of them are labeled.
+func AddHeldInstanceSvcSelector(its *workloads.InstanceSet, pods []*corev1.Pod, selectors map[string]string) {
+	if len(its.Spec.HeldInstances) == 0 {
+		return
+	}
+	if _, ok := selectors[constant.RoleLabelKey]; !ok {
+		return
+	}
+	for _, pod := range pods {
+		if _, ok := pod.Labels[heldInstanceLabelKey]; !ok {
+			return
+		}
+	}
+	selectors[heldInstanceLabelKey] = strconv.FormatBool(false)
+}
+
 func getMatchLabels(name string) map[string]string {
 	return map[string]string{
 		rsm.WorkloadsManagedByLabelKey: managedBy,
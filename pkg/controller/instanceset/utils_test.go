@@ -25,6 +25,10 @@ import (
 
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 )
 
 var _ = Describe("utils test", func() {
@@ -84,6 +88,40 @@ var _ = Describe("utils test", func() {
 		})
 	})
 
+	Context("held instances", func() {
+		It("should work well", func() {
+			its := builder.NewInstanceSetBuilder(namespace, name).AddMatchLabelsInMap(selectors).SetRoles(roles).GetObject()
+			its.Spec.HeldInstances = []workloads.HeldInstance{{Name: "bar-0", ExcludeFromLeaderCandidates: true}, {Name: "bar-1"}}
+			pod0 := builder.NewPodBuilder(namespace, "bar-0").GetObject()
+			pod1 := builder.NewPodBuilder(namespace, "bar-1").GetObject()
+			pod2 := builder.NewPodBuilder(namespace, "bar-2").GetObject()
+			Expect(IsLeaderCandidate(its, pod0)).Should(BeFalse())
+			Expect(IsLeaderCandidate(its, pod1)).Should(BeTrue())
+			Expect(IsLeaderCandidate(its, pod2)).Should(BeTrue())
+
+			svcSelectors := getSvcSelector(its, false)
+			AddHeldInstanceSvcSelector(its, []*corev1.Pod{pod0, pod1, pod2}, svcSelectors)
+			Expect(svcSelectors).ShouldNot(HaveKey(heldInstanceLabelKey))
+
+			for _, pod := range []*corev1.Pod{pod0, pod1, pod2} {
+				setHeldInstanceLabel(its, pod)
+			}
+			Expect(pod0.Labels).Should(HaveKeyWithValue(heldInstanceLabelKey, "true"))
+			Expect(pod1.Labels).Should(HaveKeyWithValue(heldInstanceLabelKey, "true"))
+			Expect(pod2.Labels).Should(HaveKeyWithValue(heldInstanceLabelKey, "false"))
+			AddHeldInstanceSvcSelector(its, []*corev1.Pod{pod0, pod1, pod2}, svcSelectors)
+			Expect(svcSelectors).Should(HaveKeyWithValue(heldInstanceLabelKey, "false"))
+
+			By("only the instance excluded from the leader candidates is labeled for the HA")
+			Expect(pod0.Labels).Should(HaveKeyWithValue(constant.LeaderCandidateExcludedLabelKey, "true"))
+			Expect(pod1.Labels).ShouldNot(HaveKey(constant.LeaderCandidateExcludedLabelKey))
+			Expect(pod2.Labels).ShouldNot(HaveKey(constant.LeaderCandidateExcludedLabelKey))
+			its.Spec.HeldInstances = nil
+			setHeldInstanceLabel(its, pod0)
+			Expect(pod0.Labels).ShouldNot(HaveKey(constant.LeaderCandidateExcludedLabelKey))
+		})
+	})
+
 	Context("mergeMap", func() {
 		It("should work well", func() {
 			src := map[string]string{
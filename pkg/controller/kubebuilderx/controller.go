@@ -27,6 +27,8 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 // TODO(free6om): this is a new reconciler framework in the very early stage leaving the following tasks to do:
@@ -48,6 +50,8 @@ type controller struct {
 	logger   logr.Logger
 
 	err error
+	// requeueErr is the delayed requeue error returned by the reconcilers, which is returned after the tree is committed.
+	requeueErr error
 
 	oldTree *ObjectTree
 	tree    *ObjectTree
@@ -55,6 +59,7 @@ type controller struct {
 
 func (c *controller) Prepare(reader TreeLoader) Controller {
 	c.oldTree, c.err = reader.Load(c.ctx, c.cli, c.req, c.recorder, c.logger)
+	c.requeueErr = nil
 	if c.err != nil {
 		return c
 	}
@@ -85,6 +90,13 @@ func (c *controller) Do(reconcilers ...Reconciler) Controller {
 		}
 
 		c.tree, c.err = reconciler.Reconcile(c.tree)
+		// the delayed requeue error doesn't stop the reconciliation, the tree is still committed.
+		if c.err != nil && c.tree != nil && intctrlutil.IsDelayedRequeueError(c.err) {
+			if c.requeueErr == nil {
+				c.requeueErr = c.err
+			}
+			c.err = nil
+		}
 		if c.err != nil {
 			return c
 		}
@@ -108,8 +120,10 @@ func (c *controller) Commit() error {
 	if err != nil {
 		return err
 	}
-	err = plan.Execute()
-	return err
+	if err = plan.Execute(); err != nil {
+		return err
+	}
+	return c.requeueErr
 }
 
 func NewController(ctx context.Context, cli client.Client, req ctrl.Request, recorder record.EventRecorder, logger logr.Logger) Controller {
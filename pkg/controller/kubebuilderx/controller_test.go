@@ -22,6 +22,7 @@ package kubebuilderx
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 var _ = Describe("controller test", func() {
@@ -75,6 +77,13 @@ var _ = Describe("controller test", func() {
 			reconcileErr := fmt.Errorf("reconcile with error")
 			err = controller.Prepare(&dummyLoader{tree: tree}).Do(&dummyReconciler{err: reconcileErr}).Commit()
 			Expect(err).Should(Equal(reconcileErr))
+
+			By("Reconcile with delayed requeue error")
+			requeueErr := intctrlutil.NewDelayedRequeueError(time.Second, "requeue")
+			err = controller.Prepare(&dummyLoader{tree: tree}).
+				Do(&dummyReconciler{err: requeueErr}, &dummyReconciler{preErr: reconcileCondErr}).
+				Commit()
+			Expect(err).Should(Equal(reconcileCondErr))
 		})
 	})
 })
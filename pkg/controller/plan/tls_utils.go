@@ -22,12 +22,18 @@ package plan
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -42,6 +48,11 @@ import (
 //  2. should avoid using Go template to call a function, this is too hacky & costly,
 //     should just call underlying registered Go template function.
 func ComposeTLSSecret(namespace, clusterName, componentName string) (*v1.Secret, error) {
+	return ComposeTLSSecretWithValidity(namespace, clusterName, componentName, DefaultTLSCertValidity)
+}
+
+// ComposeTLSSecretWithValidity composes a TLS secret object with a self-signed certificate valid for the duration.
+func ComposeTLSSecretWithValidity(namespace, clusterName, componentName string, validity time.Duration) (*v1.Secret, error) {
 	name := GenerateTLSSecretName(clusterName, componentName)
 	secret := builder.NewSecretBuilder(namespace, name).
 		AddLabels(constant.AppInstanceLabelKey, clusterName).
@@ -49,11 +60,15 @@ func ComposeTLSSecret(namespace, clusterName, componentName string) (*v1.Secret,
 		SetStringData(map[string]string{}).
 		GetObject()
 
-	const tpl = `{{- $cert := genCA "KubeBlocks" 3650 }}
+	days := int(validity.Hours() / 24)
+	if days < 1 {
+		days = 1
+	}
+	const tpl = `{{- $cert := genCA "KubeBlocks" .Days }}
 {{ $cert.Cert }}
 {{ $cert.Key }}
 `
-	out, err := buildFromTemplate(tpl, nil)
+	out, err := buildFromTemplate(tpl, map[string]int{"Days": days})
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +88,119 @@ func GenerateTLSSecretName(clusterName, componentName string) string {
 	return clusterName + "-" + componentName + "-tls-certs"
 }
 
+// ParseTLSCertificate parses the certificate stored in the secret with the key.
+func ParseTLSCertificate(secret *v1.Secret, key string) (*x509.Certificate, error) {
+	data, ok := secret.Data[key]
+	if !ok {
+		data = []byte(secret.StringData[key])
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("no PEM encoded certificate found in secret %s with key %s", secret.Name, key)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// BuildCABundle builds the CA bundle of the new CA and the previous one in the old bundle, the previous CA is
+// dropped if it has expired or can't be parsed. Only one previous CA is kept, so the bundle doesn't grow.
+func BuildCABundle(newCA, oldBundle []byte) []byte {
+	bundle := append([]byte{}, newCA...)
+	block, _ := pem.Decode(oldBundle)
+	if block == nil {
+		return bundle
+	}
+	if newBlock, _ := pem.Decode(newCA); newBlock != nil && bytes.Equal(newBlock.Bytes, block.Bytes) {
+		return bundle
+	}
+	prevCA, err := x509.ParseCertificate(block.Bytes)
+	if err != nil || time.Now().After(prevCA.NotAfter) {
+		return bundle
+	}
+	if len(bundle) > 0 && bundle[len(bundle)-1] != '\n' {
+		bundle = append(bundle, '\n')
+	}
+	return append(bundle, pem.EncodeToMemory(block)...)
+}
+
+// GetTLSCertRenewalTime returns the time when the certificate should be renewed,
+// defaults to the time when two thirds of the validity duration has passed.
+func GetTLSCertRenewalTime(cert *x509.Certificate, renewBefore *metav1.Duration) time.Time {
+	if renewBefore != nil && renewBefore.Duration > 0 {
+		return cert.NotAfter.Add(-renewBefore.Duration)
+	}
+	return cert.NotAfter.Add(-cert.NotAfter.Sub(cert.NotBefore) / 3)
+}
+
+// GetTLSCertVersion returns the version of the certificate, which changes when the certificate is rotated.
+func GetTLSCertVersion(cert *x509.Certificate) string {
+	return cert.SerialNumber.Text(16)
+}
+
+// ComposeCertManagerCertificate composes a cert-manager Certificate object, which stores the issued certificate
+// in the TLS secret of the component.
+func ComposeCertManagerCertificate(namespace, clusterName, componentName string, issuer *dbaasv1alpha1.Issuer) *unstructured.Unstructured {
+	svcName := constant.GenerateDefaultComponentServiceName(clusterName, componentName)
+	headlessSvcName := constant.GenerateDefaultComponentHeadlessServiceName(clusterName, componentName)
+	dnsNames := []any{
+		svcName,
+		svcName + "." + namespace,
+		svcName + "." + namespace + ".svc",
+		"*." + headlessSvcName,
+		"*." + headlessSvcName + "." + namespace,
+		"*." + headlessSvcName + "." + namespace + ".svc",
+	}
+	issuerRef := map[string]any{
+		"name":  issuer.CertManager.Name,
+		"kind":  "Issuer",
+		"group": CertManagerGroup,
+	}
+	if issuer.CertManager.Kind != "" {
+		issuerRef["kind"] = issuer.CertManager.Kind
+	}
+	if issuer.CertManager.Group != "" {
+		issuerRef["group"] = issuer.CertManager.Group
+	}
+	spec := map[string]any{
+		"secretName": GenerateTLSSecretName(clusterName, componentName),
+		"commonName": svcName,
+		"dnsNames":   dnsNames,
+		"issuerRef":  issuerRef,
+		"secretTemplate": map[string]any{
+			"labels": map[string]any{
+				constant.AppInstanceLabelKey: clusterName,
+				constant.KBManagedByKey:      constant.AppName,
+			},
+		},
+	}
+	if issuer.Duration != nil {
+		spec["duration"] = issuer.Duration.Duration.String()
+	}
+	if issuer.RenewBefore != nil {
+		spec["renewBefore"] = issuer.RenewBefore.Duration.String()
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	certificate.SetGroupVersionKind(CertManagerCertificateGVK)
+	certificate.SetNamespace(namespace)
+	certificate.SetName(GenerateTLSSecretName(clusterName, componentName))
+	certificate.SetLabels(map[string]string{
+		constant.AppInstanceLabelKey:    clusterName,
+		constant.KBAppComponentLabelKey: componentName,
+		constant.KBManagedByKey:         constant.AppName,
+	})
+	return certificate
+}
+
+const (
+	// DefaultTLSCertValidity is the default validity duration of the certificates issued by KubeBlocks.
+	DefaultTLSCertValidity = 3650 * 24 * time.Hour
+
+	CertManagerGroup = "cert-manager.io"
+)
+
+// CertManagerCertificateGVK is the GroupVersionKind of the cert-manager Certificate.
+var CertManagerCertificateGVK = schema.GroupVersionKind{Group: CertManagerGroup, Version: "v1", Kind: "Certificate"}
+
 func buildFromTemplate(tpl string, vars interface{}) (string, error) {
 	fmap := sprig.TxtFuncMap()
 	t := template.Must(template.New("tls").Funcs(fmap).Parse(tpl))
@@ -20,8 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package plan
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,6 +31,8 @@ import (
 	"github.com/golang/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -58,6 +62,75 @@ var _ = Describe("TLSUtilsTest", func() {
 		})
 	})
 
+	Context("TLS certificate rotation", func() {
+		It("should work well", func() {
+			validity := 30 * 24 * time.Hour
+			secret, err := ComposeTLSSecretWithValidity(namespace, "bar", "test", validity)
+			Expect(err).Should(BeNil())
+
+			By("parse the certificate from the secret")
+			cert, err := ParseTLSCertificate(secret, constant.CertName)
+			Expect(err).Should(BeNil())
+			Expect(cert.NotAfter.Sub(cert.NotBefore)).Should(BeNumerically("~", validity, time.Hour))
+			Expect(GetTLSCertVersion(cert)).ShouldNot(BeEmpty())
+			_, err = ParseTLSCertificate(secret, "not-exist")
+			Expect(err).ShouldNot(BeNil())
+
+			By("the certificate is renewed at 2/3 of the validity by default")
+			renewalTime := GetTLSCertRenewalTime(cert, nil)
+			Expect(cert.NotAfter.Sub(renewalTime)).Should(BeNumerically("~", validity/3, time.Hour))
+			renewalTime = GetTLSCertRenewalTime(cert, &metav1.Duration{Duration: 24 * time.Hour})
+			Expect(cert.NotAfter.Sub(renewalTime)).Should(Equal(24 * time.Hour))
+		})
+
+		It("keeps the previous CA in the CA bundle", func() {
+			oldSecret, err := ComposeTLSSecret(namespace, "bar", "test")
+			Expect(err).Should(BeNil())
+			newSecret, err := ComposeTLSSecret(namespace, "bar", "test")
+			Expect(err).Should(BeNil())
+			oldCA := bytes.TrimSpace([]byte(oldSecret.StringData[constant.CAName]))
+			newCA := bytes.TrimSpace([]byte(newSecret.StringData[constant.CAName]))
+
+			By("the bundle contains both the new and the previous CA")
+			bundle := BuildCABundle(newCA, oldCA)
+			Expect(bytes.HasPrefix(bundle, newCA)).Should(BeTrue())
+			Expect(bytes.Contains(bundle, oldCA)).Should(BeTrue())
+
+			By("only one previous CA is kept after the next rotation")
+			nextSecret, err := ComposeTLSSecret(namespace, "bar", "test")
+			Expect(err).Should(BeNil())
+			nextCA := bytes.TrimSpace([]byte(nextSecret.StringData[constant.CAName]))
+			nextBundle := BuildCABundle(nextCA, bundle)
+			Expect(bytes.Contains(nextBundle, newCA)).Should(BeTrue())
+			Expect(bytes.Contains(nextBundle, oldCA)).Should(BeFalse())
+
+			By("the CA is not duplicated, and the broken one is dropped")
+			Expect(BuildCABundle(newCA, newCA)).Should(Equal(newCA))
+			Expect(BuildCABundle(newCA, []byte("broken"))).Should(Equal(newCA))
+		})
+	})
+
+	Context("ComposeCertManagerCertificate function", func() {
+		It("should work well", func() {
+			issuer := &appsv1alpha1.Issuer{
+				Name:        appsv1alpha1.IssuerCertManager,
+				CertManager: &appsv1alpha1.CertManagerIssuerRef{Name: "ca-issuer", Kind: "ClusterIssuer"},
+				RenewBefore: &metav1.Duration{Duration: time.Hour},
+			}
+			certificate := ComposeCertManagerCertificate(namespace, "bar", "test", issuer)
+			Expect(certificate.GroupVersionKind()).Should(Equal(CertManagerCertificateGVK))
+			Expect(certificate.GetNamespace()).Should(Equal(namespace))
+			secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+			Expect(secretName).Should(Equal(GenerateTLSSecretName("bar", "test")))
+			issuerKind, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "kind")
+			Expect(issuerKind).Should(Equal("ClusterIssuer"))
+			renewBefore, _, _ := unstructured.NestedString(certificate.Object, "spec", "renewBefore")
+			Expect(renewBefore).Should(Equal("1h0m0s"))
+			dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+			Expect(dnsNames).ShouldNot(BeEmpty())
+		})
+	})
+
 	Context("CheckTLSSecretRef function", func() {
 		It("should work well", func() {
 			ctx := context.Background()
@@ -56,6 +56,12 @@ const (
 	jobTypePromote              = "promote"
 	jobScenarioMembership       = "membership-reconfiguration"
 	jobScenarioUpdate           = "pod-update"
+	jobScenarioInstanceUpdate   = "instance-update"
+
+	// the types of the membership reconfiguration actions run by the InstanceSet controller during the update.
+	SwitchoverActionType  = jobTypeSwitchover
+	MemberJoinActionType  = jobTypeMemberJoinNotifying
+	MemberLeaveActionType = jobTypeMemberLeaveNotifying
 
 	roleProbeContainerName       = "kb-role-probe"
 	roleProbeBinaryName          = "lorry"
@@ -332,6 +332,12 @@ func buildAction(rsm *workloads.InstanceSet, actionName, actionType, actionScena
 		GetObject()
 }
 
+// BuildMembershipAction builds the Job of the membership reconfiguration action run by the InstanceSet controller,
+// the actionType is one of SwitchoverActionType, MemberJoinActionType and MemberLeaveActionType.
+func BuildMembershipAction(rsm *workloads.InstanceSet, actionName, actionType string, leader, target string) *batchv1.Job {
+	return buildAction(rsm, actionName, actionType, jobScenarioInstanceUpdate, leader, target)
+}
+
 func buildActionPodTemplate(rsm *workloads.InstanceSet, env []corev1.EnvVar, actionType string) *corev1.PodTemplateSpec {
 	credential := rsm.Spec.Credential
 	credentialEnv := make([]corev1.EnvVar, 0)
@@ -0,0 +1,411 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// DryRunAction defines the action recorded by the DryRunClient.
+type DryRunAction string
+
+const (
+	DryRunCreate DryRunAction = "Create"
+	DryRunUpdate DryRunAction = "Update"
+	DryRunDelete DryRunAction = "Delete"
+)
+
+// DryRunChange records an object changed through the DryRunClient.
+type DryRunChange struct {
+	Action DryRunAction
+	// Original is the object before the dry run, it is nil if the object is created.
+	Original client.Object
+	// Object is the object after the dry run.
+	Object client.Object
+}
+
+type dryRunKey struct {
+	gvk schema.GroupVersionKind
+	client.ObjectKey
+}
+
+// DryRunClient is a client.Client which records the write operations instead of sending them to the API server.
+// The reads return the objects as if the recorded writes had been applied, so that a sequence of reconciliations
+// can be simulated on top of the live objects.
+type DryRunClient struct {
+	client.Client
+
+	mu        sync.Mutex
+	objects   map[dryRunKey]client.Object
+	originals map[dryRunKey]client.Object
+	deleted   map[dryRunKey]bool
+	keys      []dryRunKey
+}
+
+var _ client.Client = &DryRunClient{}
+
+// NewDryRunClient creates a DryRunClient which reads the live objects from cli.
+func NewDryRunClient(cli client.Client) *DryRunClient {
+	return &DryRunClient{
+		Client:    cli,
+		objects:   map[dryRunKey]client.Object{},
+		originals: map[dryRunKey]client.Object{},
+		deleted:   map[dryRunKey]bool{},
+	}
+}
+
+// Changes returns the recorded changes in the order of their first write.
+// the objects which are created and deleted during the dry run are omitted.
+func (c *DryRunClient) Changes() []DryRunChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var changes []DryRunChange
+	for _, key := range c.keys {
+		original := c.originals[key]
+		switch {
+		case c.deleted[key] && original == nil:
+			continue
+		case c.deleted[key]:
+			changes = append(changes, DryRunChange{Action: DryRunDelete, Original: original, Object: original})
+		case original == nil:
+			changes = append(changes, DryRunChange{Action: DryRunCreate, Object: c.objects[key]})
+		default:
+			changes = append(changes, DryRunChange{Action: DryRunUpdate, Original: original, Object: c.objects[key]})
+		}
+	}
+	return changes
+}
+
+func (c *DryRunClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	dKey, err := c.keyOf(obj, key)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	stored, recorded := c.objects[dKey]
+	deleted := c.deleted[dKey]
+	c.mu.Unlock()
+	switch {
+	case deleted:
+		return newDryRunNotFoundError(dKey)
+	case recorded:
+		return c.copyInto(stored, obj)
+	default:
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+}
+
+func (c *DryRunClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(list, c.Scheme())
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	listed := map[dryRunKey]bool{}
+	var result []runtime.Object
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			result = append(result, item)
+			continue
+		}
+		key := dryRunKey{gvk: gvk, ObjectKey: client.ObjectKeyFromObject(obj)}
+		listed[key] = true
+		if c.deleted[key] {
+			continue
+		}
+		if stored, ok := c.objects[key]; ok {
+			result = append(result, stored.DeepCopyObject())
+			continue
+		}
+		result = append(result, item)
+	}
+	// the objects created during the dry run.
+	for _, key := range c.keys {
+		if key.gvk != gvk || listed[key] || c.deleted[key] {
+			continue
+		}
+		stored := c.objects[key]
+		if listOpts.Namespace != "" && stored.GetNamespace() != listOpts.Namespace {
+			continue
+		}
+		if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(stored.GetLabels())) {
+			continue
+		}
+		result = append(result, stored.DeepCopyObject())
+	}
+	return meta.SetList(list, result)
+}
+
+func (c *DryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if obj.GetName() == "" && obj.GetGenerateName() != "" {
+		obj.SetName(obj.GetGenerateName() + utilrand.String(5))
+	}
+	key, err := c.keyOf(obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	exists, err := c.exists(ctx, key, obj)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return apierrors.NewAlreadyExists(schema.GroupResource{Group: key.gvk.Group, Resource: key.gvk.Kind}, key.Name)
+	}
+	if reflect.ValueOf(obj).Elem().FieldByName("Spec").IsValid() {
+		obj.SetGeneration(1)
+	}
+	c.record(key, nil, obj, false)
+	return nil
+}
+
+func (c *DryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.write(ctx, obj, false)
+}
+
+// Patch records the object as it is, which has been modified to the expected state by the caller.
+func (c *DryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.write(ctx, obj, false)
+}
+
+func (c *DryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	key, err := c.keyOf(obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	_, original, err := c.current(ctx, key, obj)
+	if err != nil {
+		return err
+	}
+	c.record(key, original, obj, true)
+	return nil
+}
+
+func (c *DryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	gvk.Kind += "List"
+	listObj, err := c.Scheme().New(gvk)
+	if err != nil {
+		return err
+	}
+	list, ok := listObj.(client.ObjectList)
+	if !ok {
+		return apierrors.NewBadRequest("unsupported list type " + gvk.String())
+	}
+	deleteAllOfOpts := &client.DeleteAllOfOptions{}
+	deleteAllOfOpts.ApplyOptions(opts)
+	if err = c.List(ctx, list, &deleteAllOfOpts.ListOptions); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if itemObj, ok := item.(client.Object); ok {
+			if err = c.Delete(ctx, itemObj); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *DryRunClient) Status() client.SubResourceWriter {
+	return &dryRunSubResourceClient{DryRunClient: c, subResource: "status"}
+}
+
+func (c *DryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{DryRunClient: c, subResource: subResource}
+}
+
+// write records the update of an existing object.
+// as what the API server does, the status is ignored when updating the object and only the status is updated
+// when updating the status sub-resource, the generation is increased if the spec is changed.
+func (c *DryRunClient) write(ctx context.Context, obj client.Object, subResource bool) error {
+	key, err := c.keyOf(obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	current, original, err := c.current(ctx, key, obj)
+	if err != nil {
+		return err
+	}
+	var stored client.Object
+	if subResource {
+		stored = current.DeepCopyObject().(client.Object)
+		if !copyField(obj, stored, "Status") {
+			stored = obj
+		}
+	} else {
+		stored = obj.DeepCopyObject().(client.Object)
+		copyField(current, stored, "Status")
+		if !fieldEqual(current, stored, "Spec") {
+			stored.SetGeneration(current.GetGeneration() + 1)
+		}
+	}
+	c.record(key, original, stored, false)
+	return c.copyInto(stored, obj)
+}
+
+func (c *DryRunClient) record(key dryRunKey, original, obj client.Object, deleted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.objects[key]; !ok {
+		c.keys = append(c.keys, key)
+		if original != nil {
+			c.originals[key] = original.DeepCopyObject().(client.Object)
+		}
+	}
+	c.objects[key] = obj.DeepCopyObject().(client.Object)
+	c.deleted[key] = deleted
+}
+
+// current returns the object with the recorded changes and the live object before the dry run, the object must exist.
+func (c *DryRunClient) current(ctx context.Context, key dryRunKey, obj client.Object) (client.Object, client.Object, error) {
+	c.mu.Lock()
+	stored, recorded := c.objects[key]
+	original := c.originals[key]
+	deleted := c.deleted[key]
+	c.mu.Unlock()
+	if deleted {
+		return nil, nil, newDryRunNotFoundError(key)
+	}
+	if recorded {
+		return stored, original, nil
+	}
+	liveObj := obj.DeepCopyObject().(client.Object)
+	if err := c.Client.Get(ctx, key.ObjectKey, liveObj); err != nil {
+		return nil, nil, err
+	}
+	return liveObj, liveObj, nil
+}
+
+func (c *DryRunClient) exists(ctx context.Context, key dryRunKey, obj client.Object) (bool, error) {
+	c.mu.Lock()
+	_, recorded := c.objects[key]
+	deleted := c.deleted[key]
+	c.mu.Unlock()
+	if recorded {
+		return !deleted, nil
+	}
+	liveObj := obj.DeepCopyObject().(client.Object)
+	if err := c.Client.Get(ctx, key.ObjectKey, liveObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *DryRunClient) keyOf(obj client.Object, objKey client.ObjectKey) (dryRunKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return dryRunKey{}, err
+	}
+	return dryRunKey{gvk: gvk, ObjectKey: objKey}, nil
+}
+
+func (c *DryRunClient) copyInto(src, dst client.Object) error {
+	srcValue := reflect.ValueOf(src.DeepCopyObject())
+	dstValue := reflect.ValueOf(dst)
+	if srcValue.Type() == dstValue.Type() {
+		dstValue.Elem().Set(srcValue.Elem())
+		return nil
+	}
+	return c.Scheme().Convert(src, dst, nil)
+}
+
+// copyField copies the field of src to dst, it returns false if the field does not exist.
+func copyField(src, dst client.Object, name string) bool {
+	srcField := reflect.ValueOf(src).Elem().FieldByName(name)
+	dstField := reflect.ValueOf(dst).Elem().FieldByName(name)
+	if !srcField.IsValid() || !dstField.IsValid() || srcField.Type() != dstField.Type() {
+		return false
+	}
+	dstField.Set(srcField)
+	return true
+}
+
+func fieldEqual(obj1, obj2 client.Object, name string) bool {
+	field1 := reflect.ValueOf(obj1).Elem().FieldByName(name)
+	field2 := reflect.ValueOf(obj2).Elem().FieldByName(name)
+	if !field1.IsValid() || !field2.IsValid() {
+		return true
+	}
+	return reflect.DeepEqual(field1.Interface(), field2.Interface())
+}
+
+func newDryRunNotFoundError(key dryRunKey) error {
+	return apierrors.NewNotFound(schema.GroupResource{Group: key.gvk.Group, Resource: key.gvk.Kind}, key.Name)
+}
+
+// dryRunSubResourceClient records the writes to the sub-resource as the writes to the object.
+type dryRunSubResourceClient struct {
+	*DryRunClient
+	subResource string
+}
+
+var _ client.SubResourceClient = &dryRunSubResourceClient{}
+
+func (c *dryRunSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return c.DryRunClient.Client.SubResource(c.subResource).Get(ctx, obj, subResource, opts...)
+}
+
+// Create does nothing, as the sub-resources which support creation, such as eviction, take no effect in the dry run.
+func (c *dryRunSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (c *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return c.DryRunClient.write(ctx, obj, true)
+}
+
+func (c *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return c.DryRunClient.write(ctx, obj, true)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDryRunClient(t *testing.T) {
+	ctx := context.Background()
+	newConfigMap := func(name string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "test"}},
+			Data:       data,
+		}
+	}
+	liveCli := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+		WithObjects(newConfigMap("cm-update", map[string]string{"k": "v1"}), newConfigMap("cm-delete", nil)).
+		Build()
+	cli := NewDryRunClient(liveCli)
+
+	// update
+	cm := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: "cm-update", Namespace: "default"}, cm); err != nil {
+		t.Fatal(err)
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	cm.Data["k"] = "v2"
+	if err := cli.Patch(ctx, cm, patch); err != nil {
+		t.Fatal(err)
+	}
+	// create
+	if err := cli.Create(ctx, newConfigMap("cm-create", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.Create(ctx, newConfigMap("cm-create", nil)); !apierrors.IsAlreadyExists(err) {
+		t.Errorf("expect AlreadyExists error, but got %v", err)
+	}
+	// delete
+	if err := cli.Delete(ctx, newConfigMap("cm-delete", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.Delete(ctx, newConfigMap("cm-not-exist", nil)); !apierrors.IsNotFound(err) {
+		t.Errorf("expect NotFound error, but got %v", err)
+	}
+
+	// the live objects are not changed.
+	liveCM := &corev1.ConfigMap{}
+	if err := liveCli.Get(ctx, client.ObjectKey{Name: "cm-update", Namespace: "default"}, liveCM); err != nil {
+		t.Fatal(err)
+	}
+	if liveCM.Data["k"] != "v1" {
+		t.Errorf("expect the live object not changed, but got %s", liveCM.Data["k"])
+	}
+	if err := liveCli.Get(ctx, client.ObjectKey{Name: "cm-create", Namespace: "default"}, liveCM); !apierrors.IsNotFound(err) {
+		t.Errorf("expect the object not created, but got %v", err)
+	}
+
+	// the reads return the recorded objects.
+	if err := cli.Get(ctx, client.ObjectKey{Name: "cm-update", Namespace: "default"}, cm); err != nil || cm.Data["k"] != "v2" {
+		t.Errorf("expect the recorded object, but got %v, %v", cm.Data, err)
+	}
+	if err := cli.Get(ctx, client.ObjectKey{Name: "cm-delete", Namespace: "default"}, cm); !apierrors.IsNotFound(err) {
+		t.Errorf("expect NotFound error, but got %v", err)
+	}
+	cmList := &corev1.ConfigMapList{}
+	if err := cli.List(ctx, cmList, client.InNamespace("default"), client.MatchingLabels{"app": "test"}); err != nil {
+		t.Fatal(err)
+	}
+	listed := map[string]string{}
+	for _, item := range cmList.Items {
+		listed[item.Name] = item.Data["k"]
+	}
+	if len(listed) != 2 || listed["cm-update"] != "v2" {
+		t.Errorf("unexpected list result: %v", listed)
+	}
+
+	changes := cli.Changes()
+	if len(changes) != 3 {
+		t.Fatalf("expect 3 changes, but got %d", len(changes))
+	}
+	expectedActions := []DryRunAction{DryRunUpdate, DryRunCreate, DryRunDelete}
+	for i, change := range changes {
+		if change.Action != expectedActions[i] {
+			t.Errorf("expect action %s, but got %s", expectedActions[i], change.Action)
+		}
+	}
+	if changes[0].Original.(*corev1.ConfigMap).Data["k"] != "v1" || changes[0].Object.(*corev1.ConfigMap).Data["k"] != "v2" {
+		t.Errorf("unexpected update change: %v", changes[0])
+	}
+}
@@ -20,8 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package controllerutil
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -65,7 +67,7 @@ func GenShardingCompSpecList(ctx context.Context, cli client.Reader,
 			compNameMap[genCompName] = genCompName
 		}
 	case len(undeletedShardingCompSpecs) > int(shardingSpec.Shards):
-		// TODO: order by?
+		// the shards marked as removing are placed at the end, so they are removed first.
 		compSpecList = compSpecList[:int(shardingSpec.Shards)]
 	}
 	return compSpecList, nil
@@ -89,6 +91,11 @@ func listNCheckShardingComponents(ctx context.Context, cli client.Reader,
 		}
 	}
 
+	// move the shards marked as removing to the end
+	slices.SortStableFunc(undeletedShardingComps, func(a, b appsv1alpha1.Component) int {
+		return cmp.Compare(boolToInt(IsShardRemoving(&a)), boolToInt(IsShardRemoving(&b)))
+	})
+
 	if cluster.Generation == cluster.Status.ObservedGeneration && len(undeletedShardingComps) != int(shardingSpec.Shards) {
 		return nil, nil, errors.New("sharding components are not correct when cluster is not updating")
 	}
@@ -109,6 +116,19 @@ func ListShardingComponents(ctx context.Context, cli client.Reader,
 	return compList.Items, nil
 }
 
+// IsShardRemoving checks if the shard component is marked to be removed.
+func IsShardRemoving(comp *appsv1alpha1.Component) bool {
+	_, ok := comp.Annotations[constant.ShardRemovingAnnotationKey]
+	return ok
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // listUndeletedShardingCompSpecs lists undeleted sharding component specs.
 func listUndeletedShardingCompSpecs(ctx context.Context, cli client.Reader, cluster *appsv1alpha1.Cluster, shardingSpec *appsv1alpha1.ShardingSpec) ([]*appsv1alpha1.ClusterComponentSpec, error) {
 	return listShardingCompSpecs(ctx, cli, cluster, shardingSpec, false)
@@ -113,5 +113,33 @@ var _ = Describe("cluster shard component", func() {
 			Expect(shardingCompSpecList).ShouldNot(BeNil())
 			Expect(len(shardingCompSpecList)).Should(BeEquivalentTo(2))
 		})
+
+		It("remove the shards marked as removing first", func() {
+			By("create mock sharding component objects and mark the first one as removing")
+			for i, name := range []string{mysqlShardingCompName + "-a", mysqlShardingCompName + "-b"} {
+				factory := testapps.NewComponentFactory(testCtx.DefaultNamespace, cluster.Name+"-"+name, "").
+					AddLabels(constant.AppInstanceLabelKey, cluster.Name).
+					AddLabels(constant.KBAppClusterUIDLabelKey, string(cluster.UID)).
+					AddLabels(constant.KBAppShardingNameLabelKey, mysqlShardingName).
+					SetReplicas(1)
+				if i == 0 {
+					factory.AddAnnotations(constant.ShardRemovingAnnotationKey, "true")
+				}
+				compKey := client.ObjectKeyFromObject(factory.Create(&testCtx).GetObject())
+				Eventually(testapps.CheckObjExists(&testCtx, compKey, &appsv1alpha1.Component{}, true)).Should(Succeed())
+			}
+
+			shardingSpec := &appsv1alpha1.ShardingSpec{
+				Template: appsv1alpha1.ClusterComponentSpec{
+					Replicas: 2,
+				},
+				Name:   mysqlShardingName,
+				Shards: 1,
+			}
+			shardingCompSpecList, err := GenShardingCompSpecList(testCtx.Ctx, k8sClient, cluster, shardingSpec)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(shardingCompSpecList).Should(HaveLen(1))
+			Expect(shardingCompSpecList[0].Name).Should(Equal(mysqlShardingCompName + "-b"))
+		})
 	})
 })
@@ -62,6 +62,9 @@ const (
 	ConnectionPasswordAnnotationKey = "dataprotection.kubeblocks.io/connection-password"
 	// GeminiAcknowledgedAnnotationKey indicates whether Gemini has acknowledged the backup.
 	GeminiAcknowledgedAnnotationKey = "dataprotection.kubeblocks.io/gemini-acknowledged"
+	// ImportedBackupAnnotationKey indicates the backup is imported from another Kubernetes cluster,
+	// its status is set by the importer and the backup controller will not start a new backup for it.
+	ImportedBackupAnnotationKey = "dataprotection.kubeblocks.io/imported"
 )
 
 // label keys
@@ -105,6 +105,10 @@ var ComponentVersionSignature = func(appsv1alpha1.ComponentVersion, *appsv1alpha
 }
 var OpsDefinitionSignature = func(_ appsv1alpha1.OpsDefinition, _ *appsv1alpha1.OpsDefinition, _ appsv1alpha1.OpsDefinitionList, _ *appsv1alpha1.OpsDefinitionList) {
 }
+var OpsPipelineSignature = func(_ appsv1alpha1.OpsPipeline, _ *appsv1alpha1.OpsPipeline, _ appsv1alpha1.OpsPipelineList, _ *appsv1alpha1.OpsPipelineList) {
+}
+var OpsFleetSignature = func(_ appsv1alpha1.OpsFleet, _ *appsv1alpha1.OpsFleet, _ appsv1alpha1.OpsFleetList, _ *appsv1alpha1.OpsFleetList) {
+}
 var OpsRequestSignature = func(_ appsv1alpha1.OpsRequest, _ *appsv1alpha1.OpsRequest, _ appsv1alpha1.OpsRequestList, _ *appsv1alpha1.OpsRequestList) {
 }
 var ConfigConstraintSignature = func(_ appsv1beta1.ConfigConstraint, _ *appsv1beta1.ConfigConstraint, _ appsv1beta1.ConfigConstraintList, _ *appsv1beta1.ConfigConstraintList) {
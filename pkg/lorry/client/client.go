@@ -22,6 +22,7 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	corev1 "k8s.io/api/core/v1"
@@ -162,6 +163,27 @@ func (cli *lorryClient) RevokeUserRole(ctx context.Context, userName, roleName s
 	return err
 }
 
+func (cli *lorryClient) UpdatePassword(ctx context.Context, userName, password string, retainCurrent bool) error {
+	parameters := map[string]any{
+		"userName":      userName,
+		"password":      password,
+		"retainCurrent": retainCurrent,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(UpdatePasswordOp), http.MethodPost, req)
+	return err
+}
+
+func (cli *lorryClient) DiscardPassword(ctx context.Context, userName, password string) error {
+	parameters := map[string]any{
+		"userName": userName,
+		"password": password,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(DiscardPasswordOp), http.MethodPost, req)
+	return err
+}
+
 func (cli *lorryClient) Switchover(ctx context.Context, primary, candidate string, force bool) error {
 	parameters := map[string]any{
 		"primary":   primary,
@@ -173,6 +195,24 @@ func (cli *lorryClient) Switchover(ctx context.Context, primary, candidate strin
 	return err
 }
 
+// GetLag gets the replication lag of the replica.
+func (cli *lorryClient) GetLag(ctx context.Context) (int64, error) {
+	resp, err := cli.Request(ctx, string(GetLagOperation), http.MethodGet, nil)
+	if err != nil {
+		return 0, err
+	}
+	lag, ok := resp["lag"]
+	if !ok {
+		return 0, errors.New("lag not found in the response")
+	}
+	// the numbers are decoded as float64 from the json response.
+	value, ok := lag.(float64)
+	if !ok {
+		return 0, fmt.Errorf("invalid lag: %v", lag)
+	}
+	return int64(value), nil
+}
+
 // ListUsers lists all normal users created
 func (cli *lorryClient) ListUsers(ctx context.Context) ([]map[string]any, error) {
 	resp, err := cli.Request(ctx, string(ListUsersOp), http.MethodGet, nil)
@@ -259,6 +299,17 @@ func (cli *lorryClient) DataLoad(ctx context.Context) error {
 	return err
 }
 
+// Reconfigure sends a reconfigure request to Lorry, the engine reloads the TLS certificate of the serial number
+// if it is specified, and the request fails if the mounted certificate has not been refreshed to it.
+func (cli *lorryClient) Reconfigure(ctx context.Context, tlsCertSerialNumber string) error {
+	var req map[string]any
+	if tlsCertSerialNumber != "" {
+		req = map[string]any{"parameters": map[string]any{TLSCertSerialNumberParameter: tlsCertSerialNumber}}
+	}
+	_, err := cli.Request(ctx, string(ReconfigureOperation), http.MethodPost, req)
+	return err
+}
+
 func (cli *lorryClient) Request(ctx context.Context, operation, method string, req map[string]any) (map[string]any, error) {
 	if cli.requester == nil {
 		return nil, errors.New("lorry client's requester must be set")
@@ -126,6 +126,35 @@ func (mr *MockClientMockRecorder) DescribeUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeUser", reflect.TypeOf((*MockClient)(nil).DescribeUser), arg0, arg1)
 }
 
+// DiscardPassword mocks base method.
+func (m *MockClient) DiscardPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardPassword indicates an expected call of DiscardPassword.
+func (mr *MockClientMockRecorder) DiscardPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardPassword", reflect.TypeOf((*MockClient)(nil).DiscardPassword), arg0, arg1, arg2)
+}
+
+// GetLag mocks base method.
+func (m *MockClient) GetLag(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLag", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLag indicates an expected call of GetLag.
+func (mr *MockClientMockRecorder) GetLag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLag", reflect.TypeOf((*MockClient)(nil).GetLag), arg0)
+}
+
 // GetRole mocks base method.
 func (m *MockClient) GetRole(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -269,6 +298,20 @@ func (mr *MockClientMockRecorder) Rebuild(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockClient)(nil).Rebuild), arg0)
 }
 
+// Reconfigure mocks base method.
+func (m *MockClient) Reconfigure(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconfigure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconfigure indicates an expected call of Reconfigure.
+func (mr *MockClientMockRecorder) Reconfigure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconfigure", reflect.TypeOf((*MockClient)(nil).Reconfigure), arg0, arg1)
+}
+
 // RevokeUserRole mocks base method.
 func (m *MockClient) RevokeUserRole(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -310,3 +353,17 @@ func (mr *MockClientMockRecorder) Unlock(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockClient)(nil).Unlock), arg0)
 }
+
+// UpdatePassword mocks base method.
+func (m *MockClient) UpdatePassword(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockClientMockRecorder) UpdatePassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockClient)(nil).UpdatePassword), arg0, arg1, arg2, arg3)
+}
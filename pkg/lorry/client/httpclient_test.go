@@ -247,6 +247,29 @@ var _ = Describe("Lorry HTTP Client", func() {
 		})
 	})
 
+	Context("update password", func() {
+		var lorryClient *HTTPClient
+
+		BeforeEach(func() {
+			lorryClient, _ = NewHTTPClientWithPod(pod)
+			Expect(lorryClient).ShouldNot(BeNil())
+		})
+
+		It("success", func() {
+			mockDBManager.EXPECT().UpdatePassword(gomock.Any(), "user-test", "password-test", true).Return(nil)
+			Expect(lorryClient.UpdatePassword(context.TODO(), "user-test", "password-test", true)).Should(Succeed())
+			mockDBManager.EXPECT().DiscardPassword(gomock.Any(), "user-test", "password-old").Return(nil)
+			Expect(lorryClient.DiscardPassword(context.TODO(), "user-test", "password-old")).Should(Succeed())
+		})
+
+		It("not implemented", func() {
+			mockDBManager.EXPECT().UpdatePassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf(msg))
+			err := lorryClient.UpdatePassword(context.TODO(), "user-test", "password-test", false)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(msg))
+		})
+	})
+
 	Context("describe user", func() {
 		var lorryClient *HTTPClient
 		var userInfo *models.UserInfo
@@ -513,6 +536,29 @@ var _ = Describe("Lorry HTTP Client", func() {
 		})
 	})
 
+	Context("get replication lag", func() {
+		var lorryClient *HTTPClient
+
+		BeforeEach(func() {
+			lorryClient, _ = NewHTTPClientWithPod(pod)
+			Expect(lorryClient).ShouldNot(BeNil())
+		})
+
+		It("success", func() {
+			mockDBManager.EXPECT().GetLag(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+			mockDCSStore.EXPECT().GetClusterFromCache().Return(&dcs.Cluster{})
+			Expect(lorryClient.GetLag(context.TODO())).Should(Equal(int64(10)))
+		})
+
+		It("failed", func() {
+			mockDBManager.EXPECT().GetLag(gomock.Any(), gomock.Any()).Return(int64(0), fmt.Errorf(msg))
+			mockDCSStore.EXPECT().GetClusterFromCache().Return(&dcs.Cluster{})
+			_, err := lorryClient.GetLag(context.TODO())
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(msg))
+		})
+	})
+
 	Context("switchover", func() {
 		var lorryClient *HTTPClient
 
@@ -33,6 +33,10 @@ type Client interface {
 	RevokeUserRole(ctx context.Context, userName, roleName string) error
 	ListUsers(ctx context.Context) ([]map[string]any, error)
 	ListSystemAccounts(ctx context.Context) ([]map[string]any, error)
+	// UpdatePassword changes the password of the user, the current password keeps working until
+	// it is discarded by DiscardPassword if retainCurrent is true and the engine supports it.
+	UpdatePassword(ctx context.Context, userName, password string, retainCurrent bool) error
+	DiscardPassword(ctx context.Context, userName, password string) error
 
 	// JoinMember sends a join member operation request to Lorry, located on the target pod that is about to join.
 	JoinMember(ctx context.Context) error
@@ -41,6 +45,10 @@ type Client interface {
 	LeaveMember(ctx context.Context) error
 
 	Switchover(ctx context.Context, primary, candidate string, force bool) error
+
+	// GetLag returns the replication lag of the target replica behind the leader.
+	GetLag(ctx context.Context) (int64, error)
+
 	Lock(ctx context.Context) error
 	Unlock(ctx context.Context) error
 	PostProvision(ctx context.Context, componentNames, podNames, podIPs, podHostNames, podHostIPs string) error
@@ -50,4 +58,5 @@ type Client interface {
 	Rebuild(ctx context.Context) error
 	DataDump(ctx context.Context) error
 	DataLoad(ctx context.Context) error
+	Reconfigure(ctx context.Context, tlsCertSerialNumber string) error
 }
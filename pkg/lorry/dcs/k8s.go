@@ -256,6 +256,7 @@ func (store *KubernetesStore) GetMembers() ([]Member, error) {
 		if pod.Spec.HostNetwork {
 			member.UseIP = true
 		}
+		member.ExcludeFromLeaderCandidates = pod.Labels[constant.LeaderCandidateExcludedLabelKey] == "true"
 		member.resource = pod.DeepCopy()
 	}
 
@@ -240,7 +240,9 @@ type Member struct {
 	HAPort    string
 	UID       string
 	UseIP     bool
-	resource  any
+	// ExcludeFromLeaderCandidates indicates the member is held for maintenance, and it should not take the leader.
+	ExcludeFromLeaderCandidates bool
+	resource                    any
 }
 
 func (m *Member) GetName() string {
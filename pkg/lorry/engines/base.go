@@ -227,6 +227,14 @@ func (mgr *DBManagerBase) GrantUserRole(context.Context, string, string) error {
 	return errors.New("not implemented")
 }
 
+func (mgr *DBManagerBase) UpdatePassword(context.Context, string, string, bool) error {
+	return errors.New("not implemented")
+}
+
+func (mgr *DBManagerBase) DiscardPassword(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+
 func (mgr *DBManagerBase) RevokeUserRole(context.Context, string, string) error {
 	return errors.New("not implemented")
 }
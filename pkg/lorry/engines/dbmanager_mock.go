@@ -144,6 +144,20 @@ func (mr *MockDBManagerMockRecorder) DescribeUser(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeUser", reflect.TypeOf((*MockDBManager)(nil).DescribeUser), arg0, arg1)
 }
 
+// DiscardPassword mocks base method.
+func (m *MockDBManager) DiscardPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardPassword indicates an expected call of DiscardPassword.
+func (mr *MockDBManagerMockRecorder) DiscardPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardPassword", reflect.TypeOf((*MockDBManager)(nil).DiscardPassword), arg0, arg1, arg2)
+}
+
 // Exec mocks base method.
 func (m *MockDBManager) Exec(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -741,3 +755,17 @@ func (mr *MockDBManagerMockRecorder) Unlock(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockDBManager)(nil).Unlock), arg0)
 }
+
+// UpdatePassword mocks base method.
+func (m *MockDBManager) UpdatePassword(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockDBManagerMockRecorder) UpdatePassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockDBManager)(nil).UpdatePassword), arg0, arg1, arg2, arg3)
+}
@@ -117,6 +117,11 @@ type DBManager interface {
 	DescribeUser(context.Context, string) (*models.UserInfo, error)
 	GrantUserRole(context.Context, string, string) error
 	RevokeUserRole(context.Context, string, string) error
+	// UpdatePassword changes the password of the user, the current password keeps working
+	// if retainCurrent is true and the engine supports multiple passwords for a user.
+	UpdatePassword(ctx context.Context, userName, password string, retainCurrent bool) error
+	// DiscardPassword discards the password retained by UpdatePassword.
+	DiscardPassword(ctx context.Context, userName, password string) error
 
 	GetPort() (int, error)
 
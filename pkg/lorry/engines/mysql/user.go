@@ -46,6 +46,9 @@ const (
 	deleteUserSQL         = "DROP USER IF EXISTS '%s'@'%%';"
 	grantSQL              = "GRANT %s TO '%s'@'%%';"
 	revokeSQL             = "REVOKE %s FROM '%s'@'%%';"
+	updatePasswordSQL     = "ALTER USER '%s'@'%%' IDENTIFIED BY '%s';"
+	retainPasswordSQL     = "ALTER USER '%s'@'%%' IDENTIFIED BY '%s' RETAIN CURRENT PASSWORD;"
+	discardPasswordSQL    = "ALTER USER '%s'@'%%' DISCARD OLD PASSWORD;"
 	listSystemAccountsSQL = "SELECT user AS userName FROM mysql.user WHERE host = '%' and user like 'kb%';"
 )
 
@@ -138,6 +141,34 @@ func (mgr *Manager) DeleteUser(ctx context.Context, userName string) error {
 	return nil
 }
 
+// UpdatePassword changes the password of the user, the dual password is supported since MySQL 8.0.14.
+func (mgr *Manager) UpdatePassword(ctx context.Context, userName, password string, retainCurrent bool) error {
+	sql := fmt.Sprintf(updatePasswordSQL, userName, password)
+	if retainCurrent {
+		sql = fmt.Sprintf(retainPasswordSQL, userName, password)
+	}
+
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
+func (mgr *Manager) DiscardPassword(ctx context.Context, userName, _ string) error {
+	sql := fmt.Sprintf(discardPasswordSQL, userName)
+
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+
+	return nil
+}
+
 func (mgr *Manager) GrantUserRole(ctx context.Context, userName, roleName string) error {
 	// render sql stmts
 	roleDesc, _ := role2Priv(roleName)
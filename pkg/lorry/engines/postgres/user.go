@@ -63,6 +63,7 @@ const (
 	dropUserTpl           = "DROP USER IF EXISTS %s;"
 	grantTpl              = "GRANT %s TO %s;"
 	revokeTpl             = "REVOKE %s FROM %s;"
+	updatePasswordTpl     = "ALTER USER %s WITH PASSWORD '%s';"
 	listSystemAccountsTpl = "SELECT rolname FROM pg_catalog.pg_roles WHERE pg_roles.rolname LIKE 'kb%'"
 )
 
@@ -146,6 +147,24 @@ func (mgr *Manager) DeleteUser(ctx context.Context, userName string) error {
 	return nil
 }
 
+// UpdatePassword changes the password of the user, PostgreSQL supports only one password for a user,
+// so the current password is always replaced.
+func (mgr *Manager) UpdatePassword(ctx context.Context, userName, password string, _ bool) error {
+	sql := fmt.Sprintf(updatePasswordTpl, userName, password)
+
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
+func (mgr *Manager) DiscardPassword(context.Context, string, string) error {
+	return nil
+}
+
 func (mgr *Manager) GrantUserRole(ctx context.Context, userName, roleName string) error {
 	var sql string
 	if models.SuperUserRole.EqualTo(roleName) {
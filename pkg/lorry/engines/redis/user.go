@@ -37,6 +37,10 @@ const (
 	dropUserTpl   = "ACL DELUSER %s"
 	grantTpl      = "ACL SETUSER %s %s"
 	revokeTpl     = "ACL SETUSER %s %s"
+
+	updatePasswordTpl  = "ACL SETUSER %s resetpass >%s"
+	addPasswordTpl     = "ACL SETUSER %s >%s"
+	discardPasswordTpl = "ACL SETUSER %s <%s"
 )
 
 var (
@@ -138,6 +142,34 @@ func (mgr *Manager) CreateUser(ctx context.Context, userName, password string) e
 	return nil
 }
 
+// UpdatePassword changes the password of the user, the ACL user of Redis can have multiple passwords.
+func (mgr *Manager) UpdatePassword(ctx context.Context, userName, password string, retainCurrent bool) error {
+	sql := fmt.Sprintf(updatePasswordTpl, userName, password)
+	if retainCurrent {
+		sql = fmt.Sprintf(addPasswordTpl, userName, password)
+	}
+
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute command failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
+func (mgr *Manager) DiscardPassword(ctx context.Context, userName, password string) error {
+	sql := fmt.Sprintf(discardPasswordTpl, userName, password)
+
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute command failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
 func (mgr *Manager) DeleteUser(ctx context.Context, userName string) error {
 	sql := fmt.Sprintf(dropUserTpl, userName)
 
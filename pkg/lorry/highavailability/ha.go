@@ -288,6 +288,10 @@ func (ha *Ha) Start() {
 func (ha *Ha) IsHealthiestMember(ctx context.Context, cluster *dcs3.Cluster) bool {
 	currentMemberName := ha.dbManager.GetCurrentMemberName()
 	currentMember := cluster.GetMemberWithName(currentMemberName)
+	if currentMember != nil && currentMember.ExcludeFromLeaderCandidates {
+		ha.logger.Info("current member is held and excluded from the leader candidates")
+		return false
+	}
 	if cluster.Switchover != nil {
 		switchover := cluster.Switchover
 		leader := switchover.Leader
@@ -336,6 +340,9 @@ func (ha *Ha) HasOtherHealthyMember(cluster *dcs3.Cluster) bool {
 	}
 
 	for _, other := range otherMembers {
+		if other == nil || other.ExcludeFromLeaderCandidates {
+			continue
+		}
 		if ha.dbManager.IsMemberHealthy(ha.ctx, cluster, other) {
 			if isLagging, _ := ha.dbManager.IsMemberLagging(ha.ctx, cluster, other); !isLagging {
 				return true
@@ -353,7 +360,8 @@ func (ha *Ha) isMinimumLag(ctx context.Context, cluster *dcs3.Cluster, member *d
 	}
 
 	for _, m := range cluster.Members {
-		if m.Name != member.Name {
+		// the members excluded from the leader candidates don't compete for the leader.
+		if m.Name != member.Name && !m.ExcludeFromLeaderCandidates {
 			isLagging, lag := ha.dbManager.IsMemberLagging(ctx, cluster, &m)
 			// There are other members with smaller lag
 			if !isLagging && lag < currentLag {
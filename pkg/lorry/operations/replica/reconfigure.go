@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package replica
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+// reconfigure makes the engine reload its configuration and the TLS certificates.
+type reconfigure struct {
+	operations.Base
+	logger  logr.Logger
+	Command []string
+}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.ReconfigureOperation)), &reconfigure{})
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *reconfigure) Init(_ context.Context) error {
+	actionJSON := viper.GetString(constant.KBEnvActionCommands)
+	if actionJSON != "" {
+		actionCommands := map[string][]string{}
+		err := json.Unmarshal([]byte(actionJSON), &actionCommands)
+		if err != nil {
+			s.logger.Info("get action commands failed", "error", err.Error())
+			return err
+		}
+		cmd, ok := actionCommands[constant.ReconfigureAction]
+		if ok && len(cmd) > 0 {
+			s.Command = cmd
+		}
+	}
+	return nil
+}
+
+// PreCheck checks that the mounted TLS certificate has been refreshed to the expected one, if it is specified,
+// the files of the secret volume are refreshed by the kubelet some time after the secret is updated.
+func (s *reconfigure) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	if len(s.Command) == 0 {
+		return errors.New("the reconfigure action is not defined")
+	}
+	serialNumber := req.GetString(util.TLSCertSerialNumberParameter)
+	if serialNumber == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(constant.MountPath, constant.CertName))
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return errors.New("no PEM encoded certificate found in the mounted TLS certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+	if cert.SerialNumber.Text(16) != serialNumber {
+		return errors.Errorf("the mounted TLS certificate %s is not refreshed to %s yet", cert.SerialNumber.Text(16), serialNumber)
+	}
+	return nil
+}
+
+func (s *reconfigure) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	return nil, doCommonAction(ctx, s.logger, "reconfigure", s.Command)
+}
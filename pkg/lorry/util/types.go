@@ -63,6 +63,8 @@ const (
 	GrantUserRoleOp      OperationKind = "grantUserRole"
 	RevokeUserRoleOp     OperationKind = "revokeUserRole"
 	ListSystemAccountsOp OperationKind = "listSystemAccounts"
+	UpdatePasswordOp     OperationKind = "updatePassword"
+	DiscardPasswordOp    OperationKind = "discardPassword"
 
 	JoinMemberOperation  OperationKind = "joinMember"
 	LeaveMemberOperation OperationKind = "leaveMember"
@@ -73,8 +75,12 @@ const (
 	OperationFailed            = "Failed"
 	DefaultProbeTimeoutSeconds = 2
 
-	DataDumpOperation OperationKind = "dataDump"
-	DataLoadOperation OperationKind = "dataLoad"
+	DataDumpOperation    OperationKind = "dataDump"
+	DataLoadOperation    OperationKind = "dataLoad"
+	ReconfigureOperation OperationKind = "reconfigure"
+
+	// TLSCertSerialNumberParameter specifies the serial number of the TLS certificate to be reloaded by the reconfigure operation.
+	TLSCertSerialNumberParameter = "tlsCertSerialNumber"
 
 	// this is a general script template, which can be used for all kinds of exec request to databases.
 	DataScriptRequestTpl string = `
@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// CfgKeySecretStoreBackend specifies the backend of the external secret store, such as "vault".
+	// The external secret store is disabled if it is empty.
+	CfgKeySecretStoreBackend = "SECRET_STORE_BACKEND"
+)
+
+// ErrNotFound is returned if the secret doesn't exist in the store.
+var ErrNotFound = errors.New("secret not found in the secret store")
+
+// Store is an external store of the secrets, such as the passwords of the system accounts.
+// The secrets are addressed by slash-separated paths, which are relative to the root of KubeBlocks in the store.
+type Store interface {
+	// Get returns the data of the secret, or ErrNotFound if it doesn't exist.
+	Get(ctx context.Context, path string) (map[string][]byte, error)
+
+	// Put creates or overwrites the secret.
+	Put(ctx context.Context, path string, data map[string][]byte) error
+
+	// Delete deletes the secret, it succeeds if the secret doesn't exist.
+	Delete(ctx context.Context, path string) error
+}
+
+// PodInjector is implemented by the stores which can deliver the secrets into the pods directly,
+// without the Kubernetes secrets.
+type PodInjector interface {
+	// InjectionEnabled checks whether the secrets are injected into the pods.
+	InjectionEnabled() bool
+
+	// InjectionAnnotations returns the pod annotations to write the key of the secret to the file
+	// named by name in the pods.
+	InjectionAnnotations(name, path, key string) map[string]string
+
+	// InjectedFilePath returns the path of the file named by name in the pods.
+	InjectedFilePath(name string) string
+}
+
+// Factory creates a store from the configuration of KubeBlocks.
+type Factory func() (Store, error)
+
+var (
+	factories = map[string]Factory{}
+
+	once         sync.Once
+	defaultStore Store
+	defaultErr   error
+)
+
+// RegisterBackend registers the factory of a store backend, it is called in the init function of the backends.
+func RegisterBackend(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// Default returns the store configured by CfgKeySecretStoreBackend, or nil if it is not configured.
+func Default() (Store, error) {
+	once.Do(func() {
+		defaultStore, defaultErr = New(viper.GetString(CfgKeySecretStoreBackend))
+	})
+	return defaultStore, defaultErr
+}
+
+// DefaultInjector returns the default store and its PodInjector if the secrets are injected into the pods from it,
+// the Kubernetes secrets of the system accounts are not created then. It returns nil if the secrets are not injected.
+func DefaultInjector() (Store, PodInjector, error) {
+	store, err := Default()
+	if err != nil || store == nil {
+		return nil, nil, err
+	}
+	injector, ok := store.(PodInjector)
+	if !ok || !injector.InjectionEnabled() {
+		return nil, nil, nil
+	}
+	return store, injector, nil
+}
+
+// SetDefault overrides the default store, it is used in tests.
+func SetDefault(store Store) {
+	once.Do(func() {})
+	defaultStore, defaultErr = store, nil
+}
+
+// New creates a store of the backend, or returns nil if the backend is empty.
+func New(backend string) (Store, error) {
+	if backend == "" {
+		return nil, nil
+	}
+	factory, ok := factories[backend]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret store backend: %s", backend)
+	}
+	return factory()
+}
+
+// AccountPath returns the path of the system account of the component in the store.
+func AccountPath(namespace, clusterName, compName, accountName string) string {
+	return path.Join(namespace, clusterName, compName, "accounts", accountName)
+}
+
+// AccountInjectionName returns the name of the file which the password of the system account is injected to.
+func AccountInjectionName(accountName string) string {
+	return constant.KBLowerPrefix + "-account-" + accountName
+}
+
+// ConnCredentialPath returns the path of the connection credential of the cluster in the store.
+func ConnCredentialPath(namespace, clusterName string) string {
+	return path.Join(namespace, clusterName, "conn-credential")
+}
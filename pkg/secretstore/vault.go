@@ -0,0 +1,269 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	VaultBackend = "vault"
+
+	// CfgKeyVaultAddr is the address of the Vault server, such as "http://vault.vault:8200".
+	CfgKeyVaultAddr = "VAULT_ADDR"
+	// CfgKeyVaultToken is the token to access Vault, the Kubernetes auth method is used if it is empty.
+	CfgKeyVaultToken = "VAULT_TOKEN"
+	// CfgKeyVaultAuthRole is the role of KubeBlocks for the Kubernetes auth method.
+	CfgKeyVaultAuthRole = "VAULT_AUTH_ROLE"
+	// CfgKeyVaultAuthMount is the mount path of the Kubernetes auth method.
+	CfgKeyVaultAuthMount = "VAULT_AUTH_MOUNT"
+	// CfgKeyVaultKVMount is the mount path of the KV version 2 secrets engine.
+	CfgKeyVaultKVMount = "VAULT_KV_MOUNT"
+	// CfgKeyVaultPathPrefix is the root path of KubeBlocks in the KV secrets engine.
+	CfgKeyVaultPathPrefix = "VAULT_PATH_PREFIX"
+	// CfgKeyVaultInjectRole is the role of the database pods for the Vault Agent Injector,
+	// the secrets are not injected into the pods if it is empty.
+	CfgKeyVaultInjectRole = "VAULT_INJECT_ROLE"
+)
+
+// serviceAccountTokenFile is the token of the service account of KubeBlocks, which is used to login Vault.
+var serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+func init() {
+	viper.SetDefault(CfgKeyVaultAuthMount, "kubernetes")
+	viper.SetDefault(CfgKeyVaultKVMount, "secret")
+	viper.SetDefault(CfgKeyVaultPathPrefix, "kubeblocks")
+	RegisterBackend(VaultBackend, func() (Store, error) {
+		return NewVaultStore(VaultConfig{
+			Address:    viper.GetString(CfgKeyVaultAddr),
+			Token:      viper.GetString(CfgKeyVaultToken),
+			AuthRole:   viper.GetString(CfgKeyVaultAuthRole),
+			AuthMount:  viper.GetString(CfgKeyVaultAuthMount),
+			KVMount:    viper.GetString(CfgKeyVaultKVMount),
+			PathPrefix: viper.GetString(CfgKeyVaultPathPrefix),
+			InjectRole: viper.GetString(CfgKeyVaultInjectRole),
+		})
+	})
+}
+
+// VaultConfig is the configuration of the Vault store.
+type VaultConfig struct {
+	Address    string
+	Token      string
+	AuthRole   string
+	AuthMount  string
+	KVMount    string
+	PathPrefix string
+	InjectRole string
+}
+
+// vaultStore stores the secrets in the KV version 2 secrets engine of HashiCorp Vault.
+type vaultStore struct {
+	config VaultConfig
+	client *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+var _ Store = &vaultStore{}
+var _ PodInjector = &vaultStore{}
+
+func NewVaultStore(config VaultConfig) (Store, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("the address of Vault is required")
+	}
+	if config.Token == "" && config.AuthRole == "" {
+		return nil, fmt.Errorf("either the token or the Kubernetes auth role of Vault is required")
+	}
+	config.Address = strings.TrimSuffix(config.Address, "/")
+	return &vaultStore{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+		token:  config.Token,
+	}, nil
+}
+
+func (s *vaultStore) Get(ctx context.Context, secretPath string) (map[string][]byte, error) {
+	resp := struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}{}
+	if err := s.request(ctx, http.MethodGet, s.kvPath("data", secretPath), nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Data.Data == nil {
+		// the latest version is deleted
+		return nil, ErrNotFound
+	}
+	data := make(map[string][]byte, len(resp.Data.Data))
+	for k, v := range resp.Data.Data {
+		data[k] = []byte(v)
+	}
+	return data, nil
+}
+
+func (s *vaultStore) Put(ctx context.Context, secretPath string, data map[string][]byte) error {
+	values := make(map[string]string, len(data))
+	for k, v := range data {
+		values[k] = string(v)
+	}
+	return s.request(ctx, http.MethodPost, s.kvPath("data", secretPath), map[string]any{"data": values}, nil)
+}
+
+func (s *vaultStore) Delete(ctx context.Context, secretPath string) error {
+	// delete all the versions and the metadata of the secret
+	err := s.request(ctx, http.MethodDelete, s.kvPath("metadata", secretPath), nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// InjectionEnabled checks whether the role of the database pods for the Vault Agent Injector is configured.
+func (s *vaultStore) InjectionEnabled() bool {
+	return s.config.InjectRole != ""
+}
+
+// InjectionAnnotations returns the annotations of the Vault Agent Injector, the secret is rendered to the file
+// /vault/secrets/<name> by an init container before the containers of the pod are started.
+func (s *vaultStore) InjectionAnnotations(name, secretPath, key string) map[string]string {
+	if s.config.InjectRole == "" {
+		return nil
+	}
+	kvPath := s.kvPath("data", secretPath)
+	return map[string]string{
+		"vault.hashicorp.com/agent-inject":                  "true",
+		"vault.hashicorp.com/agent-pre-populate-only":       "true",
+		"vault.hashicorp.com/role":                          s.config.InjectRole,
+		"vault.hashicorp.com/agent-inject-secret-" + name:   kvPath,
+		"vault.hashicorp.com/agent-inject-template-" + name: fmt.Sprintf(`{{- with secret "%s" -}}{{ index .Data.data "%s" }}{{- end }}`, kvPath, key),
+	}
+}
+
+// InjectedFilePath returns the path of the file rendered by the Vault Agent Injector.
+func (s *vaultStore) InjectedFilePath(name string) string {
+	return path.Join("/vault/secrets", name)
+}
+
+func (s *vaultStore) kvPath(kind, secretPath string) string {
+	return path.Join(s.config.KVMount, kind, s.config.PathPrefix, secretPath)
+}
+
+func (s *vaultStore) request(ctx context.Context, method, apiPath string, body any, out any) error {
+	token, err := s.getToken(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.do(ctx, method, apiPath, token, body, out)
+	if errors.Is(err, errPermissionDenied) && s.config.Token == "" {
+		// the token got by the Kubernetes auth method may expire, login again
+		s.mu.Lock()
+		s.token = ""
+		s.mu.Unlock()
+		if token, err = s.getToken(ctx); err != nil {
+			return err
+		}
+		err = s.do(ctx, method, apiPath, token, body, out)
+	}
+	return err
+}
+
+func (s *vaultStore) getToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" {
+		return s.token, nil
+	}
+	jwt, err := os.ReadFile(serviceAccountTokenFile)
+	if err != nil {
+		return "", err
+	}
+	resp := struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}{}
+	loginPath := path.Join("auth", s.config.AuthMount, "login")
+	if err = s.do(ctx, http.MethodPost, loginPath, "", map[string]any{"role": s.config.AuthRole, "jwt": string(jwt)}, &resp); err != nil {
+		return "", fmt.Errorf("failed to login Vault with the Kubernetes auth method: %s", err.Error())
+	}
+	s.token = resp.Auth.ClientToken
+	return s.token, nil
+}
+
+var errPermissionDenied = errors.New("permission denied")
+
+func (s *vaultStore) do(ctx context.Context, method, apiPath, token string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.config.Address+"/v1/"+apiPath, reader)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusForbidden:
+		return errPermissionDenied
+	case resp.StatusCode >= http.StatusBadRequest:
+		return fmt.Errorf("vault request %s %s failed with status %d: %s", method, apiPath, resp.StatusCode, string(data))
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFakeVault starts a fake Vault server which implements the KV version 2 API and the Kubernetes auth method.
+func newFakeVault(token string) *httptest.Server {
+	var (
+		mu      sync.Mutex
+		secrets = map[string]map[string]string{}
+	)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/v1/auth/kubernetes/login" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": token}})
+			return
+		}
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		secretPath := strings.TrimPrefix(r.URL.Path, "/v1/secret/")
+		key := strings.TrimPrefix(strings.TrimPrefix(secretPath, "data/"), "metadata/")
+		switch r.Method {
+		case http.MethodPost:
+			body := struct {
+				Data map[string]string `json:"data"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			secrets[key] = body.Data
+		case http.MethodGet:
+			data, ok := secrets[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+		case http.MethodDelete:
+			delete(secrets, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+	secretPath := AccountPath("default", "mycluster", "mysql", "root")
+	if _, err := store.Get(ctx, secretPath); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expect ErrNotFound, but got %v", err)
+	}
+	data := map[string][]byte{"username": []byte("root"), "password": []byte("p@ssw0rd")}
+	if err := store.Put(ctx, secretPath, data); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Get(ctx, secretPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got["password"]) != "p@ssw0rd" || string(got["username"]) != "root" {
+		t.Errorf("unexpected secret data: %v", got)
+	}
+	if err = store.Delete(ctx, secretPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Get(ctx, secretPath); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expect ErrNotFound after deletion, but got %v", err)
+	}
+	if err = store.Delete(ctx, secretPath); err != nil {
+		t.Fatalf("expect deleting a nonexistent secret to succeed, but got %v", err)
+	}
+}
+
+func TestVaultStore(t *testing.T) {
+	server := newFakeVault("root")
+	defer server.Close()
+
+	store, err := NewVaultStore(VaultConfig{Address: server.URL, Token: "root", KVMount: "secret", PathPrefix: "kubeblocks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+
+	store, _ = NewVaultStore(VaultConfig{Address: server.URL, Token: "invalid", KVMount: "secret", PathPrefix: "kubeblocks"})
+	if _, err = store.Get(context.Background(), "foo"); !errors.Is(err, errPermissionDenied) {
+		t.Errorf("expect permission denied, but got %v", err)
+	}
+}
+
+func TestVaultStoreKubernetesAuth(t *testing.T) {
+	server := newFakeVault("k8s-token")
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("jwt"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func(file string) { serviceAccountTokenFile = file }(serviceAccountTokenFile)
+	serviceAccountTokenFile = tokenFile
+
+	store, err := NewVaultStore(VaultConfig{Address: server.URL, AuthRole: "kubeblocks", AuthMount: "kubernetes",
+		KVMount: "secret", PathPrefix: "kubeblocks", InjectRole: "database"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+
+	annotations := store.(PodInjector).InjectionAnnotations("root", AccountPath("default", "mycluster", "mysql", "root"), "password")
+	if annotations["vault.hashicorp.com/role"] != "database" ||
+		annotations["vault.hashicorp.com/agent-inject-secret-root"] != "secret/data/kubeblocks/default/mycluster/mysql/accounts/root" {
+		t.Errorf("unexpected injection annotations: %v", annotations)
+	}
+	injector := store.(PodInjector)
+	if !injector.InjectionEnabled() || injector.InjectedFilePath("root") != "/vault/secrets/root" {
+		t.Errorf("unexpected injection of the secrets")
+	}
+}
+
+// TestVaultDevServer runs against a local Vault dev server. The binary is taken from VAULT_BIN, which
+// `make test` downloads, or from PATH. Without it only the fake server of TestVaultStore covers the store.
+func TestVaultDevServer(t *testing.T) {
+	vaultBin := os.Getenv("VAULT_BIN")
+	if vaultBin == "" {
+		var err error
+		if vaultBin, err = exec.LookPath("vault"); err != nil {
+			t.Skip("vault is not installed, run `make vault` and set VAULT_BIN to run against a real server")
+		}
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	cmd := exec.Command(vaultBin, "server", "-dev", "-dev-root-token-id=root", "-dev-listen-address="+addr)
+	if err = cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	store, err := NewVaultStore(VaultConfig{Address: fmt.Sprintf("http://%s", addr), Token: "root", KVMount: "secret", PathPrefix: "kubeblocks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// wait for the dev server to be ready
+	for i := 0; ; i++ {
+		if _, err = store.Get(context.Background(), "ready"); errors.Is(err, ErrNotFound) {
+			break
+		}
+		if i > 50 {
+			t.Fatalf("the vault dev server is not ready: %v", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	testStore(t, store)
+}
+
+func TestNew(t *testing.T) {
+	if store, err := New(""); store != nil || err != nil {
+		t.Errorf("expect no store if the backend is not configured, but got %v, %v", store, err)
+	}
+	if _, err := New("unknown"); err == nil {
+		t.Error("expect an error for the unknown backend")
+	}
+}